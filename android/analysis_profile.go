@@ -0,0 +1,265 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"runtime/metrics"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/blueprint"
+	"google.golang.org/protobuf/proto"
+
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
+)
+
+// generateBuildActionsPass is the name of the analysis pass that calls GenerateAndroidBuildActions.
+const generateBuildActionsPass = "generate_build_actions"
+
+// singletonPassPrefix is prepended to the names of singletons to name their analysis passes, as
+// singletons and mutators may have the same name.
+const singletonPassPrefix = "singleton:"
+
+// analysisProfiler records the time spent in each analysis pass, i.e. each mutator,
+// GenerateAndroidBuildActions and each singleton, broken down by module type. It is only created
+// when SOONG_PROFILE_ANALYSIS is set, all its methods do nothing on a nil analysisProfiler.
+//
+// Blueprint runs the passes one after the other, although a single pass may visit modules in
+// parallel. That allows attributing the allocations between the start of a pass and the start of
+// the next one to the pass, which isn't possible for a single module.
+type analysisProfiler struct {
+	lock   sync.Mutex
+	passes []*analysisPass
+	byName map[string]*analysisPass
+}
+
+type analysisPass struct {
+	name       string
+	start      time.Time
+	end        time.Time
+	cumulative time.Duration
+
+	// The allocation counters when the pass started and when the next one started.
+	startAllocs, endAllocs allocCounters
+
+	moduleTypes map[string]*moduleTypeProfile
+}
+
+type moduleTypeProfile struct {
+	variants      int
+	cumulative    time.Duration
+	max           time.Duration
+	slowestModule string
+}
+
+type allocCounters struct {
+	count, size uint64
+}
+
+func newAnalysisProfiler() *analysisProfiler {
+	return &analysisProfiler{byName: make(map[string]*analysisPass)}
+}
+
+func readAllocCounters() allocCounters {
+	samples := []metrics.Sample{
+		{Name: "/gc/heap/allocs:objects"},
+		{Name: "/gc/heap/allocs:bytes"},
+	}
+	metrics.Read(samples)
+	var counters allocCounters
+	if samples[0].Value.Kind() == metrics.KindUint64 {
+		counters.count = samples[0].Value.Uint64()
+	}
+	if samples[1].Value.Kind() == metrics.KindUint64 {
+		counters.size = samples[1].Value.Uint64()
+	}
+	return counters
+}
+
+// profile calls f, and records the time it took for a module of the given type in the given pass.
+// moduleType is empty for singletons.
+func (p *analysisProfiler) profile(pass, moduleType, moduleName string, f func()) {
+	if p == nil {
+		f()
+		return
+	}
+
+	start := time.Now()
+	p.startPass(pass, start)
+	f()
+	end := time.Now()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	profile := p.byName[pass]
+	if end.After(profile.end) {
+		profile.end = end
+	}
+	elapsed := end.Sub(start)
+	profile.cumulative += elapsed
+
+	if moduleType == "" {
+		return
+	}
+	typeProfile := profile.moduleTypes[moduleType]
+	if typeProfile == nil {
+		typeProfile = &moduleTypeProfile{}
+		profile.moduleTypes[moduleType] = typeProfile
+	}
+	typeProfile.variants++
+	typeProfile.cumulative += elapsed
+	if elapsed > typeProfile.max {
+		typeProfile.max = elapsed
+		typeProfile.slowestModule = moduleName
+	}
+}
+
+// startPass starts a pass the first time it is called with a given name, which also ends the
+// allocation accounting of the previous pass.
+func (p *analysisProfiler) startPass(name string, start time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.byName[name]; ok {
+		return
+	}
+	allocs := readAllocCounters()
+	if len(p.passes) > 0 {
+		p.passes[len(p.passes)-1].endAllocs = allocs
+	}
+	pass := &analysisPass{
+		name:        name,
+		start:       start,
+		end:         start,
+		startAllocs: allocs,
+		moduleTypes: make(map[string]*moduleTypeProfile),
+	}
+	p.passes = append(p.passes, pass)
+	p.byName[name] = pass
+}
+
+// bottomUpMutator returns a mutator that profiles each call to m.
+func (p *analysisProfiler) bottomUpMutator(name string, m blueprint.BottomUpMutator) blueprint.BottomUpMutator {
+	if p == nil {
+		return m
+	}
+	return func(ctx blueprint.BottomUpMutatorContext) {
+		p.profile(name, ctx.ModuleType(), ctx.ModuleName(), func() { m(ctx) })
+	}
+}
+
+// topDownMutator returns a mutator that profiles each call to m.
+func (p *analysisProfiler) topDownMutator(name string, m blueprint.TopDownMutator) blueprint.TopDownMutator {
+	if p == nil {
+		return m
+	}
+	return func(ctx blueprint.TopDownMutatorContext) {
+		p.profile(name, ctx.ModuleType(), ctx.ModuleName(), func() { m(ctx) })
+	}
+}
+
+// transitionMutator returns a mutator that profiles the calls to the Split and Mutate methods of m,
+// which are called once per module.
+func (p *analysisProfiler) transitionMutator(name string, m blueprint.TransitionMutator) blueprint.TransitionMutator {
+	if p == nil {
+		return m
+	}
+	return &profilingTransitionMutator{m, name, p}
+}
+
+type profilingTransitionMutator struct {
+	blueprint.TransitionMutator
+	name     string
+	profiler *analysisProfiler
+}
+
+func (t *profilingTransitionMutator) Split(ctx blueprint.BaseModuleContext) []string {
+	var variations []string
+	t.profiler.profile(t.name, ctx.ModuleType(), ctx.ModuleName(), func() {
+		variations = t.TransitionMutator.Split(ctx)
+	})
+	return variations
+}
+
+func (t *profilingTransitionMutator) Mutate(ctx blueprint.BottomUpMutatorContext, variation string) {
+	t.profiler.profile(t.name, ctx.ModuleType(), ctx.ModuleName(), func() {
+		t.TransitionMutator.Mutate(ctx, variation)
+	})
+}
+
+// add adds the time spent in another pass to t. The number of variants is the largest number
+// visited by a single pass, as most passes visit the same modules.
+func (t *moduleTypeProfile) add(other *moduleTypeProfile) {
+	if other.variants > t.variants {
+		t.variants = other.variants
+	}
+	t.cumulative += other.cumulative
+	if other.max > t.max {
+		t.max = other.max
+		t.slowestModule = other.slowestModule
+	}
+}
+
+func moduleTypeProfilesProto(moduleTypes map[string]*moduleTypeProfile) []*soong_metrics_proto.ModuleTypeProfile {
+	var ret []*soong_metrics_proto.ModuleTypeProfile
+	for _, moduleType := range SortedStringKeys(moduleTypes) {
+		t := moduleTypes[moduleType]
+		ret = append(ret, &soong_metrics_proto.ModuleTypeProfile{
+			ModuleType:     proto.String(moduleType),
+			Variants:       proto.Uint32(uint32(t.variants)),
+			CumulativeTime: proto.Uint64(uint64(t.cumulative)),
+			MaxTime:        proto.Uint64(uint64(t.max)),
+			SlowestModule:  proto.String(t.slowestModule),
+		})
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].GetCumulativeTime() > ret[j].GetCumulativeTime()
+	})
+	return ret
+}
+
+// addToMetrics adds the analysis passes and the time spent on each module type to metrics. The
+// allocations of the last pass are counted until now.
+func (p *analysisProfiler) addToMetrics(metrics *soong_metrics_proto.SoongBuildMetrics) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.passes) > 0 && p.passes[len(p.passes)-1].endAllocs == (allocCounters{}) {
+		p.passes[len(p.passes)-1].endAllocs = readAllocCounters()
+	}
+
+	total := make(map[string]*moduleTypeProfile)
+	for _, pass := range p.passes {
+		metrics.AnalysisPasses = append(metrics.AnalysisPasses, &soong_metrics_proto.AnalysisPassInfo{
+			Name:           proto.String(pass.name),
+			StartTime:      proto.Uint64(uint64(pass.start.UnixNano())),
+			RealTime:       proto.Uint64(uint64(pass.end.Sub(pass.start))),
+			CumulativeTime: proto.Uint64(uint64(pass.cumulative)),
+			AllocCount:     proto.Uint64(pass.endAllocs.count - pass.startAllocs.count),
+			AllocSize:      proto.Uint64(pass.endAllocs.size - pass.startAllocs.size),
+			ModuleTypes:    moduleTypeProfilesProto(pass.moduleTypes),
+		})
+		for moduleType, t := range pass.moduleTypes {
+			if total[moduleType] == nil {
+				total[moduleType] = &moduleTypeProfile{}
+			}
+			total[moduleType].add(t)
+		}
+	}
+	metrics.ModuleTypes = moduleTypeProfilesProto(total)
+}
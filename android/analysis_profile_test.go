@@ -0,0 +1,76 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"testing"
+	"time"
+
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
+)
+
+func TestAnalysisProfiler(t *testing.T) {
+	p := newAnalysisProfiler()
+	sleep := func(d time.Duration) func() {
+		return func() { time.Sleep(d) }
+	}
+
+	p.profile("mutator", "cc_library", "libfoo", sleep(time.Millisecond))
+	p.profile("mutator", "cc_library", "libbar", sleep(5*time.Millisecond))
+	p.profile("mutator", "java_library", "foo", sleep(time.Millisecond))
+	p.profile(generateBuildActionsPass, "cc_library", "libfoo", sleep(2*time.Millisecond))
+	p.profile(singletonPassPrefix+"androidmk", "", "", sleep(time.Millisecond))
+
+	metrics := &soong_metrics_proto.SoongBuildMetrics{}
+	p.addToMetrics(metrics)
+
+	var passes []string
+	for _, pass := range metrics.GetAnalysisPasses() {
+		passes = append(passes, pass.GetName())
+		if pass.GetCumulativeTime() == 0 || pass.GetRealTime() < pass.GetCumulativeTime()/2 {
+			t.Errorf("pass %s: unexpected times real %d cumulative %d", pass.GetName(),
+				pass.GetRealTime(), pass.GetCumulativeTime())
+		}
+	}
+	AssertArrayString(t, "passes", []string{"mutator", generateBuildActionsPass, "singleton:androidmk"}, passes)
+
+	mutator := metrics.GetAnalysisPasses()[0]
+	AssertIntEquals(t, "mutator module types", 2, len(mutator.GetModuleTypes()))
+	ccLibrary := mutator.GetModuleTypes()[0]
+	AssertStringEquals(t, "slowest module type", "cc_library", ccLibrary.GetModuleType())
+	AssertIntEquals(t, "cc_library variants", 2, int(ccLibrary.GetVariants()))
+	AssertStringEquals(t, "slowest module", "libbar", ccLibrary.GetSlowestModule())
+
+	AssertIntEquals(t, "singleton module types", 0, len(metrics.GetAnalysisPasses()[2].GetModuleTypes()))
+
+	// The totals sum the time spent in each pass, but count the variants of a single pass.
+	total := metrics.GetModuleTypes()[0]
+	AssertStringEquals(t, "total slowest module type", "cc_library", total.GetModuleType())
+	AssertIntEquals(t, "total cc_library variants", 2, int(total.GetVariants()))
+	AssertBoolEquals(t, "total cumulative time includes all passes",
+		true, total.GetCumulativeTime() >= uint64(8*time.Millisecond))
+	AssertStringEquals(t, "total slowest module", "libbar", total.GetSlowestModule())
+}
+
+func TestNilAnalysisProfiler(t *testing.T) {
+	var p *analysisProfiler
+	called := false
+	p.profile("mutator", "cc_library", "libfoo", func() { called = true })
+	AssertBoolEquals(t, "called", true, called)
+
+	metrics := &soong_metrics_proto.SoongBuildMetrics{}
+	p.addToMetrics(metrics)
+	AssertIntEquals(t, "passes", 0, len(metrics.GetAnalysisPasses()))
+}
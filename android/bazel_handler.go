@@ -390,28 +390,28 @@ func bazelPathsFromConfig(c *config) (*bazelPaths, error) {
 		soongOutDir: c.soongOutDir,
 	}
 	var missingEnvVars []string
-	if len(c.Getenv("BAZEL_HOME")) > 1 {
-		p.homeDir = c.Getenv("BAZEL_HOME")
+	if len(c.EnvString("BAZEL_HOME")) > 1 {
+		p.homeDir = c.EnvString("BAZEL_HOME")
 	} else {
 		missingEnvVars = append(missingEnvVars, "BAZEL_HOME")
 	}
-	if len(c.Getenv("BAZEL_PATH")) > 1 {
-		p.bazelPath = c.Getenv("BAZEL_PATH")
+	if len(c.EnvString("BAZEL_PATH")) > 1 {
+		p.bazelPath = c.EnvString("BAZEL_PATH")
 	} else {
 		missingEnvVars = append(missingEnvVars, "BAZEL_PATH")
 	}
-	if len(c.Getenv("BAZEL_OUTPUT_BASE")) > 1 {
-		p.outputBase = c.Getenv("BAZEL_OUTPUT_BASE")
+	if len(c.EnvString("BAZEL_OUTPUT_BASE")) > 1 {
+		p.outputBase = c.EnvString("BAZEL_OUTPUT_BASE")
 	} else {
 		missingEnvVars = append(missingEnvVars, "BAZEL_OUTPUT_BASE")
 	}
-	if len(c.Getenv("BAZEL_WORKSPACE")) > 1 {
-		p.workspaceDir = c.Getenv("BAZEL_WORKSPACE")
+	if len(c.EnvString("BAZEL_WORKSPACE")) > 1 {
+		p.workspaceDir = c.EnvString("BAZEL_WORKSPACE")
 	} else {
 		missingEnvVars = append(missingEnvVars, "BAZEL_WORKSPACE")
 	}
-	if len(c.Getenv("BAZEL_METRICS_DIR")) > 1 {
-		p.metricsDir = c.Getenv("BAZEL_METRICS_DIR")
+	if len(c.EnvString("BAZEL_METRICS_DIR")) > 1 {
+		p.metricsDir = c.EnvString("BAZEL_METRICS_DIR")
 	} else {
 		missingEnvVars = append(missingEnvVars, "BAZEL_METRICS_DIR")
 	}
@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -38,6 +39,7 @@ import (
 	"android/soong/android/soongconfig"
 	"android/soong/bazel"
 	"android/soong/remoteexec"
+	"android/soong/shared"
 	"android/soong/starlark_fmt"
 )
 
@@ -178,6 +180,10 @@ type config struct {
 	envDeps   map[string]string
 	envFrozen bool
 
+	// envRegistryErrors maps the names of environment variables that were read without being
+	// registered with the type they were read as to the error to report.
+	envRegistryErrors map[string]string
+
 	// Changes behavior based on whether Kati runs after soong_build, or if soong_build
 	// runs standalone.
 	katiEnabled bool
@@ -185,6 +191,9 @@ type config struct {
 	captureBuild      bool // true for tests, saves build parameters for each module
 	ignoreEnvironment bool // true for tests, returns empty from all Getenv calls
 
+	// Records the time spent in each analysis pass when SOONG_PROFILE_ANALYSIS is set.
+	analysisProfiler *analysisProfiler
+
 	fs         pathtools.FileSystem
 	mockBpList string
 
@@ -481,6 +490,9 @@ func NewConfig(moduleListFile string, buildMode SoongBuildMode, runGoTests bool,
 	}
 
 	config.BuildMode = buildMode
+	if config.EnvBool("SOONG_PROFILE_ANALYSIS") {
+		config.analysisProfiler = newAnalysisProfiler()
+	}
 	config.BazelContext, err = NewBazelContext(config)
 	config.Bp2buildPackageConfig = GetBp2BuildAllowList()
 
@@ -628,8 +640,260 @@ func (c *config) IsEnvFalse(key string) bool {
 	return value == "0" || value == "n" || value == "no" || value == "off" || value == "false"
 }
 
+// envRegistry declares the environment variables read by the android package and by the packages
+// that don't register their own, with their type, default, owner and description. Other packages
+// register their variables with RegisterEnvVar from their init functions. soong_build writes
+// the registry to the Soong output directory, where soong_ui reads it to validate the SOONG_* and
+// RBE_* variables in the environment and to implement --list-env.
+var envRegistry = []shared.EnvVar{
+	// android
+	BoolEnvVar("ALLOW_MISSING_DEPENDENCIES", "false", "android", "Allow missing dependencies, which fail the rules that use them when they are built."),
+	BoolEnvVar("ANDROID_REQUIRE_LICENSES", "true", "android", "Set to false to allow modules without license metadata."),
+	StringEnvVar("BAZEL_HOME", "", "android", "Overrides the home directory used by Bazel in mixed builds."),
+	StringEnvVar("BAZEL_METRICS_DIR", "", "android", "Overrides the directory Bazel writes metrics to in mixed builds."),
+	StringEnvVar("BAZEL_OUTPUT_BASE", "", "android", "Overrides the Bazel output base in mixed builds."),
+	StringEnvVar("BAZEL_PATH", "", "android", "Overrides the path to the Bazel binary in mixed builds."),
+	StringEnvVar("BAZEL_WORKSPACE", "", "android", "Overrides the Bazel workspace directory in mixed builds."),
+	BoolEnvVar("EMMA_INSTRUMENT", "false", "android", "Build with code coverage instrumentation."),
+	StringEnvVar("RBE_WRAPPER", remoteexec.DefaultWrapperPath, "android", "Path to the rewrapper binary."),
+	StringEnvVar(shared.IdeExportDirsEnv, "", "android", "Comma separated directories whose modules are exported to IDE projects, set by soong_ui --ide-export."),
+	StringEnvVar("SOONG_NEVERALLOW_AUDIT", "", "android", "Write neverallow violations to neverallow_violations.json, \"warn\" instead of failing the build or \"error\" before failing it."),
+	BoolEnvVar("SOONG_PROFILE_ANALYSIS", "false", "android", "Profile the analysis passes of soong_build per mutator and module type."),
+	StringEnvVar("SOONG_SBOX_CACHE_DIR", "", "android", "Directory where sbox caches the outputs of sandboxed rules."),
+	IntEnvVar("SOONG_SBOX_CACHE_MAX_SIZE_MB", "10240", "android", "Size in MiB that sbox trims its cache to by removing the least recently used outputs, 0 for no limit."),
+	BoolEnvVar("SOONG_SBOX_CHECK_HERMETICITY", "false", "android", "Check that sandboxed rules don't read files outside the sandbox."),
+
+	// bp2build
+	BoolEnvVar("BP2BUILD_ERROR_UNCONVERTED", "false", "bp2build", "Fail when a module can't be converted to Bazel."),
+	BoolEnvVar("BP2BUILD_VERBOSE", "false", "bp2build", "Print details about the conversion to Bazel."),
+
+	// cc
+	BoolEnvVar("ALLOW_LOCAL_TIDY_TRUE", "false", "cc", "Run clang-tidy on modules with tidy: true."),
+	BoolEnvVar("ALLOW_UNKNOWN_WARNING_OPTION", "false", "cc", "Don't fail on unknown clang warning options."),
+	StringEnvVar("ANDROID_PGO_INSTRUMENT", "", "cc", "Comma separated PGO benchmarks to instrument, or ALL."),
+	BoolEnvVar("ANDROID_PGO_NO_PROFILE_USE", "false", "cc", "Don't use PGO profiles."),
+	BoolEnvVar("ANDROID_TEMPORARILY_ALLOW_WEVERYTHING", "false", "cc", "Allow -Weverything in cflags."),
+	BoolEnvVar("AUTO_PATTERN_INITIALIZE", "false", "cc", "Initialize automatic variables with a pattern."),
+	BoolEnvVar("AUTO_UNINITIALIZE", "false", "cc", "Don't initialize automatic variables."),
+	BoolEnvVar("AUTO_ZERO_INITIALIZE", "false", "cc", "Initialize automatic variables with zeros."),
+	StringEnvVar("CC_WRAPPER", "", "cc", "Command to prefix C/C++ compiler invocations with, e.g. ccache."),
+	BoolEnvVar("CLANG_ANALYZER_CHECKS", "false", "cc", "Enable the clang-analyzer checks of clang-tidy."),
+	StringEnvVar("DEFAULT_EXTERNAL_VENDOR_TIDY_CHECKS", "", "cc", "Overrides the clang-tidy checks for external and vendor code."),
+	StringEnvVar("DEFAULT_GLOBAL_TIDY_CHECKS", "", "cc", "Overrides the default clang-tidy checks."),
+	StringEnvVar("DEFAULT_TIDY_HEADER_DIRS", "", "cc", "Regular expression of the headers to report clang-tidy findings for."),
+	BoolEnvVar("DISABLE_HOST_PIE", "false", "cc", "Don't link host binaries as position independent executables."),
+	BoolEnvVar("DISABLE_LTO", "false", "cc", "Disable link time optimization."),
+	BoolEnvVar("GLOBAL_THINLTO", "false", "cc", "Enable ThinLTO for all modules."),
+	BoolEnvVar("LLVM_NEXT", "false", "cc", "Build with the next version of clang and bindgen."),
+	BoolEnvVar("RBE_ABI_DUMPER", "false", "cc", "Run header-abi-dumper with RBE."),
+	BoolEnvVar("RBE_ABI_LINKER", "false", "cc", "Run header-abi-linker with RBE."),
+	BoolEnvVar("RBE_CLANG_TIDY", "false", "cc", "Run clang-tidy with RBE."),
+	BoolEnvVar("RBE_CXX_LINKS", "false", "cc", "Link C/C++ modules with RBE."),
+	BoolEnvVar("SKIP_ABI_CHECKS", "false", "cc", "Don't check the ABI of VNDK and LLNDK libraries."),
+	BoolEnvVar("SOONG_GEN_CMAKEFILES", "false", "cc", "Write CMakeLists.txt files for CLion."),
+	BoolEnvVar("SOONG_GEN_CMAKEFILES_DEBUG", "false", "cc", "Add debug information to the CMakeLists.txt files."),
+	BoolEnvVar("SOONG_GEN_COMPDB", "false", "cc", "Write compile_commands.json for all C/C++ modules."),
+	BoolEnvVar("SOONG_GEN_COMPDB_DEBUG", "false", "cc", "Indent compile_commands.json."),
+	BoolEnvVar("SOONG_GEN_COMPDB_HEADERS", "false", "cc", "Add entries for headers to compile_commands.json."),
+	StringEnvVar("SOONG_LINK_COMPDB_TO", "", "cc", "Directory to symlink compile_commands.json into."),
+	IntEnvVar("TIDY_TIMEOUT", "", "cc", "Timeout in seconds of each clang-tidy run, and skip the sources listed in tidy_timeout_srcs."),
+	BoolEnvVar("USE_CCACHE", "false", "cc", "Build C/C++ with ccache."),
+	BoolEnvVar("USE_THINLTO_CACHE", "false", "cc", "Use a cache for ThinLTO."),
+	BoolEnvVar("WITH_TIDY", "false", "cc", "Run clang-tidy on all modules."),
+	StringEnvVar("WITH_TIDY_FLAGS", "", "cc", "Extra flags passed to clang-tidy."),
+
+	// dexpreopt
+	BoolEnvVar("USE_DEX2OAT_DEBUG", "true", "dexpreopt", "Set to false to dexpreopt with the release version of dex2oat."),
+
+	// fuzz
+	StringEnvVar("FUZZ_FRAMEWORK", "", "fuzz", "Fuzzing engine to build fuzzers for, e.g. AFL."),
+
+	// java
+	StringEnvVar("ALTERNATE_JAVAC", "", "java", "Path to a javac to use instead of the prebuilt one."),
+	BoolEnvVar("ALWAYS_EMBED_NOTICES", "false", "java", "Embed notices in all apps."),
+	StringEnvVar("ANDROID_JAVA11_HOME", "", "java", "Path to the JDK 11."),
+	StringEnvVar("ANDROID_JAVA8_HOME", "", "java", "Path to the JDK 8 used by modules that don't build with a newer JDK."),
+	StringEnvVar("ANDROID_JAVA_HOME", "", "java", "Path to the JDK, used when EXPERIMENTAL_USE_OPENJDK17_TOOLCHAIN is not set."),
+	StringEnvVar("ANDROID_LINT_CHECK", "", "java", "Comma separated lint checks to run instead of the default ones."),
+	StringEnvVar("ANDROID_LINT_CHECK_EXTRA_MODULES", "", "java", "Comma separated modules with extra lint checks."),
+	StringEnvVar("ART_BOOT_IMAGE_EXTRA_ARGS", "", "java", "Extra arguments passed to dex2oat when compiling the boot image."),
+	StringEnvVar("BUILD_DATETIME_FILE", "", "java", "File containing the build timestamp, used in the generated docs."),
+	BoolEnvVar("EMMA_INSTRUMENT_FRAMEWORK", "false", "java", "Instrument the framework for code coverage."),
+	BoolEnvVar("EMMA_INSTRUMENT_STATIC", "false", "java", "Instrument static libraries for code coverage."),
+	BoolEnvVar("EXPERIMENTAL_TARGET_JAVA_VERSION_17", "false", "java", "Compile Java with target version 17."),
+	StringEnvVar("EXPERIMENTAL_USE_OPENJDK17_TOOLCHAIN", "", "java", "Set to true to build with JDK 17."),
+	StringEnvVar("GENERATE_DEX_DEBUG", "", "java", "Set to any value to build dex files with debug information."),
+	IntEnvVar("KYTHE_JAVA_SOURCE_BATCH_SIZE", "1000", "java", "Maximum number of Java sources in a Kythe compilation unit."),
+	StringEnvVar("KYTHE_KZIP_ENCODING", "json", "java", "Encoding of Kythe compilation units: json, proto or all."),
+	StringEnvVar("NO_OPTIMIZE_DX", "", "java", "Set to any value to build dex files without optimizations."),
+	StringEnvVar("OVERRIDE_JLINK_VERSION_NUMBER", "", "java", "Overrides the version passed to jlink."),
+	BoolEnvVar("RBE_D8", "false", "java", "Run d8 with RBE."),
+	BoolEnvVar("RBE_JAR", "false", "java", "Run jar with RBE."),
+	BoolEnvVar("RBE_JAVAC", "false", "java", "Run javac with RBE."),
+	BoolEnvVar("RBE_LINT", "false", "java", "Run lint with RBE."),
+	StringEnvVar("RBE_LINT_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "java", "RBE execution strategy of lint."),
+	StringEnvVar("RBE_LINT_POOL", "java16", "java", "RBE worker pool of lint."),
+	BoolEnvVar("RBE_METALAVA", "false", "java", "Run metalava with RBE."),
+	StringEnvVar("RBE_METALAVA_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "java", "RBE execution strategy of metalava."),
+	StringEnvVar("RBE_METALAVA_POOL", "java16", "java", "RBE worker pool of metalava."),
+	BoolEnvVar("RBE_R8", "false", "java", "Run r8 with RBE."),
+	BoolEnvVar("RBE_SIGNAPK", "false", "java", "Run signapk with RBE."),
+	BoolEnvVar("RBE_TURBINE", "false", "java", "Run turbine with RBE."),
+	BoolEnvVar("RBE_ZIP", "false", "java", "Run soong_zip with RBE."),
+	BoolEnvVar("RUN_ERROR_PRONE", "false", "java", "Compile Java with Error Prone."),
+	BoolEnvVar("TURBINE_ENABLED", "true", "java", "Set to false to compile Java headers with javac instead of turbine."),
+	BoolEnvVar("UNBUNDLED_BUILD_TARGET_SDK_WITH_API_FINGERPRINT", "false", "java", "Use the API fingerprint as the target SDK version of unbundled apps."),
+	BoolEnvVar("UNSAFE_DISABLE_HIDDENAPI_FLAGS", "false", "java", "Don't generate and check the hidden API flags."),
+	BoolEnvVar("WITHOUT_CHECK_API", "false", "java", "Don't check the APIs against the checked in API files."),
+	StringEnvVar("XREF_CORPUS", "", "java", "Kythe cross-reference corpus name."),
+
+	// rust
+	StringEnvVar("CLIPPY_DEFAULT_LINTS", "", "rust", "Overrides the default clippy lints."),
+	StringEnvVar("CLIPPY_VENDOR_LINTS", "", "rust", "Overrides the clippy lints for vendor code."),
+	StringEnvVar("LLVM_BINDGEN_PREBUILTS_VERSION", "", "rust", "Overrides the version of clang used by bindgen."),
+	StringEnvVar("RUST_DEFAULT_LINTS", "", "rust", "Overrides the default rustc lints."),
+	StringEnvVar("RUST_PREBUILTS_BASE", "", "rust", "Overrides the directory of the rust prebuilts."),
+	StringEnvVar("RUST_PREBUILTS_VERSION", "", "rust", "Overrides the version of the rust prebuilts."),
+	StringEnvVar("RUST_VENDOR_LINTS", "", "rust", "Overrides the rustc lints for vendor code."),
+	BoolEnvVar("SOONG_GEN_RUST_PROJECT", "false", "rust", "Write rust-project.json for rust-analyzer."),
+	BoolEnvVar("SOONG_RUSTC_INCREMENTAL", "false", "rust", "Compile rust with incremental compilation."),
+
+	// sdk
+	BoolEnvVar("SOONG_SDK_SNAPSHOT_PREFER", "false", "sdk", "Set the prefer property of the prebuilts in sdk snapshots."),
+	StringEnvVar("SOONG_SDK_SNAPSHOT_TARGET_BUILD_RELEASE", "", "sdk", "Build release the sdk snapshots target, defaults to the current one."),
+	StringEnvVar("SOONG_SDK_SNAPSHOT_USE_SOURCE_CONFIG_VAR", "", "sdk", "Soong config variable that controls whether sdk snapshot prebuilts are preferred."),
+	BoolEnvVar("SOONG_SDK_SNAPSHOT_USE_SRCJAR", "false", "sdk", "Use srcjars instead of sources for java_sdk_library in sdk snapshots."),
+
+	// soong_ui reads these variables, they don't affect soong_build.
+	StringEnvVar("RBE_DIR", "", "soong_ui", "Directory of the RBE tools."),
+	StringEnvVar("SOONG_DELVE", "", "soong_ui", "Listen address of the delve debugger for soong_build."),
+	StringEnvVar("SOONG_DELVE_PATH", "", "soong_ui", "Path to the delve binary."),
+	StringEnvVar("SOONG_DELVE_REEXECUTED", "", "soong_ui", "Set when a tool is reexecuted under delve."),
+	StringEnvVar("SOONG_LOCK_TIMEOUT", "10s", "soong_ui", "Duration to wait for the lock of the output directory."),
+	BoolEnvVar("SOONG_UI_ANSI_OUTPUT", "false", "soong_ui", "Force or disable the smart terminal output."),
+	StringEnvVar("SOONG_UI_DELVE", "", "soong_ui", "Listen address of the delve debugger for soong_ui."),
+	StringEnvVar("SOONG_UI_NINJA_ARGS", "", "soong_ui", "Extra arguments passed to ninja."),
+	IntEnvVar("SOONG_UI_TABLE_HEIGHT", "", "soong_ui", "Height of the table of running actions in the smart terminal output."),
+	BoolEnvVar("SOONG_UNBUFFERED_OUTPUT", "false", "soong_ui", "Print the output of actions while they run."),
+}
+
+var registeredEnvVars = make(map[string]shared.EnvVar)
+
+func init() {
+	for _, v := range envRegistry {
+		RegisterEnvVar(v)
+	}
+}
+
+// RegisterEnvVar declares an environment variable that affects the build, so that it can be read
+// with EnvBool, EnvString, EnvInt or StaticVariableWithEnvOverride. It may only be called during a
+// Go package's initialization, and panics if the variable is already registered.
+func RegisterEnvVar(v shared.EnvVar) {
+	if _, exists := registeredEnvVars[v.Name]; exists {
+		panic(fmt.Errorf("environment variable %s is registered twice", v.Name))
+	}
+	registeredEnvVars[v.Name] = v
+}
+
+// BoolEnvVar returns a boolean environment variable to register with RegisterEnvVar.
+func BoolEnvVar(name, defaultValue, owner, description string) shared.EnvVar {
+	return shared.EnvVar{Name: name, Type: shared.EnvBool, Default: defaultValue, Owner: owner, Description: description}
+}
+
+// StringEnvVar returns a string environment variable to register with RegisterEnvVar.
+func StringEnvVar(name, defaultValue, owner, description string) shared.EnvVar {
+	return shared.EnvVar{Name: name, Type: shared.EnvString, Default: defaultValue, Owner: owner, Description: description}
+}
+
+// IntEnvVar returns an integer environment variable to register with RegisterEnvVar.
+func IntEnvVar(name, defaultValue, owner, description string) shared.EnvVar {
+	return shared.EnvVar{Name: name, Type: shared.EnvInt, Default: defaultValue, Owner: owner, Description: description}
+}
+
+// RegisteredEnvVars returns the environment variables that affect the build, sorted by name.
+func RegisteredEnvVars() []shared.EnvVar {
+	vars := make([]shared.EnvVar, 0, len(registeredEnvVars))
+	for _, v := range registeredEnvVars {
+		vars = append(vars, v)
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
+// lookupEnvVar returns the registered environment variable with the given name, or an error if it
+// isn't registered with the given type.
+func lookupEnvVar(name string, typ shared.EnvVarType) (shared.EnvVar, error) {
+	v, ok := registeredEnvVars[name]
+	if !ok {
+		return shared.EnvVar{}, fmt.Errorf("environment variable %s is not registered", name)
+	}
+	if v.Type != typ {
+		return shared.EnvVar{}, fmt.Errorf("environment variable %s is registered as %s, not %s", name, v.Type, typ)
+	}
+	return v, nil
+}
+
+// registeredEnvVar returns the registered environment variable with the given name. If it isn't
+// registered with the given type, the error is recorded for EnvRegistryErrors and a variable of
+// the given type without a default is returned.
+func (c *config) registeredEnvVar(name string, typ shared.EnvVarType) shared.EnvVar {
+	v, err := lookupEnvVar(name, typ)
+	if err != nil {
+		c.envLock.Lock()
+		defer c.envLock.Unlock()
+		if c.envRegistryErrors == nil {
+			c.envRegistryErrors = make(map[string]string)
+		}
+		c.envRegistryErrors[name] = err.Error()
+		return shared.EnvVar{Name: name, Type: typ}
+	}
+	return v
+}
+
+// EnvRegistryErrors returns the errors of the reads of environment variables that aren't
+// registered with the type they were read as, sorted by variable name.
+func (c *config) EnvRegistryErrors() []string {
+	c.envLock.Lock()
+	defer c.envLock.Unlock()
+	names := SortedStringKeys(c.envRegistryErrors)
+	errs := make([]string, 0, len(names))
+	for _, name := range names {
+		errs = append(errs, c.envRegistryErrors[name])
+	}
+	return errs
+}
+
+// EnvBool returns the value of a registered boolean environment variable, or its default if it is
+// not set or is neither true nor false.
+func (c *config) EnvBool(name string) bool {
+	v := c.registeredEnvVar(name, shared.EnvBool)
+	if b, ok := shared.ParseEnvBool(c.Getenv(name)); ok {
+		return b
+	}
+	b, _ := shared.ParseEnvBool(v.Default)
+	return b
+}
+
+// EnvString returns the value of a registered string environment variable, or its default if it is
+// not set.
+func (c *config) EnvString(name string) string {
+	v := c.registeredEnvVar(name, shared.EnvString)
+	return c.GetenvWithDefault(name, v.Default)
+}
+
+// EnvInt returns the value of a registered integer environment variable, or its default if it is
+// not set or isn't an integer. It returns 0 if there is no default.
+func (c *config) EnvInt(name string) int {
+	v := c.registeredEnvVar(name, shared.EnvInt)
+	if i, err := strconv.Atoi(c.Getenv(name)); err == nil {
+		return i
+	}
+	i, _ := strconv.Atoi(v.Default)
+	return i
+}
+
 func (c *config) TargetsJava17() bool {
-	return c.IsEnvTrue("EXPERIMENTAL_TARGET_JAVA_VERSION_17")
+	return c.EnvBool("EXPERIMENTAL_TARGET_JAVA_VERSION_17")
 }
 
 // EnvDeps returns the environment variables this build depends on. The first
@@ -811,6 +1075,27 @@ func (c *config) ProductAAPTPrebuiltDPI() []string {
 	return c.productVariables.AAPTPrebuiltDPI
 }
 
+func (c *config) ProductLocales() []string {
+	return c.productVariables.ProductLocales
+}
+
+// ProductTextureCompressionFormats returns the texture compression formats supported by the
+// device, most preferred first.
+func (c *config) ProductTextureCompressionFormats() []string {
+	return c.productVariables.ProductTextureCompressionFormats
+}
+
+// ProductDeviceTier returns the device tier of the device, or nil if it is not set.
+func (c *config) ProductDeviceTier() *int {
+	return c.productVariables.ProductDeviceTier
+}
+
+// ProductCountrySet returns the name of the country set of the device, or an empty string if it is
+// not set.
+func (c *config) ProductCountrySet() string {
+	return String(c.productVariables.ProductCountrySet)
+}
+
 func (c *config) DefaultAppCertificateDir(ctx PathContext) SourcePath {
 	defaultCert := String(c.productVariables.DefaultAppCertificate)
 	if defaultCert != "" {
@@ -961,40 +1246,36 @@ func (c *config) UseRemoteBuild() bool {
 }
 
 func (c *config) RunErrorProne() bool {
-	return c.IsEnvTrue("RUN_ERROR_PRONE")
+	return c.EnvBool("RUN_ERROR_PRONE")
 }
 
 // XrefCorpusName returns the Kythe cross-reference corpus name.
 func (c *config) XrefCorpusName() string {
-	return c.Getenv("XREF_CORPUS")
+	return c.EnvString("XREF_CORPUS")
 }
 
 // XrefCuEncoding returns the compilation unit encoding to use for Kythe code
 // xrefs. Can be 'json' (default), 'proto' or 'all'.
 func (c *config) XrefCuEncoding() string {
-	if enc := c.Getenv("KYTHE_KZIP_ENCODING"); enc != "" {
-		return enc
-	}
-	return "json"
+	return c.EnvString("KYTHE_KZIP_ENCODING")
 }
 
 // XrefCuJavaSourceMax returns the maximum number of the Java source files
-// in a single compilation unit
-const xrefJavaSourceFileMaxDefault = "1000"
-
+// in a single compilation unit. Unlike EnvInt it accepts any unsigned value
+// that strconv.ParseUint understands, such as 0x400, and warns about invalid ones.
 func (c Config) XrefCuJavaSourceMax() string {
+	defaultValue := c.registeredEnvVar("KYTHE_JAVA_SOURCE_BATCH_SIZE", shared.EnvInt).Default
 	v := c.Getenv("KYTHE_JAVA_SOURCE_BATCH_SIZE")
 	if v == "" {
-		return xrefJavaSourceFileMaxDefault
+		return defaultValue
 	}
 	if _, err := strconv.ParseUint(v, 0, 0); err != nil {
 		fmt.Fprintf(os.Stderr,
 			"bad KYTHE_JAVA_SOURCE_BATCH_SIZE value: %s, will use %s",
-			err, xrefJavaSourceFileMaxDefault)
-		return xrefJavaSourceFileMaxDefault
+			err, defaultValue)
+		return defaultValue
 	}
 	return v
-
 }
 
 func (c *config) EmitXrefRules() bool {
@@ -1699,7 +1980,25 @@ func (c *config) ApexBootJars() ConfiguredJarList {
 }
 
 func (c *config) RBEWrapper() string {
-	return c.GetenvWithDefault("RBE_WRAPPER", remoteexec.DefaultWrapperPath)
+	return c.EnvString("RBE_WRAPPER")
+}
+
+// SboxCacheDir returns the directory of the local cache of the outputs of commands run in sbox, or
+// an empty string if the cache is disabled.
+func (c *config) SboxCacheDir() string {
+	return c.EnvString("SOONG_SBOX_CACHE_DIR")
+}
+
+// SboxCacheMaxSizeMB returns the size in MiB that sbox trims its action cache to, or 0 if the size
+// of the cache is unlimited.
+func (c *config) SboxCacheMaxSizeMB() int {
+	return c.EnvInt("SOONG_SBOX_CACHE_MAX_SIZE_MB")
+}
+
+// SboxCheckHermeticity returns true if commands run in sbox should fail when they read files in the
+// source tree that are not declared as inputs.
+func (c *config) SboxCheckHermeticity() bool {
+	return c.EnvBool("SOONG_SBOX_CHECK_HERMETICITY")
 }
 
 // UseHostMusl returns true if the host target has been configured to build against musl libc.
@@ -180,3 +180,54 @@ func TestConfiguredJarList(t *testing.T) {
 		assertStringEquals(t, "apex1:jarA", list5.String())
 	})
 }
+
+func TestEnvRegistry(t *testing.T) {
+	for _, v := range RegisteredEnvVars() {
+		if v.Owner == "" || v.Description == "" {
+			t.Errorf("environment variable %s has no owner or description", v.Name)
+		}
+		if err := v.Validate(v.Default); err != nil {
+			t.Errorf("invalid default: %s", err)
+		}
+	}
+}
+
+func TestEnvAccessors(t *testing.T) {
+	config := TestConfig(t.TempDir(), map[string]string{
+		"WITH_TIDY":       "1",
+		"TURBINE_ENABLED": "false",
+		"DISABLE_LTO":     "maybe",
+		"TIDY_TIMEOUT":    "60",
+		"RBE_LINT_POOL":   "",
+	}, "", nil)
+
+	AssertBoolEquals(t, "WITH_TIDY", true, config.EnvBool("WITH_TIDY"))
+	AssertBoolEquals(t, "TURBINE_ENABLED", false, config.EnvBool("TURBINE_ENABLED"))
+	AssertBoolEquals(t, "ANDROID_REQUIRE_LICENSES", true, config.EnvBool("ANDROID_REQUIRE_LICENSES"))
+	AssertBoolEquals(t, "DISABLE_LTO", false, config.EnvBool("DISABLE_LTO"))
+	AssertIntEquals(t, "TIDY_TIMEOUT", 60, config.EnvInt("TIDY_TIMEOUT"))
+	AssertStringEquals(t, "RBE_LINT_POOL", "java16", config.EnvString("RBE_LINT_POOL"))
+	AssertStringEquals(t, "KYTHE_KZIP_ENCODING", "json", config.EnvString("KYTHE_KZIP_ENCODING"))
+
+	AssertBoolEquals(t, "SOONG_NOT_REGISTERED", false, config.EnvBool("SOONG_NOT_REGISTERED"))
+	AssertStringEquals(t, "WITH_TIDY as string", "", config.EnvString("WITH_TIDY"))
+	AssertDeepEquals(t, "registry errors", []string{
+		"environment variable SOONG_NOT_REGISTERED is not registered",
+		"environment variable WITH_TIDY is registered as bool, not string",
+	}, config.EnvRegistryErrors())
+}
+
+func TestXrefCuJavaSourceMax(t *testing.T) {
+	for _, tc := range []struct{ value, want string }{
+		{"", "1000"},
+		{"500", "500"},
+		{"0x400", "0x400"},
+		{"-1", "1000"},
+		{"many", "1000"},
+	} {
+		config := TestConfig(t.TempDir(), map[string]string{
+			"KYTHE_JAVA_SOURCE_BATCH_SIZE": tc.value,
+		}, "", nil)
+		AssertStringEquals(t, "KYTHE_JAVA_SOURCE_BATCH_SIZE="+tc.value, tc.want, config.XrefCuJavaSourceMax())
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"path/filepath"
+	"strings"
+
+	"android/soong/shared"
+)
+
+// The IDE project export writes compile_commands.json, rust-project.json and
+// module_bp_java_deps.json for the modules in a set of directories and their transitive
+// dependencies to ${OUT_DIR}/soong/ide-export. It is enabled by setting SOONG_IDE_EXPORT_DIRS to a
+// comma separated list of directories, which is what `soong_ui --ide-export <dirs>` does. Building
+// the ide-export phony target builds the generated sources and headers of the exported modules, so
+// that an IDE can resolve them.
+
+func init() {
+	RegisterSingletonType("ide_export", ideExportSingletonFactory)
+}
+
+// IdeGeneratedSources is implemented by modules that compile sources or headers generated during
+// the build, which must be built before they can be indexed by an IDE.
+type IdeGeneratedSources interface {
+	IdeGeneratedSources() Paths
+}
+
+// IdeExportDirs returns the directories, relative to the top of the source tree, whose modules
+// are exported to IDE projects.
+func IdeExportDirs(config Config) []string {
+	var dirs []string
+	for _, dir := range strings.Split(config.EnvString(shared.IdeExportDirsEnv), ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			dirs = append(dirs, filepath.Clean(dir))
+		}
+	}
+	return dirs
+}
+
+// IdeExportEnabled returns true if the IDE project export was requested.
+func IdeExportEnabled(config Config) bool {
+	return len(IdeExportDirs(config)) > 0
+}
+
+// PathForIdeExport returns the path of a file written by the IDE project export.
+func PathForIdeExport(ctx PathContext, file string) OutputPath {
+	return PathForOutput(ctx, shared.IdeExportDir, file)
+}
+
+func inIdeExportDirs(dir string, dirs []string) bool {
+	for _, d := range dirs {
+		if d == "." || dir == d || strings.HasPrefix(dir, d+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+var ideExportModulesKey = NewOnceKey("ideExportModules")
+
+// IdeExportModules returns the enabled module variants defined in the exported directories, and all
+// their transitive dependencies.
+func IdeExportModules(ctx SingletonContext) map[Module]bool {
+	return ctx.Config().Once(ideExportModulesKey, func() interface{} {
+		dirs := IdeExportDirs(ctx.Config())
+		modules := make(map[Module]bool)
+		var queue []Module
+		ctx.VisitAllModules(func(module Module) {
+			if module.Enabled() && inIdeExportDirs(ctx.ModuleDir(module), dirs) {
+				modules[module] = true
+				queue = append(queue, module)
+			}
+		})
+		for len(queue) > 0 {
+			module := queue[0]
+			queue = queue[1:]
+			ctx.VisitDirectDeps(module, func(dep Module) {
+				if !modules[dep] {
+					modules[dep] = true
+					queue = append(queue, dep)
+				}
+			})
+		}
+		return modules
+	}).(map[Module]bool)
+}
+
+func ideExportSingletonFactory() Singleton {
+	return &ideExportSingleton{}
+}
+
+type ideExportSingleton struct{}
+
+func (s *ideExportSingleton) GenerateBuildActions(ctx SingletonContext) {
+	if !IdeExportEnabled(ctx.Config()) {
+		return
+	}
+
+	modules := IdeExportModules(ctx)
+	if len(modules) == 0 {
+		ctx.Errorf("%s: no modules found in %s", shared.IdeExportDirsEnv,
+			strings.Join(IdeExportDirs(ctx.Config()), ", "))
+		return
+	}
+
+	// Always create the phony target, even if there is nothing to generate.
+	ctx.Phony(shared.IdeExportPhony)
+	ctx.VisitAllModules(func(module Module) {
+		if generated, ok := module.(IdeGeneratedSources); ok && modules[module] {
+			ctx.Phony(shared.IdeExportPhony, generated.IdeGeneratedSources()...)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"testing"
+
+	"android/soong/shared"
+
+	"github.com/google/blueprint"
+)
+
+type ideExportTestDepTag struct {
+	blueprint.BaseDependencyTag
+}
+
+type ideExportTestModule struct {
+	ModuleBase
+	properties struct {
+		Deps []string
+	}
+	generated Paths
+}
+
+func (m *ideExportTestModule) DepsMutator(ctx BottomUpMutatorContext) {
+	ctx.AddDependency(ctx.Module(), ideExportTestDepTag{}, m.properties.Deps...)
+}
+
+func (m *ideExportTestModule) GenerateAndroidBuildActions(ctx ModuleContext) {
+	gen := PathForModuleGen(ctx, "gen.h")
+	ctx.Build(pctx, BuildParams{
+		Rule:   Touch,
+		Output: gen,
+	})
+	m.generated = Paths{gen}
+}
+
+func (m *ideExportTestModule) IdeGeneratedSources() Paths {
+	return m.generated
+}
+
+func ideExportTestModuleFactory() Module {
+	m := &ideExportTestModule{}
+	m.AddProperties(&m.properties)
+	InitAndroidModule(m)
+	return m
+}
+
+type ideExportTestSingleton struct {
+	modules []string
+}
+
+func (s *ideExportTestSingleton) GenerateBuildActions(ctx SingletonContext) {
+	modules := IdeExportModules(ctx)
+	ctx.VisitAllModules(func(module Module) {
+		if modules[module] {
+			s.modules = append(s.modules, module.Name())
+		}
+	})
+	s.modules = SortedUniqueStrings(s.modules)
+}
+
+func TestIdeExport(t *testing.T) {
+	testSingleton := &ideExportTestSingleton{}
+	result := GroupFixturePreparers(
+		FixtureRegisterWithContext(func(ctx RegistrationContext) {
+			ctx.RegisterModuleType("test_module", ideExportTestModuleFactory)
+			ctx.RegisterSingletonType("ide_export", ideExportSingletonFactory)
+			ctx.RegisterSingletonType("ide_export_test", func() Singleton {
+				return testSingleton
+			})
+		}),
+		FixtureMergeEnv(map[string]string{
+			shared.IdeExportDirsEnv: "a, b/c/",
+		}),
+		FixtureAddTextFile("a/Android.bp", `
+			test_module {
+				name: "a",
+				deps: ["lib"],
+			}
+		`),
+		FixtureAddTextFile("b/c/d/Android.bp", `
+			test_module {
+				name: "d",
+			}
+		`),
+		FixtureAddTextFile("b/Android.bp", `
+			test_module {
+				name: "b",
+				deps: ["a"],
+			}
+		`),
+		FixtureAddTextFile("lib/Android.bp", `
+			test_module {
+				name: "lib",
+				deps: ["base"],
+			}
+			test_module {
+				name: "base",
+			}
+			test_module {
+				name: "unrelated",
+			}
+		`),
+	).RunTest(t)
+
+	AssertArrayString(t, "dirs", []string{"a", "b/c"}, IdeExportDirs(result.Config))
+	AssertArrayString(t, "modules", []string{"a", "base", "d", "lib"}, testSingleton.modules)
+
+	phony := getPhonyMap(result.Config)[shared.IdeExportPhony]
+	AssertPathsRelativeToTopEquals(t, "generated sources", []string{
+		"out/soong/.intermediates/a/a/gen/gen.h",
+		"out/soong/.intermediates/b/c/d/d/gen/gen.h",
+		"out/soong/.intermediates/lib/base/gen/gen.h",
+		"out/soong/.intermediates/lib/lib/gen/gen.h",
+	}, SortedUniquePaths(phony))
+}
+
+func TestIdeExportNoModules(t *testing.T) {
+	GroupFixturePreparers(
+		FixtureRegisterWithContext(func(ctx RegistrationContext) {
+			ctx.RegisterModuleType("test_module", ideExportTestModuleFactory)
+			ctx.RegisterSingletonType("ide_export", ideExportSingletonFactory)
+		}),
+		FixtureMergeEnv(map[string]string{
+			shared.IdeExportDirsEnv: "missing",
+		}),
+		FixtureWithRootAndroidBp(`
+			test_module {
+				name: "foo",
+			}
+		`),
+	).ExtendWithErrorHandler(FixtureExpectsAtLeastOneErrorMatchingPattern(
+		`SOONG_IDE_EXPORT_DIRS: no modules found in missing`,
+	)).RunTest(t)
+}
@@ -104,6 +104,8 @@ func collectMetrics(config Config, eventHandler metrics.EventHandler) *soong_met
 	mixedBuildsInfo.MixedBuildDisabledModules = mixedBuildDisabledModules
 	metrics.MixedBuildsInfo = &mixedBuildsInfo
 
+	config.analysisProfiler.addToMetrics(metrics)
+
 	return metrics
 }
 
@@ -1442,6 +1442,9 @@ type ModuleBase struct {
 	ruleParams  map[blueprint.Rule]blueprint.RuleParams
 	variables   map[string]string
 
+	// Only set when generating the module graph, see ModuleGraph.
+	graphInfo moduleGraphInfo
+
 	initRcPaths         Paths
 	vintfFragmentsPaths Paths
 
@@ -2243,6 +2246,13 @@ func (m *ModuleBase) baseModuleContextFactory(ctx blueprint.BaseModuleContext) b
 }
 
 func (m *ModuleBase) GenerateBuildActions(blueprintCtx blueprint.ModuleContext) {
+	profiler := blueprintCtx.Config().(Config).analysisProfiler
+	profiler.profile(generateBuildActionsPass, blueprintCtx.ModuleType(), blueprintCtx.ModuleName(), func() {
+		m.generateBuildActions(blueprintCtx)
+	})
+}
+
+func (m *ModuleBase) generateBuildActions(blueprintCtx blueprint.ModuleContext) {
 	ctx := &moduleContext{
 		module:            m.module,
 		bp:                blueprintCtx,
@@ -2274,6 +2284,10 @@ func (m *ModuleBase) GenerateBuildActions(blueprintCtx blueprint.ModuleContext)
 		ctx.ruleParams = make(map[blueprint.Rule]blueprint.RuleParams)
 	}
 
+	if ctx.config.BuildMode == GenerateModuleGraph {
+		m.recordGraphDeps(blueprintCtx)
+	}
+
 	desc := "//" + ctx.ModuleDir() + ":" + ctx.ModuleName() + " "
 	var suffix []string
 	if ctx.Os().Class != Device && ctx.Os().Class != Generic {
@@ -2536,6 +2550,11 @@ func (b *baseModuleContext) HasProvider(provider blueprint.ProviderKey) bool {
 	return b.bp.HasProvider(provider)
 }
 func (b *baseModuleContext) SetProvider(provider blueprint.ProviderKey, value interface{}) {
+	if b.config.BuildMode == GenerateModuleGraph {
+		if m, ok := b.bp.Module().(Module); ok {
+			m.base().recordGraphProvider(value)
+		}
+	}
 	b.bp.SetProvider(provider, value)
 }
 
@@ -2744,6 +2763,10 @@ func (m *moduleContext) Build(pctx PackageContext, params BuildParams) {
 		m.buildParams = append(m.buildParams, params)
 	}
 
+	if m.config.BuildMode == GenerateModuleGraph {
+		m.module.base().recordGraphOutputs(params)
+	}
+
 	bparams := convertBuildParams(params)
 	err := validateBuildParams(bparams)
 	if err != nil {
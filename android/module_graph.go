@@ -0,0 +1,150 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/google/blueprint"
+	"google.golang.org/protobuf/proto"
+
+	"android/soong/android/module_graph_proto"
+)
+
+// ModuleGraphVersion is the version of the module graph written by WriteModuleGraph. It must be
+// incremented whenever the meaning of an existing field of module_graph_proto.ModuleGraph changes.
+const ModuleGraphVersion = 1
+
+// moduleGraphInfo is the information about a module variant that is only available while the
+// module is being processed. It is recorded when the build mode is GenerateModuleGraph.
+type moduleGraphInfo struct {
+	deps      []moduleGraphDep
+	providers []string
+	outputs   []string
+}
+
+type moduleGraphDep struct {
+	module blueprint.Module
+	tag    string
+}
+
+func (m *ModuleBase) recordGraphDeps(ctx blueprint.ModuleContext) {
+	ctx.VisitDirectDeps(func(dep blueprint.Module) {
+		tag := ctx.OtherModuleDependencyTag(dep)
+		var tagType string
+		if tag != nil {
+			tagType = fmt.Sprintf("%T", tag)
+		}
+		m.graphInfo.deps = append(m.graphInfo.deps, moduleGraphDep{dep, tagType})
+	})
+}
+
+func (m *ModuleBase) recordGraphProvider(value interface{}) {
+	m.graphInfo.providers = append(m.graphInfo.providers, fmt.Sprintf("%T", value))
+}
+
+func (m *ModuleBase) recordGraphOutputs(params BuildParams) {
+	m.graphInfo.outputs = append(m.graphInfo.outputs, buildParamsOutputs(params)...)
+}
+
+// buildParamsOutputs returns the files written by a build statement.
+func buildParamsOutputs(params BuildParams) []string {
+	var outputs []string
+	for _, output := range []WritablePath{params.Output, params.ImplicitOutput} {
+		if output != nil {
+			outputs = append(outputs, output.String())
+		}
+	}
+	for _, paths := range []WritablePaths{params.Outputs, params.ImplicitOutputs} {
+		outputs = append(outputs, paths.Strings()...)
+	}
+	return outputs
+}
+
+// ModuleGraph returns the graph of all the module variants in ctx, and the files written by the
+// singletons. The build actions must have been generated with the GenerateModuleGraph build mode so
+// that the dependency tags, providers and outputs of the modules were recorded.
+func ModuleGraph(ctx *Context) *module_graph_proto.ModuleGraph {
+	var modules []blueprint.Module
+	ctx.VisitAllModules(func(m blueprint.Module) {
+		modules = append(modules, m)
+	})
+	sort.SliceStable(modules, func(i, j int) bool {
+		if a, b := ctx.ModuleName(modules[i]), ctx.ModuleName(modules[j]); a != b {
+			return a < b
+		}
+		return ctx.ModuleSubDir(modules[i]) < ctx.ModuleSubDir(modules[j])
+	})
+
+	indexes := make(map[blueprint.Module]uint32, len(modules))
+	for i, m := range modules {
+		indexes[m] = uint32(i)
+	}
+
+	graph := &module_graph_proto.ModuleGraph{
+		Version: ModuleGraphVersion,
+		Modules: make([]*module_graph_proto.Module, 0, len(modules)),
+	}
+	for _, m := range modules {
+		module := &module_graph_proto.Module{
+			Name:    ctx.ModuleName(m),
+			Variant: ctx.ModuleSubDir(m),
+			Type:    ctx.ModuleType(m),
+			Dir:     ctx.ModuleDir(m),
+			Enabled: true,
+		}
+		if aModule, ok := m.(Module); ok {
+			base := aModule.base()
+			module.Enabled = base.Enabled()
+			for _, dep := range base.graphInfo.deps {
+				if index, ok := indexes[dep.module]; ok {
+					module.Deps = append(module.Deps, &module_graph_proto.Dependency{
+						Module: index,
+						Tag:    dep.tag,
+					})
+				}
+			}
+			module.Providers = SortedUniqueStrings(base.graphInfo.providers)
+			module.Outputs = SortedUniqueStrings(base.graphInfo.outputs)
+			module.Installs = SortedUniqueStrings(base.installFiles.Strings())
+		}
+		graph.Modules = append(graph.Modules, module)
+	}
+
+	for _, s := range ctx.Singletons() {
+		if adaptor, ok := s.(*singletonAdaptor); ok && len(adaptor.graphOutputs) > 0 {
+			graph.Singletons = append(graph.Singletons, &module_graph_proto.Singleton{
+				Name:    ctx.SingletonName(s),
+				Outputs: SortedUniqueStrings(adaptor.graphOutputs),
+			})
+		}
+	}
+	sort.Slice(graph.Singletons, func(i, j int) bool {
+		return graph.Singletons[i].Name < graph.Singletons[j].Name
+	})
+	return graph
+}
+
+// WriteModuleGraph writes the graph of all the module variants in ctx to graphFile as a binary
+// module_graph_proto.ModuleGraph.
+func WriteModuleGraph(ctx *Context, graphFile string) error {
+	buf, err := proto.Marshal(ModuleGraph(ctx))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(absolutePath(graphFile), buf, 0666)
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"testing"
+
+	"github.com/google/blueprint"
+)
+
+type moduleGraphTestInfo struct{}
+
+var moduleGraphTestInfoProvider = blueprint.NewProvider(moduleGraphTestInfo{})
+
+type moduleGraphTestDepTag struct {
+	blueprint.BaseDependencyTag
+}
+
+type moduleGraphTestModule struct {
+	ModuleBase
+	properties struct {
+		Deps []string
+	}
+}
+
+func (m *moduleGraphTestModule) DepsMutator(ctx BottomUpMutatorContext) {
+	ctx.AddDependency(ctx.Module(), moduleGraphTestDepTag{}, m.properties.Deps...)
+}
+
+func (m *moduleGraphTestModule) GenerateAndroidBuildActions(ctx ModuleContext) {
+	out := PathForModuleOut(ctx, "out")
+	ctx.Build(pctx, BuildParams{
+		Rule:           Touch,
+		Output:         out,
+		ImplicitOutput: PathForModuleOut(ctx, "out.d"),
+	})
+	ctx.SetProvider(moduleGraphTestInfoProvider, moduleGraphTestInfo{})
+	ctx.InstallFile(PathForModuleInstall(ctx, "bin"), ctx.ModuleName(), out)
+}
+
+func moduleGraphTestModuleFactory() Module {
+	m := &moduleGraphTestModule{}
+	m.AddProperties(&m.properties)
+	InitAndroidModule(m)
+	return m
+}
+
+type moduleGraphTestSingleton struct{}
+
+func (s *moduleGraphTestSingleton) GenerateBuildActions(ctx SingletonContext) {
+	ctx.Build(pctx, BuildParams{
+		Rule:   Touch,
+		Output: PathForOutput(ctx, "module_graph_test_singleton"),
+	})
+}
+
+func TestModuleGraph(t *testing.T) {
+	result := GroupFixturePreparers(
+		FixtureRegisterWithContext(func(ctx RegistrationContext) {
+			ctx.RegisterModuleType("test_module", moduleGraphTestModuleFactory)
+			ctx.RegisterSingletonType("module_graph_test_singleton", func() Singleton {
+				return &moduleGraphTestSingleton{}
+			})
+		}),
+		FixtureModifyConfig(func(config Config) {
+			config.BuildMode = GenerateModuleGraph
+		}),
+		FixtureWithRootAndroidBp(`
+			test_module {
+				name: "foo",
+				deps: ["bar", "baz"],
+			}
+			test_module {
+				name: "bar",
+				deps: ["baz"],
+			}
+			test_module {
+				name: "baz",
+			}
+			test_module {
+				name: "qux",
+				enabled: false,
+			}
+		`),
+	).RunTest(t)
+
+	graph := ModuleGraph(result.TestContext.Context)
+	AssertIntEquals(t, "version", ModuleGraphVersion, int(graph.Version))
+
+	var names []string
+	for _, m := range graph.Modules {
+		names = append(names, m.Name)
+	}
+	AssertArrayString(t, "modules", []string{"bar", "baz", "foo", "qux"}, names)
+
+	foo := graph.Modules[2]
+	AssertStringEquals(t, "type", "test_module", foo.Type)
+	AssertStringEquals(t, "dir", ".", foo.Dir)
+	AssertBoolEquals(t, "foo enabled", true, foo.Enabled)
+	AssertBoolEquals(t, "qux enabled", false, graph.Modules[3].Enabled)
+
+	var deps []string
+	for _, dep := range foo.Deps {
+		deps = append(deps, graph.Modules[dep.Module].Name+" "+dep.Tag)
+	}
+	AssertArrayString(t, "deps", []string{
+		"bar android.moduleGraphTestDepTag",
+		"baz android.moduleGraphTestDepTag",
+	}, deps)
+
+	AssertArrayString(t, "providers", []string{"android.moduleGraphTestInfo"}, foo.Providers)
+	AssertArrayString(t, "outputs", []string{
+		"out/soong/.intermediates/foo/out",
+		"out/soong/.intermediates/foo/out.d",
+		"out/soong/target/product/test_device/system/bin/foo",
+	}, foo.Outputs)
+	AssertArrayString(t, "installs", []string{
+		"out/soong/target/product/test_device/system/bin/foo",
+	}, foo.Installs)
+
+	var singletonOutputs []string
+	for _, s := range graph.Singletons {
+		if s.Name == "module_graph_test_singleton" {
+			singletonOutputs = s.Outputs
+		}
+	}
+	AssertArrayString(t, "singleton outputs", []string{"out/soong/module_graph_test_singleton"},
+		singletonOutputs)
+}
@@ -170,6 +170,9 @@ var preArch = []RegisterMutatorFunc{
 	// This must come after the defaults mutators to ensure that any visibility supplied
 	// in a defaults module has been successfully applied before the rules are gathered.
 	RegisterVisibilityRuleGatherer,
+
+	// Gather the rules declared in neverallow_rules modules for use during neverallow enforcement.
+	RegisterNeverallowRulesGatherer,
 }
 
 func registerArchMutator(ctx RegisterMutatorsContext) {
@@ -628,13 +631,17 @@ func (mutator *mutator) componentName() string {
 
 func (mutator *mutator) register(ctx *Context) {
 	blueprintCtx := ctx.Context
+	profiler := ctx.config.analysisProfiler
 	var handle blueprint.MutatorHandle
 	if mutator.bottomUpMutator != nil {
-		handle = blueprintCtx.RegisterBottomUpMutator(mutator.name, mutator.bottomUpMutator)
+		handle = blueprintCtx.RegisterBottomUpMutator(mutator.name,
+			profiler.bottomUpMutator(mutator.name, mutator.bottomUpMutator))
 	} else if mutator.topDownMutator != nil {
-		handle = blueprintCtx.RegisterTopDownMutator(mutator.name, mutator.topDownMutator)
+		handle = blueprintCtx.RegisterTopDownMutator(mutator.name,
+			profiler.topDownMutator(mutator.name, mutator.topDownMutator))
 	} else if mutator.transitionMutator != nil {
-		blueprintCtx.RegisterTransitionMutator(mutator.name, mutator.transitionMutator)
+		blueprintCtx.RegisterTransitionMutator(mutator.name,
+			profiler.transitionMutator(mutator.name, mutator.transitionMutator))
 	}
 	if mutator.parallel {
 		handle.Parallel()
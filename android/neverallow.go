@@ -42,6 +42,9 @@ import (
 // - - if the property is a list, any of the values in the list being matches
 //     counts as a match
 // - it has none of the "Without" properties matched (same rules as above)
+//
+// Rules can also be declared in Android.bp files using the neverallow_rules module type, see
+// neverallow_rules.go.
 
 func registerNeverallowMutator(ctx RegisterMutatorsContext) {
 	ctx.BottomUp("neverallow", neverallowMutator).Parallel()
@@ -254,30 +257,36 @@ func neverallowMutator(ctx BottomUpMutatorContext) {
 
 	osClass := ctx.Module().Target().Os.Class
 
-	for _, r := range neverallowRules(ctx.Config()) {
-		n := r.(*rule)
-		if !n.appliesToPath(dir) {
-			continue
-		}
+	check := func(rules []Rule) {
+		for _, r := range rules {
+			n := r.(*rule)
+			if !n.appliesToPath(dir) {
+				continue
+			}
 
-		if !n.appliesToModuleType(ctx.ModuleType()) {
-			continue
-		}
+			if !n.appliesToModuleType(ctx.ModuleType()) {
+				continue
+			}
 
-		if !n.appliesToProperties(properties) {
-			continue
-		}
+			if !n.appliesToProperties(properties) {
+				continue
+			}
 
-		if !n.appliesToOsClass(osClass) {
-			continue
-		}
+			if !n.appliesToOsClass(osClass) {
+				continue
+			}
 
-		if !n.appliesToDirectDeps(ctx) {
-			continue
-		}
+			if !n.appliesToDirectDeps(ctx) {
+				continue
+			}
 
-		ctx.ModuleErrorf("violates " + n.String())
+			reportNeverallowViolation(ctx, n, properties)
+		}
 	}
+
+	check(neverallowRules(ctx.Config()))
+	// Also check the rules declared in neverallow_rules modules.
+	check(declaredNeverallowRules(ctx.Config()))
 }
 
 type ValueMatcher interface {
@@ -725,6 +734,9 @@ func PrepareForTestWithNeverallowRules(testRules []Rule) FixturePreparer {
 			}
 		}),
 		FixtureRegisterWithContext(func(ctx RegistrationContext) {
+			RegisterNeverallowRulesBuildComponents(ctx)
+			ctx.RegisterSingletonType("neverallow_report", neverallowReportSingletonFactory)
+			ctx.PreArchMutators(RegisterNeverallowRulesGatherer)
 			ctx.PostDepsMutators(registerNeverallowMutator)
 		}),
 	)
@@ -0,0 +1,224 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Neverallow audit mode collects the violations of all neverallow rules across the whole tree into
+// a machine readable report, which makes it possible to stage a new rule and fix all of its
+// violations before it is enforced.
+//
+// It is enabled by setting SOONG_NEVERALLOW_AUDIT:
+//   - "warn" (or "true") prints a warning for each violation and writes the violations to
+//     $OUT_DIR/soong/neverallow_violations.json, which can be built with `m neverallow_report`,
+//     instead of failing the build.
+//   - "error" writes the report of all violations and then fails the build.
+
+func init() {
+	RegisterSingletonType("neverallow_report", neverallowReportSingletonFactory)
+}
+
+const (
+	neverallowAuditWarn  = "warn"
+	neverallowAuditError = "error"
+)
+
+// neverallowWarningsWriter is where the warnings of the "warn" audit mode are printed, replaced by
+// tests.
+var neverallowWarningsWriter io.Writer = os.Stderr
+
+// neverallowAuditMode returns the audit mode set by SOONG_NEVERALLOW_AUDIT, or "" if violations
+// are reported as errors by the mutator.
+func neverallowAuditMode(config Config) string {
+	switch mode := config.EnvString("SOONG_NEVERALLOW_AUDIT"); mode {
+	case "true", neverallowAuditWarn:
+		return neverallowAuditWarn
+	case neverallowAuditError:
+		return neverallowAuditError
+	default:
+		return ""
+	}
+}
+
+// A single violation of a neverallow rule by a module.
+type neverallowViolation struct {
+	// The name of the module that violates the rule.
+	Module string `json:"module"`
+
+	// The type of the module that violates the rule.
+	ModuleType string `json:"module_type"`
+
+	// The directory containing the module that violates the rule.
+	Directory string `json:"directory"`
+
+	// The properties that matched the rule and their matching values, if any, e.g.
+	// "Vndk.Enabled=true".
+	Properties []string `json:"properties,omitempty"`
+
+	// The direct dependencies that are not allowed by the rule, if any.
+	Deps []string `json:"deps,omitempty"`
+
+	// The reason given by the rule.
+	Reason string `json:"reason"`
+
+	// The full description of the rule, identical to the text of the error reported when the rule
+	// is enforced.
+	Rule string `json:"rule"`
+}
+
+func (v neverallowViolation) key() string {
+	return v.Directory + "\x00" + v.Module + "\x00" + v.Rule
+}
+
+type neverallowViolations struct {
+	sync.Mutex
+	violations map[string]neverallowViolation
+}
+
+var neverallowViolationsKey = NewOnceKey("neverallowViolations")
+
+func neverallowViolationsForConfig(config Config) *neverallowViolations {
+	return config.Once(neverallowViolationsKey, func() interface{} {
+		return &neverallowViolations{violations: make(map[string]neverallowViolation)}
+	}).(*neverallowViolations)
+}
+
+// matchedPropertyValues returns the values of the property that are matched by its matcher.
+func matchedPropertyValues(properties []interface{}, prop ruleProperty) []string {
+	var values []string
+	for _, propertyStruct := range properties {
+		propertiesValue := reflect.ValueOf(propertyStruct).Elem()
+		for _, v := range prop.fields {
+			if !propertiesValue.IsValid() {
+				break
+			}
+			propertiesValue = propertiesValue.FieldByName(v)
+		}
+		if !propertiesValue.IsValid() {
+			continue
+		}
+		matchValue(propertiesValue, func(value string) bool {
+			if prop.matcher.Test(value) {
+				values = append(values, value)
+			}
+			return false
+		})
+	}
+	return FirstUniqueStrings(values)
+}
+
+// recordNeverallowViolation adds a violation of rule r by the current module to the report.
+// Violations by multiple variants of the same module are only recorded once.
+func recordNeverallowViolation(ctx BottomUpMutatorContext, r *rule, properties []interface{}) {
+	v := neverallowViolation{
+		Module:     ctx.ModuleName(),
+		ModuleType: ctx.ModuleType(),
+		Directory:  ctx.ModuleDir(),
+		Reason:     r.reason,
+		Rule:       r.String(),
+	}
+	for _, p := range r.props {
+		name := strings.Join(p.fields, ".")
+		for _, value := range matchedPropertyValues(properties, p) {
+			v.Properties = append(v.Properties, name+"="+value)
+		}
+	}
+	if len(r.directDeps) > 0 {
+		ctx.VisitDirectDeps(func(m Module) {
+			if name := ctx.OtherModuleName(m); r.directDeps[name] {
+				v.Deps = append(v.Deps, name)
+			}
+		})
+		v.Deps = FirstUniqueStrings(v.Deps)
+	}
+
+	violations := neverallowViolationsForConfig(ctx.Config())
+	violations.Lock()
+	defer violations.Unlock()
+	violations.violations[v.key()] = v
+}
+
+// reportNeverallowViolation reports a violation of rule r by the current module, either as an
+// error or, in audit mode, by adding it to the report.
+func reportNeverallowViolation(ctx BottomUpMutatorContext, r *rule, properties []interface{}) {
+	if neverallowAuditMode(ctx.Config()) == "" {
+		ctx.ModuleErrorf("violates " + r.String())
+		return
+	}
+
+	recordNeverallowViolation(ctx, r, properties)
+}
+
+func neverallowReportSingletonFactory() Singleton {
+	return &neverallowReportSingleton{}
+}
+
+type neverallowReportSingleton struct{}
+
+func (n *neverallowReportSingleton) GenerateBuildActions(ctx SingletonContext) {
+	mode := neverallowAuditMode(ctx.Config())
+	if mode == "" {
+		return
+	}
+
+	violations := neverallowViolationsForConfig(ctx.Config())
+	violations.Lock()
+	list := make([]neverallowViolation, 0, len(violations.violations))
+	for _, v := range violations.violations {
+		list = append(list, v)
+	}
+	violations.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].key() < list[j].key()
+	})
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		ctx.Errorf("failed to marshal neverallow violations: %s", err)
+		return
+	}
+
+	report := PathForOutput(ctx, "neverallow_violations.json")
+	if mode == neverallowAuditError && len(list) > 0 {
+		// The errors prevent the ninja file from being written, write the report directly.
+		if err := WriteFileToOutputDir(report, data, 0666); err != nil {
+			ctx.Errorf("failed to write %s: %s", report, err)
+		}
+		ctx.Errorf("%d neverallow violations, see %s", len(list), report)
+		return
+	}
+	for _, v := range list {
+		msg := "violates a neverallow rule"
+		if v.Reason != "" {
+			msg += " because " + v.Reason
+		}
+		fmt.Fprintf(neverallowWarningsWriter, "warning: %s: module %q %s\n", v.Directory, v.Module, msg)
+	}
+	if len(list) > 0 {
+		fmt.Fprintf(neverallowWarningsWriter, "warning: %d neverallow violations, see %s\n", len(list), report)
+	}
+	WriteFileRule(ctx, report, string(data))
+	ctx.Phony("neverallow_report", report)
+}
@@ -0,0 +1,275 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/google/blueprint/proptools"
+)
+
+// The neverallow_rules module type allows neverallow rules to be declared in Android.bp files
+// instead of being compiled into soong_build, e.g.
+//
+//	neverallow_rules {
+//	    name: "vendor_policy",
+//	    rules: [
+//	        {
+//	            in: ["vendor/acme"],
+//	            module_type: ["cc_binary"],
+//	            with: [{ property: "vendor", value: "false" }],
+//	            because: "acme binaries must be installed on the vendor partition",
+//	        },
+//	    ],
+//	}
+//
+// The rules are gathered before the arch mutators run and are applied to every module in the
+// tree by the neverallow mutator alongside the rules registered with AddNeverAllowRules, so they
+// report exactly the same errors as the equivalent rules built with NeverAllow().
+
+func init() {
+	RegisterNeverallowRulesBuildComponents(InitRegistrationContext)
+}
+
+// Register the neverallow_rules module type.
+func RegisterNeverallowRulesBuildComponents(ctx RegistrationContext) {
+	ctx.RegisterModuleType("neverallow_rules", NeverallowRulesFactory)
+}
+
+type neverallowPropertyMatcher struct {
+	// The name of the property to match, nested properties are separated with a '.', e.g.
+	// "vndk.enabled".
+	Property *string
+
+	// Matches if the property is exactly this value. The value "*" matches any value.
+	Value *string
+
+	// Matches if the property starts with this prefix.
+	Starts_with *string
+
+	// Matches if the property matches this regular expression.
+	Regexp *string
+
+	// Matches if the property is set to a non-empty value.
+	Is_set *bool
+
+	// Matches if the property is not one of these values.
+	Not_in_list []string
+}
+
+type neverallowRuleProperties struct {
+	// Paths where this rule applies. If empty the rule applies everywhere.
+	In []string
+
+	// Paths where this rule does not apply.
+	Not_in []string
+
+	// Module types that this rule applies to. If empty the rule applies to all module types.
+	Module_type []string
+
+	// Module types that this rule does not apply to.
+	Not_module_type []string
+
+	// Names of modules that are not allowed as direct dependencies.
+	In_direct_deps []string
+
+	// Property matchers that must all match for this rule to apply.
+	With []neverallowPropertyMatcher
+
+	// Property matchers that must not match for this rule to apply.
+	Without []neverallowPropertyMatcher
+
+	// The reason for this rule, reported in the error message.
+	Because *string
+}
+
+type neverallowRulesProperties struct {
+	// The rules declared by this module.
+	Rules []neverallowRuleProperties
+}
+
+type neverallowRulesModule struct {
+	ModuleBase
+
+	properties neverallowRulesProperties
+}
+
+func (n *neverallowRulesModule) GenerateAndroidBuildActions(ModuleContext) {
+	// Nothing to do.
+}
+
+func NeverallowRulesFactory() Module {
+	module := &neverallowRulesModule{}
+	module.AddProperties(&module.properties)
+	InitAndroidModule(module)
+	return module
+}
+
+// rules converts the properties of the module to a list of Rules, reporting any invalid matchers
+// as property errors.
+func (n *neverallowRulesModule) rules(ctx BaseModuleContext) []Rule {
+	var rules []Rule
+	for i, p := range n.properties.Rules {
+		property := fmt.Sprintf("rules[%d]", i)
+		if len(p.In_direct_deps) == 0 && len(p.With) == 0 && len(p.Module_type) == 0 && len(p.In) == 0 {
+			ctx.PropertyErrorf(property, "must specify at least one of in, module_type, in_direct_deps or with")
+			continue
+		}
+		if proptools.String(p.Because) == "" {
+			ctx.PropertyErrorf(property+".because", "must be set")
+			continue
+		}
+
+		r := NeverAllow()
+		if len(p.In) > 0 {
+			r.In(p.In...)
+		}
+		if len(p.Not_in) > 0 {
+			r.NotIn(p.Not_in...)
+		}
+		if len(p.Module_type) > 0 {
+			r.ModuleType(p.Module_type...)
+		}
+		if len(p.Not_module_type) > 0 {
+			r.NotModuleType(p.Not_module_type...)
+		}
+		if len(p.In_direct_deps) > 0 {
+			r.InDirectDeps(p.In_direct_deps...)
+		}
+
+		valid := true
+		for j, w := range p.With {
+			name, matcher := neverallowMatcher(ctx, fmt.Sprintf("%s.with[%d]", property, j), w)
+			if matcher == nil {
+				valid = false
+				continue
+			}
+			r.WithMatcher(name, matcher)
+		}
+		for j, w := range p.Without {
+			name, matcher := neverallowMatcher(ctx, fmt.Sprintf("%s.without[%d]", property, j), w)
+			if matcher == nil {
+				valid = false
+				continue
+			}
+			r.WithoutMatcher(name, matcher)
+		}
+		if !valid {
+			continue
+		}
+
+		rules = append(rules, r.Because(*p.Because))
+	}
+	return rules
+}
+
+// neverallowMatcher converts a property matcher to the ValueMatcher used by NeverAllow rules. It
+// returns a nil matcher if the property matcher is invalid.
+func neverallowMatcher(ctx BaseModuleContext, property string, p neverallowPropertyMatcher) (string, ValueMatcher) {
+	name := proptools.String(p.Property)
+	if name == "" {
+		ctx.PropertyErrorf(property+".property", "must be set")
+		return "", nil
+	}
+
+	var matchers []ValueMatcher
+	if p.Value != nil {
+		matchers = append(matchers, selectMatcher(*p.Value))
+	}
+	if p.Starts_with != nil {
+		matchers = append(matchers, StartsWith(*p.Starts_with))
+	}
+	if p.Regexp != nil {
+		re, err := regexp.Compile(*p.Regexp)
+		if err != nil {
+			ctx.PropertyErrorf(property+".regexp", "invalid regular expression: %s", err)
+			return "", nil
+		}
+		matchers = append(matchers, &regexMatcher{re})
+	}
+	if proptools.Bool(p.Is_set) {
+		matchers = append(matchers, isSetMatcherInstance)
+	}
+	if p.Not_in_list != nil {
+		matchers = append(matchers, NotInList(p.Not_in_list))
+	}
+
+	if len(matchers) != 1 {
+		ctx.PropertyErrorf(property,
+			"must specify exactly one of value, starts_with, regexp, is_set or not_in_list")
+		return "", nil
+	}
+	return name, matchers[0]
+}
+
+var neverallowRulesMapKey = NewOnceKey("neverallowRulesMap")
+
+// The map from qualifiedModuleName to the []Rule declared by a neverallow_rules module.
+func moduleToNeverallowRulesMap(config Config) *sync.Map {
+	return config.Once(neverallowRulesMapKey, func() interface{} {
+		return &sync.Map{}
+	}).(*sync.Map)
+}
+
+var declaredNeverallowRulesKey = NewOnceKey("declaredNeverallowRules")
+
+// declaredNeverallowRules returns the rules declared by all neverallow_rules modules, ordered by
+// the name of the module that declared them.
+//
+// This must only be called after the neverallowRulesGatherer mutator has run on all modules.
+func declaredNeverallowRules(config Config) []Rule {
+	return config.Once(declaredNeverallowRulesKey, func() interface{} {
+		rulesByModule := make(map[string][]Rule)
+		moduleToNeverallowRulesMap(config).Range(func(key, value interface{}) bool {
+			rulesByModule[key.(qualifiedModuleName).String()] = value.([]Rule)
+			return true
+		})
+
+		names := make([]string, 0, len(rulesByModule))
+		for name := range rulesByModule {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var rules []Rule
+		for _, name := range names {
+			rules = append(rules, rulesByModule[name]...)
+		}
+		return rules
+	}).([]Rule)
+}
+
+// Registers the mutator that gathers the rules declared by neverallow_rules modules.
+//
+// The rules are not dependent on arch so this is registered before the arch phase to avoid having
+// to process multiple variants for each module. The neverallow mutator that applies them runs
+// after the deps have been resolved so all rules are available by then.
+func RegisterNeverallowRulesGatherer(ctx RegisterMutatorsContext) {
+	ctx.BottomUp("neverallowRulesGatherer", neverallowRulesGatherer).Parallel()
+}
+
+func neverallowRulesGatherer(ctx BottomUpMutatorContext) {
+	m, ok := ctx.Module().(*neverallowRulesModule)
+	if !ok {
+		return
+	}
+
+	if rules := m.rules(ctx); len(rules) > 0 {
+		moduleToNeverallowRulesMap(ctx.Config()).Store(m.qualifiedModuleId(ctx), rules)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"regexp"
+	"testing"
+)
+
+var neverallowRulesTests = []struct {
+	// The name of the test.
+	name string
+
+	// Additional contents to add to the virtual filesystem used by the tests.
+	fs MockFS
+
+	// The expected error patterns. If empty then no errors are expected, otherwise each error
+	// reported must be matched by at least one of these patterns.
+	expectedErrors []string
+}{
+	{
+		name: "declared rule matches builtin rule message",
+		fs: map[string][]byte{
+			"policy/Android.bp": []byte(`
+				neverallow_rules {
+					name: "policy",
+					rules: [
+						{
+							in_direct_deps: ["not_allowed_in_direct_deps"],
+							because: "it is not allowed",
+						},
+					],
+				}`),
+			"top/Android.bp": []byte(`
+				cc_library {
+					name: "not_allowed_in_direct_deps",
+				}`),
+			"other/Android.bp": []byte(`
+				cc_library {
+					name: "libother",
+					static_libs: ["not_allowed_in_direct_deps"],
+				}`),
+		},
+		expectedErrors: []string{
+			regexp.QuoteMeta("module \"libother\": violates neverallow requirements. Not allowed:\n\tdep(s): [\"not_allowed_in_direct_deps\"]\n\t which is restricted because it is not allowed"),
+		},
+	},
+	{
+		name: "paths and module types",
+		fs: map[string][]byte{
+			"policy/Android.bp": []byte(`
+				neverallow_rules {
+					name: "policy",
+					rules: [
+						{
+							in: ["vendor"],
+							not_in: ["vendor/allowed"],
+							module_type: ["cc_library"],
+							because: "no cc_library in vendor",
+						},
+					],
+				}`),
+			"vendor/allowed/Android.bp": []byte(`
+				cc_library {
+					name: "liballowed",
+				}`),
+			"vendor/other/Android.bp": []byte(`
+				cc_library {
+					name: "libvendor",
+				}
+				java_library {
+					name: "vendor_java",
+				}`),
+		},
+		expectedErrors: []string{
+			`module "libvendor": violates neverallow requirements`,
+		},
+	},
+	{
+		name: "property matchers",
+		fs: map[string][]byte{
+			"policy/Android.bp": []byte(`
+				neverallow_rules {
+					name: "policy",
+					rules: [
+						{
+							with: [{ property: "include_dirs", starts_with: "art/" }],
+							because: "include_dirs is deprecated",
+						},
+						{
+							with: [{ property: "vndk.enabled", value: "true" }],
+							without: [{ property: "vendor_available", is_set: true }],
+							because: "vndk requires vendor_available",
+						},
+						{
+							module_type: ["java_library"],
+							with: [{ property: "sdk_version", regexp: "^system_" }],
+							because: "system sdk is not allowed",
+						},
+					],
+				}`),
+			"other/Android.bp": []byte(`
+				cc_library {
+					name: "libinclude",
+					include_dirs: ["art/libdexfile/include"],
+				}
+				cc_library {
+					name: "libvndk",
+					vndk: {
+						enabled: true,
+					},
+				}
+				cc_library {
+					name: "libvndk_vendor_available",
+					vendor_available: true,
+					vndk: {
+						enabled: true,
+					},
+				}
+				java_library {
+					name: "system_java",
+					sdk_version: "system_current",
+				}`),
+		},
+		expectedErrors: []string{
+			`module "libinclude": violates neverallow requirements. Not allowed:\n\tproperties matching: "IncludeDirs" matches: .starts-with\(art/\)`,
+			`module "libvndk": violates neverallow requirements`,
+			`module "system_java": violates neverallow requirements`,
+		},
+	},
+	{
+		name: "invalid rules",
+		fs: map[string][]byte{
+			"policy/Android.bp": []byte(`
+				neverallow_rules {
+					name: "policy",
+					rules: [
+						{
+							because: "matches everything",
+						},
+						{
+							in: ["vendor"],
+						},
+						{
+							with: [{ value: "true" }],
+							because: "missing property",
+						},
+						{
+							with: [{ property: "sdk_version", value: "current", is_set: true }],
+							because: "too many matchers",
+						},
+						{
+							with: [{ property: "sdk_version", regexp: "(" }],
+							because: "invalid regexp",
+						},
+					],
+				}`),
+		},
+		expectedErrors: []string{
+			`module "policy": rules\[0\]: must specify at least one of in, module_type, in_direct_deps or with`,
+			`module "policy": rules\[1\].because: must be set`,
+			`module "policy": rules\[2\].with\[0\].property: must be set`,
+			`module "policy": rules\[3\].with\[0\]: must specify exactly one of value, starts_with, regexp, is_set or not_in_list`,
+			`module "policy": rules\[4\].with\[0\].regexp: invalid regular expression`,
+		},
+	},
+}
+
+func TestNeverallowRulesModule(t *testing.T) {
+	for _, test := range neverallowRulesTests {
+		t.Run(test.name, func(t *testing.T) {
+			GroupFixturePreparers(
+				prepareForNeverAllowTest,
+				PrepareForTestWithNeverallowRules([]Rule{}),
+				test.fs.AddToFixture(),
+			).
+				ExtendWithErrorHandler(FixtureExpectsAllErrorsToMatchAPattern(test.expectedErrors)).
+				RunTest(t)
+		})
+	}
+}
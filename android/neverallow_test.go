@@ -15,7 +15,11 @@
 package android
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/google/blueprint"
@@ -488,3 +492,75 @@ func newMockMakefileGoalModule() Module {
 
 func (p *mockMakefileGoalModule) GenerateAndroidBuildActions(ModuleContext) {
 }
+
+func TestNeverallowAuditMode(t *testing.T) {
+	var warnings bytes.Buffer
+	neverallowWarningsWriter = &warnings
+	defer func() { neverallowWarningsWriter = os.Stderr }()
+
+	result := GroupFixturePreparers(
+		prepareForNeverAllowTest,
+		PrepareForTestWithNeverallowRules([]Rule{
+			NeverAllow().InDirectDeps("not_allowed_in_direct_deps").Because("it is not allowed"),
+			NeverAllow().In("other").With("sdk_version", "current").Because("sdk_version is restricted"),
+		}),
+		FixtureMergeEnv(map[string]string{
+			"SOONG_NEVERALLOW_AUDIT": "warn",
+		}),
+		FixtureAddTextFile("top/Android.bp", `
+			cc_library {
+				name: "not_allowed_in_direct_deps",
+			}`),
+		FixtureAddTextFile("other/Android.bp", `
+			cc_library {
+				name: "libother",
+				static_libs: ["not_allowed_in_direct_deps"],
+				sdk_version: "current",
+			}`),
+	).RunTest(t)
+
+	report := result.SingletonForTests("neverallow_report").Output("neverallow_violations.json")
+	var violations []neverallowViolation
+	if err := json.Unmarshal([]byte(ContentFromFileRuleForTests(t, report)), &violations); err != nil {
+		t.Fatalf("failed to parse report: %s", err)
+	}
+
+	AssertIntEquals(t, "number of violations", 2, len(violations))
+	for _, v := range violations {
+		AssertStringEquals(t, "module", "libother", v.Module)
+		AssertStringEquals(t, "directory", "other", v.Directory)
+		AssertStringEquals(t, "module type", "cc_library", v.ModuleType)
+	}
+	AssertDeepEquals(t, "deps", []string{"not_allowed_in_direct_deps"}, violations[0].Deps)
+	AssertStringEquals(t, "reason", "it is not allowed", violations[0].Reason)
+	AssertDeepEquals(t, "properties", []string{"Sdk_version=current"}, violations[1].Properties)
+	AssertStringEquals(t, "reason", "sdk_version is restricted", violations[1].Reason)
+
+	lines := strings.Split(strings.TrimSuffix(warnings.String(), "\n"), "\n")
+	AssertIntEquals(t, "number of warnings", 3, len(lines))
+	AssertStringEquals(t, "warning",
+		`warning: other: module "libother" violates a neverallow rule because it is not allowed`, lines[0])
+	AssertStringEquals(t, "warning",
+		`warning: other: module "libother" violates a neverallow rule because sdk_version is restricted`, lines[1])
+	AssertStringDoesContain(t, "summary", lines[2], "warning: 2 neverallow violations, see ")
+	AssertStringDoesContain(t, "summary", lines[2], "neverallow_violations.json")
+}
+
+func TestNeverallowAuditModeError(t *testing.T) {
+	GroupFixturePreparers(
+		prepareForNeverAllowTest,
+		PrepareForTestWithNeverallowRules([]Rule{
+			NeverAllow().In("other").With("sdk_version", "current").Because("sdk_version is restricted"),
+		}),
+		FixtureMergeEnv(map[string]string{
+			"SOONG_NEVERALLOW_AUDIT": "error",
+		}),
+		FixtureAddTextFile("other/Android.bp", `
+			cc_library {
+				name: "libother",
+				sdk_version: "current",
+			}`),
+	).ExtendWithErrorHandler(FixtureExpectsAllErrorsToMatchAPattern([]string{
+		`1 neverallow violations, see .*neverallow_violations.json`,
+	})).RunTest(t)
+}
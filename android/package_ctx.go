@@ -22,6 +22,7 @@ import (
 	"github.com/google/blueprint/proptools"
 
 	"android/soong/remoteexec"
+	"android/soong/shared"
 )
 
 // PackageContext is a wrapper for blueprint.PackageContext that adds
@@ -155,7 +156,8 @@ func (p PackageContext) SourcePathsVariable(name, separator string, paths ...str
 
 // SourcePathVariableWithEnvOverride returns a Variable whose value is the source directory
 // appended with the supplied path, or the value of the given environment variable if it is set.
-// The environment variable is not required to point to a path inside the source tree.
+// The environment variable is not required to point to a path inside the source tree, and must be
+// registered as a string with RegisterEnvVar.
 // It may only be called during a Go package's initialization - either from the init() function or
 // as part of a package-scoped variable's initialization.
 func (p PackageContext) SourcePathVariableWithEnvOverride(name, path, env string) blueprint.Variable {
@@ -164,6 +166,9 @@ func (p PackageContext) SourcePathVariableWithEnvOverride(name, path, env string
 		if err != nil {
 			ctx.Errorf("%s", err.Error())
 		}
+		if _, err := lookupEnvVar(env, shared.EnvString); err != nil {
+			ctx.Errorf("%s", err.Error())
+		}
 		return ctx.Config().GetenvWithDefault(env, p.String())
 	})
 }
@@ -294,9 +299,14 @@ func (p PackageContext) MultiCommandRemoteStaticRules(name string, ruleParams bl
 }
 
 // StaticVariableWithEnvOverride creates a static variable that evaluates to the value of the given
-// environment variable if set, otherwise the given default.
-func (p PackageContext) StaticVariableWithEnvOverride(name, envVar, defaultVal string) blueprint.Variable {
+// environment variable if set, otherwise its registered default. The environment variable must be
+// registered as a string with RegisterEnvVar.
+func (p PackageContext) StaticVariableWithEnvOverride(name, envVar string) blueprint.Variable {
 	return p.VariableFunc(name, func(ctx PackageVarContext) string {
-		return ctx.Config().GetenvWithDefault(envVar, defaultVal)
+		v, err := lookupEnvVar(envVar, shared.EnvString)
+		if err != nil {
+			ctx.Errorf("%s", err.Error())
+		}
+		return ctx.Config().GetenvWithDefault(envVar, v.Default)
 	})
 }
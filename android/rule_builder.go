@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -603,6 +604,33 @@ func (r *RuleBuilder) Build(name string, desc string) {
 		// depends on it to rerun.
 		command.InputHash = proto.String(hashSrcFiles(inputs))
 
+		// If the sbox action cache or hermeticity check is enabled, list the files that the
+		// command reads from outside the sandbox so that their contents are part of the key
+		// used to look up the outputs in the cache, and so that reading them is allowed.
+		sboxCacheDir := r.ctx.Config().SboxCacheDir()
+		sboxCheckHermeticity := r.ctx.Config().SboxCheckHermeticity()
+		if sboxCacheDir != "" || sboxCheckHermeticity {
+			var inPlaceInputs Paths
+			if !r.sboxInputs {
+				inPlaceInputs = append(inPlaceInputs, inputs...)
+				for _, rspFile := range rspFiles {
+					inPlaceInputs = append(inPlaceInputs, rspFile.paths...)
+				}
+			}
+			if !r.sboxTools {
+				inPlaceInputs = append(inPlaceInputs, tools...)
+			}
+			command.Inputs = SortedUniquePaths(inPlaceInputs).Strings()
+		}
+
+		// Record the module in the manifest so that the hermeticity check can attribute
+		// undeclared inputs to it.
+		if sboxCheckHermeticity {
+			if mctx, ok := r.ctx.(ModuleContext); ok {
+				manifest.Module = proto.String(mctx.ModuleName())
+			}
+		}
+
 		// Verify that the manifest textproto is not inside the sbox output directory, otherwise
 		// it will get deleted when the sbox rule clears its output directory.
 		_, manifestInOutDir := MaybeRel(r.ctx, r.outDir.String(), r.sboxManifestPath.String())
@@ -635,6 +663,16 @@ func (r *RuleBuilder) Build(name string, desc string) {
 			sboxCmd.Flag("--write-if-changed")
 		}
 
+		if sboxCacheDir != "" {
+			sboxCmd.FlagWithArg("--cache-dir ", sboxCacheDir).
+				FlagWithArg("--cache-hits-file ", shared.SboxCacheHitsFile(PathForOutput(r.ctx).String())).
+				FlagWithArg("--cache-max-size-mb ", strconv.Itoa(r.ctx.Config().SboxCacheMaxSizeMB()))
+		}
+
+		if sboxCheckHermeticity {
+			sboxCmd.Flag("--check-hermeticity")
+		}
+
 		// Replace the command string, and add the sbox tool and manifest textproto to the
 		// dependencies of the final sbox rule.
 		commandString = sboxCmd.buf.String()
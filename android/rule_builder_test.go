@@ -792,3 +792,78 @@ func TestRuleBuilderHashInputs(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleBuilderSboxCache(t *testing.T) {
+	bp := `
+		rule_builder_test {
+			name: "foo_sbox",
+			srcs: ["in"],
+			sbox: true,
+		}
+		rule_builder_test {
+			name: "foo_sbox_inputs",
+			srcs: ["in"],
+			sbox: true,
+			sbox_inputs: true,
+		}
+	`
+
+	result := GroupFixturePreparers(
+		prepareForRuleBuilderTest,
+		FixtureWithRootAndroidBp(bp),
+		FixtureMergeEnv(map[string]string{
+			"SOONG_SBOX_CACHE_DIR": "out/sbox_cache",
+		}),
+	).RunTest(t)
+
+	t.Run("sbox", func(t *testing.T) {
+		module := result.ModuleForTests("foo_sbox", "")
+		command := module.Output("gen/foo_sbox").RuleParams.Command
+		AssertStringDoesContain(t, "command", command, " --cache-dir out/sbox_cache")
+		AssertStringDoesContain(t, "command", command, " --cache-hits-file out/soong/.sbox_cache_hits")
+		AssertStringDoesContain(t, "command", command, " --cache-max-size-mb 10240")
+
+		manifest := RuleBuilderSboxProtoForTests(t, module.Output("sbox.textproto"))
+		AssertArrayString(t, "inputs", []string{"cp", "implicit", "in", "rsp_in", "rsp_in2"},
+			manifest.Commands[0].GetInputs())
+	})
+
+	t.Run("sbox_inputs", func(t *testing.T) {
+		module := result.ModuleForTests("foo_sbox_inputs", "")
+		command := module.Output("gen/foo_sbox_inputs").RuleParams.Command
+		AssertStringDoesContain(t, "command", command, " --cache-dir out/sbox_cache")
+
+		// All the inputs and tools are copied into the sandbox, so they are already part of the
+		// key in the cache.
+		manifest := RuleBuilderSboxProtoForTests(t, module.Output("sbox.textproto"))
+		AssertArrayString(t, "inputs", nil, manifest.Commands[0].GetInputs())
+	})
+}
+
+func TestRuleBuilderSboxCheckHermeticity(t *testing.T) {
+	bp := `
+		rule_builder_test {
+			name: "foo_sbox",
+			srcs: ["in"],
+			sbox: true,
+		}
+	`
+
+	result := GroupFixturePreparers(
+		prepareForRuleBuilderTest,
+		FixtureWithRootAndroidBp(bp),
+		FixtureMergeEnv(map[string]string{
+			"SOONG_SBOX_CHECK_HERMETICITY": "true",
+		}),
+	).RunTest(t)
+
+	module := result.ModuleForTests("foo_sbox", "")
+	command := module.Output("gen/foo_sbox").RuleParams.Command
+	AssertStringDoesContain(t, "command", command, " --check-hermeticity")
+	AssertStringDoesNotContain(t, "command", command, "--cache-dir")
+
+	manifest := RuleBuilderSboxProtoForTests(t, module.Output("sbox.textproto"))
+	AssertStringEquals(t, "module", "foo_sbox", manifest.GetModule())
+	AssertArrayString(t, "inputs", []string{"cp", "implicit", "in", "rsp_in", "rsp_in2"},
+		manifest.Commands[0].GetInputs())
+}
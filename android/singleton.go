@@ -100,6 +100,9 @@ type singletonAdaptor struct {
 
 	buildParams []BuildParams
 	ruleParams  map[blueprint.Rule]blueprint.RuleParams
+
+	// Only set when generating the module graph, see ModuleGraph.
+	graphOutputs []string
 }
 
 var _ testBuildProvider = (*singletonAdaptor)(nil)
@@ -110,10 +113,13 @@ func (s *singletonAdaptor) GenerateBuildActions(ctx blueprint.SingletonContext)
 		sctx.ruleParams = make(map[blueprint.Rule]blueprint.RuleParams)
 	}
 
-	s.Singleton.GenerateBuildActions(sctx)
+	sctx.Config().analysisProfiler.profile(singletonPassPrefix+ctx.Name(), "", "", func() {
+		s.Singleton.GenerateBuildActions(sctx)
+	})
 
 	s.buildParams = sctx.buildParams
 	s.ruleParams = sctx.ruleParams
+	s.graphOutputs = sctx.graphOutputs
 }
 
 func (s *singletonAdaptor) BuildParamsForTests() []BuildParams {
@@ -133,6 +139,8 @@ type singletonContextAdaptor struct {
 
 	buildParams []BuildParams
 	ruleParams  map[blueprint.Rule]blueprint.RuleParams
+
+	graphOutputs []string
 }
 
 func (s *singletonContextAdaptor) Config() Config {
@@ -171,6 +179,9 @@ func (s *singletonContextAdaptor) Build(pctx PackageContext, params BuildParams)
 	if s.Config().captureBuild {
 		s.buildParams = append(s.buildParams, params)
 	}
+	if s.Config().BuildMode == GenerateModuleGraph {
+		s.graphOutputs = append(s.graphOutputs, buildParamsOutputs(params)...)
+	}
 	bparams := convertBuildParams(params)
 	err := validateBuildParams(bparams)
 	if err != nil {
@@ -249,6 +249,11 @@ type productVariables struct {
 	AAPTPreferredConfig *string  `json:",omitempty"`
 	AAPTPrebuiltDPI     []string `json:",omitempty"`
 
+	ProductLocales                   []string `json:",omitempty"`
+	ProductTextureCompressionFormats []string `json:",omitempty"`
+	ProductDeviceTier                *int     `json:",omitempty"`
+	ProductCountrySet                *string  `json:",omitempty"`
+
 	DefaultAppCertificate           *string `json:",omitempty"`
 	MainlineSepolicyDevCertificates *string `json:",omitempty"`
 
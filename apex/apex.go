@@ -2876,7 +2876,7 @@ func (a *apexBundle) checkApexAvailability(ctx android.ModuleContext) {
 	// Coverage build adds additional dependencies for the coverage-only runtime libraries.
 	// Requiring them and their transitive depencies with apex_available is not right
 	// because they just add noise.
-	if ctx.Config().IsEnvTrue("EMMA_INSTRUMENT") || a.IsNativeCoverageNeeded(ctx) {
+	if ctx.Config().EnvBool("EMMA_INSTRUMENT") || a.IsNativeCoverageNeeded(ctx) {
 		return
 	}
 
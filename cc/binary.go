@@ -256,7 +256,7 @@ func (binary *binaryDecorator) linkerFlags(ctx ModuleContext, flags Flags) Flags
 
 	// Passing -pie to clang for Windows binaries causes a warning that -pie is unused.
 	if ctx.Host() && !ctx.Windows() && !binary.static() {
-		if !ctx.Config().IsEnvTrue("DISABLE_HOST_PIE") {
+		if !ctx.Config().EnvBool("DISABLE_HOST_PIE") {
 			flags.Global.LdFlags = append(flags.Global.LdFlags, "-pie")
 		}
 	}
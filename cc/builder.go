@@ -207,9 +207,10 @@ var (
 		blueprint.RuleParams{
 			Depfile: "${out}.d",
 			Deps:    blueprint.DepsGCC,
-			Command: "CLANG_CMD=$clangCmd TIDY_FILE=$out " +
-				"$tidyVars$reTemplate${config.ClangBin}/clang-tidy.sh $in $tidyFlags -- $cFlags",
-			CommandDeps: []string{"${config.ClangBin}/clang-tidy.sh", "$ccCmd", "$tidyCmd"},
+			// tidy_sarif records the findings of clang-tidy in ${out}.sarif.
+			Command: "CLANG_CMD=$clangCmd TIDY_FILE=$out $tidyVars$tidySarifCmd -o ${out}.sarif -- " +
+				"$reTemplate${config.ClangBin}/clang-tidy.sh $in $tidyFlags -- $cFlags",
+			CommandDeps: []string{"${config.ClangBin}/clang-tidy.sh", "$ccCmd", "$tidyCmd", "$tidySarifCmd"},
 		},
 		&remoteexec.REParams{
 			Labels:               map[string]string{"type": "lint", "tool": "clang-tidy", "lang": "cpp"},
@@ -227,6 +228,15 @@ var (
 			Platform: map[string]string{remoteexec.PoolKey: "${config.REClangTidyPool}"},
 		}, []string{"cFlags", "ccCmd", "clangCmd", "tidyCmd", "tidyFlags", "tidyVars"}, []string{})
 
+	// Rule for merging the SARIF files written by clang-tidy into a report, see cmd/tidy_sarif.
+	tidyMerge = pctx.AndroidStaticRule("tidyMerge",
+		blueprint.RuleParams{
+			Command:        "$tidySarifCmd -merge -o $out $flags @$out.rsp",
+			CommandDeps:    []string{"$tidySarifCmd"},
+			Rspfile:        "$out.rsp",
+			RspfileContent: "$in",
+		}, "flags")
+
 	_ = pctx.SourcePathVariable("yasmCmd", "prebuilts/misc/${config.HostPrebuiltTag}/yasm/yasm")
 
 	// Rule for invoking yasm to compile .asm assembly files.
@@ -345,6 +355,7 @@ func init() {
 	pctx.StaticVariable("relPwd", PwdPrefix())
 
 	pctx.HostBinToolVariable("SoongZipCmd", "soong_zip")
+	pctx.HostBinToolVariable("tidySarifCmd", "tidy_sarif")
 }
 
 // builderFlags contains various types of command line flags (and settings) for use in building
@@ -414,33 +425,36 @@ type StripFlags struct {
 
 // Objects is a collection of file paths corresponding to outputs for C++ related build statements.
 type Objects struct {
-	objFiles      android.Paths
-	tidyFiles     android.Paths
-	tidyDepFiles  android.Paths // link dependent .tidy files
-	coverageFiles android.Paths
-	sAbiDumpFiles android.Paths
-	kytheFiles    android.Paths
+	objFiles       android.Paths
+	tidyFiles      android.Paths
+	tidyDepFiles   android.Paths // link dependent .tidy files
+	tidySarifFiles android.Paths // findings of clang-tidy for each .tidy file
+	coverageFiles  android.Paths
+	sAbiDumpFiles  android.Paths
+	kytheFiles     android.Paths
 }
 
 func (a Objects) Copy() Objects {
 	return Objects{
-		objFiles:      append(android.Paths{}, a.objFiles...),
-		tidyFiles:     append(android.Paths{}, a.tidyFiles...),
-		tidyDepFiles:  append(android.Paths{}, a.tidyDepFiles...),
-		coverageFiles: append(android.Paths{}, a.coverageFiles...),
-		sAbiDumpFiles: append(android.Paths{}, a.sAbiDumpFiles...),
-		kytheFiles:    append(android.Paths{}, a.kytheFiles...),
+		objFiles:       append(android.Paths{}, a.objFiles...),
+		tidyFiles:      append(android.Paths{}, a.tidyFiles...),
+		tidyDepFiles:   append(android.Paths{}, a.tidyDepFiles...),
+		tidySarifFiles: append(android.Paths{}, a.tidySarifFiles...),
+		coverageFiles:  append(android.Paths{}, a.coverageFiles...),
+		sAbiDumpFiles:  append(android.Paths{}, a.sAbiDumpFiles...),
+		kytheFiles:     append(android.Paths{}, a.kytheFiles...),
 	}
 }
 
 func (a Objects) Append(b Objects) Objects {
 	return Objects{
-		objFiles:      append(a.objFiles, b.objFiles...),
-		tidyFiles:     append(a.tidyFiles, b.tidyFiles...),
-		tidyDepFiles:  append(a.tidyDepFiles, b.tidyDepFiles...),
-		coverageFiles: append(a.coverageFiles, b.coverageFiles...),
-		sAbiDumpFiles: append(a.sAbiDumpFiles, b.sAbiDumpFiles...),
-		kytheFiles:    append(a.kytheFiles, b.kytheFiles...),
+		objFiles:       append(a.objFiles, b.objFiles...),
+		tidyFiles:      append(a.tidyFiles, b.tidyFiles...),
+		tidyDepFiles:   append(a.tidyDepFiles, b.tidyDepFiles...),
+		tidySarifFiles: append(a.tidySarifFiles, b.tidySarifFiles...),
+		coverageFiles:  append(a.coverageFiles, b.coverageFiles...),
+		sAbiDumpFiles:  append(a.sAbiDumpFiles, b.sAbiDumpFiles...),
+		kytheFiles:     append(a.kytheFiles, b.kytheFiles...),
 	}
 }
 
@@ -450,6 +464,7 @@ func transformSourceToObj(ctx ModuleContext, subdir string, srcFiles, noTidySrcs
 	// Source files are one-to-one with tidy, coverage, or kythe files, if enabled.
 	objFiles := make(android.Paths, len(srcFiles))
 	var tidyFiles android.Paths
+	var tidySarifFiles android.Paths
 	noTidySrcsMap := make(map[string]bool)
 	var tidyVars string
 	if flags.tidy {
@@ -457,9 +472,8 @@ func transformSourceToObj(ctx ModuleContext, subdir string, srcFiles, noTidySrcs
 		for _, path := range noTidySrcs {
 			noTidySrcsMap[path.String()] = true
 		}
-		tidyTimeout := ctx.Config().Getenv("TIDY_TIMEOUT")
-		if len(tidyTimeout) > 0 {
-			tidyVars += "TIDY_TIMEOUT=" + tidyTimeout + " "
+		if tidyTimeout := ctx.Config().EnvInt("TIDY_TIMEOUT"); tidyTimeout > 0 {
+			tidyVars += "TIDY_TIMEOUT=" + strconv.Itoa(tidyTimeout) + " "
 			// add timeoutTidySrcs into noTidySrcsMap if TIDY_TIMEOUT is set
 			for _, path := range timeoutTidySrcs {
 				noTidySrcsMap[path.String()] = true
@@ -669,10 +683,12 @@ func transformSourceToObj(ctx ModuleContext, subdir string, srcFiles, noTidySrcs
 		if tidy && !noTidySrcsMap[srcFile.String()] {
 			tidyFile := android.ObjPathWithExt(ctx, subdir, srcFile, "tidy")
 			tidyFiles = append(tidyFiles, tidyFile)
+			tidySarifFile := android.ObjPathWithExt(ctx, subdir, srcFile, "tidy.sarif")
+			tidySarifFiles = append(tidySarifFiles, tidySarifFile)
 			tidyCmd := "${config.ClangBin}/clang-tidy"
 
 			rule := clangTidy
-			if ctx.Config().UseRBE() && ctx.Config().IsEnvTrue("RBE_CLANG_TIDY") {
+			if ctx.Config().UseRBE() && ctx.Config().EnvBool("RBE_CLANG_TIDY") {
 				rule = clangTidyRE
 			}
 
@@ -681,12 +697,13 @@ func transformSourceToObj(ctx ModuleContext, subdir string, srcFiles, noTidySrcs
 
 			// Add the .tidy rule
 			ctx.Build(pctx, android.BuildParams{
-				Rule:        rule,
-				Description: "clang-tidy " + srcRelPath,
-				Output:      tidyFile,
-				Input:       srcFile,
-				Implicits:   cFlagsDeps,
-				OrderOnly:   pathDeps,
+				Rule:           rule,
+				Description:    "clang-tidy " + srcRelPath,
+				Output:         tidyFile,
+				ImplicitOutput: tidySarifFile,
+				Input:          srcFile,
+				Implicits:      cFlagsDeps,
+				OrderOnly:      pathDeps,
 				Args: map[string]string{
 					"cFlags":    sharedCFlags,
 					"ccCmd":     ccCmd,
@@ -703,7 +720,7 @@ func transformSourceToObj(ctx ModuleContext, subdir string, srcFiles, noTidySrcs
 			sAbiDumpFiles = append(sAbiDumpFiles, sAbiDumpFile)
 
 			dumpRule := sAbiDump
-			if ctx.Config().UseRBE() && ctx.Config().IsEnvTrue("RBE_ABI_DUMPER") {
+			if ctx.Config().UseRBE() && ctx.Config().EnvBool("RBE_ABI_DUMPER") {
 				dumpRule = sAbiDumpRE
 			}
 			ctx.Build(pctx, android.BuildParams{
@@ -728,12 +745,13 @@ func transformSourceToObj(ctx ModuleContext, subdir string, srcFiles, noTidySrcs
 		tidyDepFiles = tidyFiles
 	}
 	return Objects{
-		objFiles:      objFiles,
-		tidyFiles:     tidyFiles,
-		tidyDepFiles:  tidyDepFiles,
-		coverageFiles: coverageFiles,
-		sAbiDumpFiles: sAbiDumpFiles,
-		kytheFiles:    kytheFiles,
+		objFiles:       objFiles,
+		tidyFiles:      tidyFiles,
+		tidyDepFiles:   tidyDepFiles,
+		tidySarifFiles: tidySarifFiles,
+		coverageFiles:  coverageFiles,
+		sAbiDumpFiles:  sAbiDumpFiles,
+		kytheFiles:     kytheFiles,
 	}
 }
 
@@ -838,7 +856,7 @@ func transformObjToDynamicBinary(ctx android.ModuleContext,
 		"ldFlags":       flags.globalLdFlags + " " + flags.localLdFlags,
 		"crtEnd":        strings.Join(crtEnd.Strings(), " "),
 	}
-	if ctx.Config().UseRBE() && ctx.Config().IsEnvTrue("RBE_CXX_LINKS") {
+	if ctx.Config().UseRBE() && ctx.Config().EnvBool("RBE_CXX_LINKS") {
 		rule = ldRE
 		args["implicitOutputs"] = strings.Join(implicitOutputs.Strings(), ",")
 		args["implicitInputs"] = strings.Join(deps.Strings(), ",")
@@ -884,7 +902,7 @@ func transformDumpToLinkedDump(ctx android.ModuleContext, sAbiDumps android.Path
 		"arch":                ctx.Arch().ArchType.Name,
 		"exportedHeaderFlags": exportedHeaderFlags,
 	}
-	if ctx.Config().UseRBE() && ctx.Config().IsEnvTrue("RBE_ABI_LINKER") {
+	if ctx.Config().UseRBE() && ctx.Config().EnvBool("RBE_ABI_LINKER") {
 		rule = sAbiLinkRE
 		rbeImplicits := implicits.Strings()
 		for _, p := range strings.Split(exportedHeaderFlags, " ") {
@@ -1015,7 +1033,7 @@ func transformObjsToObj(ctx android.ModuleContext, objFiles android.Paths,
 		"ldCmd":   ldCmd,
 		"ldFlags": flags.globalLdFlags + " " + flags.localLdFlags,
 	}
-	if ctx.Config().UseRBE() && ctx.Config().IsEnvTrue("RBE_CXX_LINKS") {
+	if ctx.Config().UseRBE() && ctx.Config().EnvBool("RBE_CXX_LINKS") {
 		rule = partialLdRE
 		args["inCommaList"] = strings.Join(objFiles.Strings(), ",")
 		args["implicitInputs"] = strings.Join(deps.Strings(), ",")
@@ -873,6 +873,8 @@ type Module struct {
 	objFiles android.Paths
 	// Tidy .tidy file output paths for this compilation module
 	tidyFiles android.Paths
+	// Merged clang-tidy findings of this compilation module, nil if clang-tidy didn't run
+	tidyReport android.Path
 
 	// For apex variants, this is set as apex.min_sdk_version
 	apexSdkVersion android.ApiLevel
@@ -1123,6 +1125,17 @@ func (c *Module) CoverageFiles() android.Paths {
 
 var _ LinkableInterface = (*Module)(nil)
 
+var _ android.IdeGeneratedSources = (*Module)(nil)
+
+// IdeGeneratedSources returns the generated sources and headers needed to compile the module, which
+// must be built before an IDE can index it.
+func (c *Module) IdeGeneratedSources() android.Paths {
+	if compiler, ok := c.compiler.(interface{ generatedSources() android.Paths }); ok {
+		return compiler.generatedSources()
+	}
+	return nil
+}
+
 func (c *Module) UnstrippedOutputFile() android.Path {
 	if c.linker != nil {
 		return c.linker.unstrippedOutputFilePath()
@@ -1975,6 +1988,11 @@ func (c *Module) GenerateAndroidBuildActions(actx android.ModuleContext) {
 		if ctx.Failed() {
 			return
 		}
+		for _, feature := range c.features {
+			if tidy, ok := feature.(*tidyFeature); ok {
+				c.tidyReport = tidy.report(ctx, &objs)
+			}
+		}
 		c.kytheFiles = objs.kytheFiles
 		c.objFiles = objs.objFiles
 		c.tidyFiles = objs.tidyFiles
@@ -47,7 +47,6 @@ const (
 	// Environment variables used to modify behavior of this singleton.
 	envVariableGenerateCMakeLists = "SOONG_GEN_CMAKEFILES"
 	envVariableGenerateDebugInfo  = "SOONG_GEN_CMAKEFILES_DEBUG"
-	envVariableTrue               = "1"
 )
 
 // Instruct generator to trace how header include path and flags were generated.
@@ -55,11 +54,11 @@ const (
 var outputDebugInfo = false
 
 func (c *cmakelistsGeneratorSingleton) GenerateBuildActions(ctx android.SingletonContext) {
-	if getEnvVariable(envVariableGenerateCMakeLists, ctx) != envVariableTrue {
+	if !ctx.Config().EnvBool(envVariableGenerateCMakeLists) {
 		return
 	}
 
-	outputDebugInfo = (getEnvVariable(envVariableGenerateDebugInfo, ctx) == envVariableTrue)
+	outputDebugInfo = ctx.Config().EnvBool(envVariableGenerateDebugInfo)
 
 	// Track which projects have already had CMakeLists.txt generated to keep the first
 	// variant for each project.
@@ -82,12 +81,6 @@ func (c *cmakelistsGeneratorSingleton) GenerateBuildActions(ctx android.Singleto
 	return
 }
 
-func getEnvVariable(name string, ctx android.SingletonContext) string {
-	// Using android.Config.Getenv instead of os.getEnv to guarantee soong will
-	// re-run in case this environment variable changes.
-	return ctx.Config().Getenv(name)
-}
-
 func exists(path string) bool {
 	_, err := os.Stat(path)
 	if err == nil {
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"android/soong/android"
+	"android/soong/shared"
 )
 
 // This singleton generates a compile_commands.json file. It does so for each
@@ -29,7 +30,14 @@ import (
 // or mmma is called. It will only create a single compile_commands.json file
 // at ${OUT_DIR}/soong/development/ide/compdb/compile_commands.json. It will also symlink it
 // to ${SOONG_LINK_COMPDB_TO} if set. In general this should be created by running
-// make SOONG_GEN_COMPDB=1 nothing to get all targets.
+// make SOONG_GEN_COMPDB=1 nothing to get all targets. The IDE project export (see
+// android/ide_export.go) also writes a compile_commands.json file restricted to the exported
+// modules.
+//
+// If SOONG_GEN_COMPDB_HEADERS is set, headers also get entries, which use the flags of a
+// translation unit of the module that owns the header's directory, or of a module that includes
+// it for header-only libraries. Generated sources and headers are only written by the build, make
+// compdb-generated builds all of those that the entries refer to.
 
 func init() {
 	android.RegisterSingletonType("compdb_generator", compDBGeneratorSingleton)
@@ -49,6 +57,10 @@ const (
 	envVariableGenerateCompdb          = "SOONG_GEN_COMPDB"
 	envVariableGenerateCompdbDebugInfo = "SOONG_GEN_COMPDB_DEBUG"
 	envVariableCompdbLink              = "SOONG_LINK_COMPDB_TO"
+	envVariableGenerateCompdbHeaders   = "SOONG_GEN_COMPDB_HEADERS"
+
+	// The phony target that builds the generated sources and headers used by the compdb entries.
+	compdbGeneratedPhony = "compdb-generated"
 )
 
 // A compdb entry. The compile_commands.json file is a list of these.
@@ -60,27 +72,65 @@ type compDbEntry struct {
 }
 
 func (c *compdbGeneratorSingleton) GenerateBuildActions(ctx android.SingletonContext) {
-	if !ctx.Config().IsEnvTrue(envVariableGenerateCompdb) {
-		return
+	if ctx.Config().EnvBool(envVariableGenerateCompdb) {
+		dir := android.PathForOutput(ctx, compdbOutputProjectsDirectory)
+		compDBFile := dir.Join(ctx, compdbFilename)
+		generated := writeCompdb(ctx, compDBFile, func(android.Module) bool { return true })
+
+		// Building this phony target builds the generated sources and headers needed for indexing.
+		ctx.Phony(compdbGeneratedPhony, generated...)
+
+		if finalLinkDir := ctx.Config().EnvString(envVariableCompdbLink); finalLinkDir != "" {
+			finalLinkPath := filepath.Join(finalLinkDir, compdbFilename)
+			os.Remove(finalLinkPath)
+			if err := os.Symlink(compDBFile.String(), finalLinkPath); err != nil {
+				log.Fatalf("Unable to symlink %s to %s: %s", compDBFile, finalLinkPath, err)
+			}
+		}
 	}
 
+	// The IDE project export only includes the modules in the requested directories and their
+	// dependencies.
+	if android.IdeExportEnabled(ctx.Config()) {
+		modules := android.IdeExportModules(ctx)
+		writeCompdb(ctx, android.PathForIdeExport(ctx, shared.IdeExportCompdbFile), func(module android.Module) bool {
+			return modules[module]
+		})
+	}
+}
+
+// writeCompdb writes a compile_commands.json file for the cc modules that match filter. It returns
+// the generated sources and headers that these modules use.
+func writeCompdb(ctx android.SingletonContext, compDBFile android.OutputPath, filter func(android.Module) bool) android.Paths {
 	// Instruct the generator to indent the json file for easier debugging.
-	outputCompdbDebugInfo := ctx.Config().IsEnvTrue(envVariableGenerateCompdbDebugInfo)
+	outputCompdbDebugInfo := ctx.Config().EnvBool(envVariableGenerateCompdbDebugInfo)
 
 	// We only want one entry per file. We don't care what module/isa it's from
 	m := make(map[string]compDbEntry)
+	var generated android.Paths
+	var ownerModules []*Module
+	owners := make(map[*Module]compDbEntry)
 	ctx.VisitAllModules(func(module android.Module) {
+		if !filter(module) {
+			return
+		}
 		if ccModule, ok := module.(*Module); ok {
 			if compiledModule, ok := ccModule.compiler.(CompiledInterface); ok {
-				generateCompdbProject(compiledModule, ctx, ccModule, m)
+				if owner, ok := generateCompdbProject(compiledModule, ctx, ccModule, m); ok {
+					ownerModules = append(ownerModules, ccModule)
+					owners[ccModule] = owner
+				}
+				generated = append(generated, ccModule.IdeGeneratedSources()...)
 			}
 		}
 	})
 
+	if ctx.Config().EnvBool(envVariableGenerateCompdbHeaders) {
+		addHeaderEntries(ctx, ownerModules, owners, m)
+	}
+
 	// Create the output file.
-	dir := android.PathForOutput(ctx, compdbOutputProjectsDirectory)
-	os.MkdirAll(filepath.Join(android.AbsSrcDirForExistingUseCases(), dir.String()), 0777)
-	compDBFile := dir.Join(ctx, compdbFilename)
+	os.MkdirAll(filepath.Join(android.AbsSrcDirForExistingUseCases(), filepath.Dir(compDBFile.String())), 0777)
 	f, err := os.Create(filepath.Join(android.AbsSrcDirForExistingUseCases(), compDBFile.String()))
 	if err != nil {
 		log.Fatalf("Could not create file %s: %s", compDBFile, err)
@@ -103,13 +153,7 @@ func (c *compdbGeneratorSingleton) GenerateBuildActions(ctx android.SingletonCon
 	}
 	f.Write(dat)
 
-	if finalLinkDir := ctx.Config().Getenv(envVariableCompdbLink); finalLinkDir != "" {
-		finalLinkPath := filepath.Join(finalLinkDir, compdbFilename)
-		os.Remove(finalLinkPath)
-		if err := os.Symlink(compDBFile.String(), finalLinkPath); err != nil {
-			log.Fatalf("Unable to symlink %s to %s: %s", compDBFile, finalLinkPath, err)
-		}
-	}
+	return generated
 }
 
 func expandAllVars(ctx android.SingletonContext, args []string) []string {
@@ -168,10 +212,14 @@ func getArguments(src android.Path, ctx android.SingletonContext, ccModule *Modu
 	return args
 }
 
-func generateCompdbProject(compiledModule CompiledInterface, ctx android.SingletonContext, ccModule *Module, builds map[string]compDbEntry) {
+// generateCompdbProject adds entries for the sources of a module to builds. It returns the entry of
+// its first C or C++ source, whose flags are used for the headers of the module.
+func generateCompdbProject(compiledModule CompiledInterface, ctx android.SingletonContext, ccModule *Module,
+	builds map[string]compDbEntry) (owner compDbEntry, hasOwner bool) {
+
 	srcs := compiledModule.Srcs()
 	if len(srcs) == 0 {
-		return
+		return compDbEntry{}, false
 	}
 
 	pathToCC, err := ctx.Eval(pctx, "${config.ClangBin}")
@@ -182,16 +230,164 @@ func generateCompdbProject(compiledModule CompiledInterface, ctx android.Singlet
 		cxxPath = filepath.Join(pathToCC, "clang++")
 	}
 	for _, src := range srcs {
-		if _, ok := builds[src.String()]; !ok {
-			builds[src.String()] = compDbEntry{
+		entry, ok := builds[src.String()]
+		if !ok {
+			entry = compDbEntry{
 				Directory: android.AbsSrcDirForExistingUseCases(),
 				Arguments: getArguments(src, ctx, ccModule, ccPath, cxxPath),
 				File:      src.String(),
 			}
+			builds[src.String()] = entry
+		}
+		if !hasOwner && compdbHeaderLanguage(src.String()) != "" {
+			owner, hasOwner = entry, true
+		}
+	}
+	return owner, hasOwner
+}
+
+// compdbHeaderLanguage returns the language of the headers included by a C or C++ source file,
+// or an empty string for other files.
+func compdbHeaderLanguage(src string) string {
+	switch filepath.Ext(src) {
+	case ".c":
+		return "c-header"
+	case ".cpp", ".cc", ".cxx":
+		return "c++-header"
+	case ".mm":
+		return "objective-c++-header"
+	}
+	return ""
+}
+
+// compdbHeaderEntry returns an entry for a header that uses the flags of the translation unit
+// owner, which has no entry of its own as headers are not compiled.
+func compdbHeaderEntry(owner compDbEntry, header string) compDbEntry {
+	args := []string{owner.Arguments[0], "-x", compdbHeaderLanguage(owner.File)}
+	// The last argument of the translation unit is its source file.
+	args = append(args, owner.Arguments[1:len(owner.Arguments)-1]...)
+	args = append(args, header)
+	return compDbEntry{
+		Directory: owner.Directory,
+		Arguments: args,
+		File:      header,
+	}
+}
+
+// The extensions of the header files that get compdb entries.
+var compdbHeaderExtensions = []string{".h", ".hh", ".hpp", ".hxx"}
+
+// compdbHeaders finds the headers in the include directories of modules and synthesizes compdb
+// entries for them.
+type compdbHeaders struct {
+	ctx     android.SingletonContext
+	builds  map[string]compDbEntry
+	headers map[string][]string // The headers in each directory, followed by "/**" if recursive.
+}
+
+// headersInDir returns the headers in a source directory, and in its subdirectories if recursive
+// is true. soong_build reruns when headers are added or removed.
+func (h *compdbHeaders) headersInDir(dir string, recursive bool) []string {
+	key := dir
+	if recursive {
+		key += "/**"
+	}
+	if headers, ok := h.headers[key]; ok {
+		return headers
+	}
+
+	var headers []string
+	for _, ext := range compdbHeaderExtensions {
+		pattern := filepath.Join(dir, "*"+ext)
+		if recursive {
+			pattern = filepath.Join(dir, "**", "*"+ext)
+		}
+		matches, err := h.ctx.GlobWithDeps(pattern, nil)
+		if err != nil {
+			h.ctx.Errorf("failed to glob headers in %s: %s", dir, err)
+			continue
+		}
+		for _, match := range matches {
+			if !strings.HasSuffix(match, "/") {
+				headers = append(headers, match)
+			}
+		}
+	}
+	h.headers[key] = headers
+	return headers
+}
+
+// addIncludeDirs adds entries for the headers in include directories that don't have one yet,
+// using the flags of owner. Generated include directories are skipped, their headers are
+// listed in the generated headers instead.
+func (h *compdbHeaders) addIncludeDirs(owner compDbEntry, dirs android.Paths) {
+	for _, dir := range dirs {
+		if _, generated := dir.(android.WritablePath); generated {
+			continue
+		}
+		h.addHeaders(owner, h.headersInDir(dir.String(), true))
+	}
+}
+
+func (h *compdbHeaders) addHeaders(owner compDbEntry, headers []string) {
+	for _, header := range headers {
+		if _, ok := h.builds[header]; !ok {
+			h.builds[header] = compdbHeaderEntry(owner, header)
 		}
 	}
 }
 
+// localIncludeDirsInterface is implemented by compilers that have local include directories.
+type localIncludeDirsInterface interface {
+	localIncludeDirs() []string
+}
+
+// addHeaderEntries adds entries for the headers included by the modules that have an owner
+// translation unit. A module first gets the headers of its own directory and include
+// directories, and of the generated headers it exports, then the headers exported by its
+// header-only dependencies that no other module owns. The headers of other dependencies get the
+// flags of the translation units of those dependencies, or no entries if they have none.
+func addHeaderEntries(ctx android.SingletonContext, modules []*Module, owners map[*Module]compDbEntry,
+	builds map[string]compDbEntry) {
+
+	h := &compdbHeaders{
+		ctx:     ctx,
+		builds:  builds,
+		headers: make(map[string][]string),
+	}
+
+	for _, module := range modules {
+		owner := owners[module]
+		moduleDir := ctx.ModuleDir(module)
+		h.addHeaders(owner, h.headersInDir(moduleDir, false))
+		if compiler, ok := module.compiler.(localIncludeDirsInterface); ok {
+			for _, dir := range compiler.localIncludeDirs() {
+				h.addHeaders(owner, h.headersInDir(filepath.Join(moduleDir, dir), true))
+			}
+		}
+		exported := ctx.ModuleProvider(module, FlagExporterInfoProvider).(FlagExporterInfo)
+		h.addIncludeDirs(owner, exported.IncludeDirs)
+		h.addIncludeDirs(owner, exported.SystemIncludeDirs)
+		h.addHeaders(owner, exported.GeneratedHeaders.Strings())
+	}
+
+	for _, module := range modules {
+		owner := owners[module]
+		ctx.VisitDirectDeps(module, func(dep android.Module) {
+			if ccDep, ok := dep.(*Module); !ok || !ccDep.Header() {
+				return
+			}
+			if !ctx.ModuleHasProvider(dep, FlagExporterInfoProvider) {
+				return
+			}
+			exported := ctx.ModuleProvider(dep, FlagExporterInfoProvider).(FlagExporterInfo)
+			h.addIncludeDirs(owner, exported.IncludeDirs)
+			h.addIncludeDirs(owner, exported.SystemIncludeDirs)
+			h.addHeaders(owner, exported.GeneratedHeaders.Strings())
+		})
+	}
+}
+
 func evalAndSplitVariable(ctx android.SingletonContext, str string) ([]string, error) {
 	evaluated, err := ctx.Eval(pctx, str)
 	if err == nil {
@@ -0,0 +1,165 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cc
+
+import (
+	"reflect"
+	"testing"
+
+	"android/soong/android"
+)
+
+func TestCompdbHeaderEntry(t *testing.T) {
+	testCases := []struct {
+		name   string
+		owner  compDbEntry
+		header string
+		want   compDbEntry
+	}{
+		{
+			name: "c++",
+			owner: compDbEntry{
+				Directory: "/src",
+				Arguments: []string{"clang++", "-Ifoo/include", "-std=gnu++17", "foo/foo.cpp"},
+				File:      "foo/foo.cpp",
+			},
+			header: "foo/include/foo.h",
+			want: compDbEntry{
+				Directory: "/src",
+				Arguments: []string{"clang++", "-x", "c++-header", "-Ifoo/include", "-std=gnu++17", "foo/include/foo.h"},
+				File:      "foo/include/foo.h",
+			},
+		},
+		{
+			name: "c",
+			owner: compDbEntry{
+				Directory: "/src",
+				Arguments: []string{"clang", "-Ibar", "bar/bar.c"},
+				File:      "bar/bar.c",
+			},
+			header: "out/soong/.intermediates/bar/gen/gen/bar.h",
+			want: compDbEntry{
+				Directory: "/src",
+				Arguments: []string{"clang", "-x", "c-header", "-Ibar", "out/soong/.intermediates/bar/gen/gen/bar.h"},
+				File:      "out/soong/.intermediates/bar/gen/gen/bar.h",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := compdbHeaderEntry(tc.owner, tc.header)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %#v, got %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCompdbHeaderLanguage(t *testing.T) {
+	for src, want := range map[string]string{
+		"a.c":   "c-header",
+		"a.cpp": "c++-header",
+		"a.cc":  "c++-header",
+		"a.mm":  "objective-c++-header",
+		"a.S":   "",
+		"a.asm": "",
+	} {
+		if got := compdbHeaderLanguage(src); got != want {
+			t.Errorf("%s: want %q, got %q", src, want, got)
+		}
+	}
+}
+
+// compdbHeadersTestSingleton collects the compdb entries of all modules, including their headers.
+type compdbHeadersTestSingleton struct {
+	builds map[string]compDbEntry
+}
+
+func (s *compdbHeadersTestSingleton) GenerateBuildActions(ctx android.SingletonContext) {
+	s.builds = make(map[string]compDbEntry)
+	var modules []*Module
+	owners := make(map[*Module]compDbEntry)
+	ctx.VisitAllModules(func(module android.Module) {
+		if ccModule, ok := module.(*Module); ok {
+			if compiledModule, ok := ccModule.compiler.(CompiledInterface); ok {
+				if owner, ok := generateCompdbProject(compiledModule, ctx, ccModule, s.builds); ok {
+					modules = append(modules, ccModule)
+					owners[ccModule] = owner
+				}
+			}
+		}
+	})
+	addHeaderEntries(ctx, modules, owners, s.builds)
+}
+
+func TestCompdbHeaderEntries(t *testing.T) {
+	singleton := &compdbHeadersTestSingleton{}
+	android.GroupFixturePreparers(
+		prepareForCcTest,
+		android.FixtureRegisterWithContext(func(ctx android.RegistrationContext) {
+			ctx.RegisterSingletonType("compdb_headers_test", func() android.Singleton { return singleton })
+		}),
+		android.FixtureMergeMockFs(android.MockFS{
+			"foo/foo.cpp":            nil,
+			"foo/foo.h":              nil,
+			"foo/include/sub/inc.h":  nil,
+			"hdr/include/hdr.h":      nil,
+			"prebuilt/include/pre.h": nil,
+			"prebuilt/libprebuilt.a": nil,
+			"unrelated/include/un.h": nil,
+		}),
+		android.FixtureAddTextFile("foo/Android.bp", `
+			cc_library_static {
+				name: "libfoo",
+				srcs: ["foo.cpp"],
+				local_include_dirs: ["include"],
+				header_libs: ["libhdr"],
+				static_libs: ["libprebuilt"],
+			}
+		`),
+	).RunTestWithBp(t, `
+		cc_library_headers {
+			name: "libhdr",
+			export_include_dirs: ["hdr/include"],
+		}
+
+		cc_prebuilt_library_static {
+			name: "libprebuilt",
+			srcs: ["prebuilt/libprebuilt.a"],
+			export_include_dirs: ["prebuilt/include"],
+		}
+	`)
+
+	fooCpp, ok := singleton.builds["foo/foo.cpp"]
+	if !ok {
+		t.Fatalf("missing entry for foo/foo.cpp in %v", singleton.builds)
+	}
+	for _, header := range []string{"foo/foo.h", "foo/include/sub/inc.h", "hdr/include/hdr.h"} {
+		entry, ok := singleton.builds[header]
+		if !ok {
+			t.Errorf("missing entry for %s", header)
+			continue
+		}
+		android.AssertDeepEquals(t, "entry for "+header, compdbHeaderEntry(fooCpp, header), entry)
+	}
+	// The headers exported by dependencies that are compiled separately are not owned by libfoo.
+	if _, ok := singleton.builds["prebuilt/include/pre.h"]; ok {
+		t.Errorf("unexpected entry for prebuilt/include/pre.h")
+	}
+	if _, ok := singleton.builds["unrelated/include/un.h"]; ok {
+		t.Errorf("unexpected entry for unrelated/include/un.h")
+	}
+}
@@ -246,6 +246,23 @@ func (compiler *baseCompiler) Srcs() android.Paths {
 	return append(android.Paths{}, compiler.srcs...)
 }
 
+// generatedSources returns the sources passed to the compiler that are generated during the build,
+// and the generated headers they may include.
+func (compiler *baseCompiler) generatedSources() android.Paths {
+	var generated android.Paths
+	for _, src := range compiler.srcs {
+		if _, ok := src.(android.WritablePath); ok {
+			generated = append(generated, src)
+		}
+	}
+	return append(generated, compiler.pathDeps...)
+}
+
+// localIncludeDirs returns the include directories of the module, relative to its directory.
+func (compiler *baseCompiler) localIncludeDirs() []string {
+	return compiler.Properties.Local_include_dirs
+}
+
 func (compiler *baseCompiler) appendCflags(flags []string) {
 	compiler.Properties.Cflags = append(compiler.Properties.Cflags, flags...)
 }
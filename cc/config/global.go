@@ -362,11 +362,11 @@ func init() {
 		// http://b/131390872
 		// Automatically initialize any uninitialized stack variables.
 		// Prefer zero-init if multiple options are set.
-		if ctx.Config().IsEnvTrue("AUTO_ZERO_INITIALIZE") {
+		if ctx.Config().EnvBool("AUTO_ZERO_INITIALIZE") {
 			flags = append(flags, "-ftrivial-auto-var-init=zero -enable-trivial-auto-var-init-zero-knowing-it-will-be-removed-from-clang")
-		} else if ctx.Config().IsEnvTrue("AUTO_PATTERN_INITIALIZE") {
+		} else if ctx.Config().EnvBool("AUTO_PATTERN_INITIALIZE") {
 			flags = append(flags, "-ftrivial-auto-var-init=pattern")
-		} else if ctx.Config().IsEnvTrue("AUTO_UNINITIALIZE") {
+		} else if ctx.Config().EnvBool("AUTO_UNINITIALIZE") {
 			flags = append(flags, "-ftrivial-auto-var-init=uninitialized")
 		} else {
 			// Default to zero initialization.
@@ -375,15 +375,15 @@ func init() {
 
 		// Workaround for ccache with clang.
 		// See http://petereisentraut.blogspot.com/2011/05/ccache-and-clang.html.
-		if ctx.Config().IsEnvTrue("USE_CCACHE") {
+		if ctx.Config().EnvBool("USE_CCACHE") {
 			flags = append(flags, "-Wno-unused-command-line-argument")
 		}
 
-		if ctx.Config().IsEnvTrue("LLVM_NEXT") {
+		if ctx.Config().EnvBool("LLVM_NEXT") {
 			flags = append(flags, llvmNextExtraCommonGlobalCflags...)
 		}
 
-		if ctx.Config().IsEnvTrue("ALLOW_UNKNOWN_WARNING_OPTION") {
+		if ctx.Config().EnvBool("ALLOW_UNKNOWN_WARNING_OPTION") {
 			flags = append(flags, "-Wno-error=unknown-warning-option")
 		}
 
@@ -428,12 +428,16 @@ func init() {
 	exportedVars.ExportStringStaticVariable("CLANG_DEFAULT_VERSION", ClangDefaultVersion)
 	exportedVars.ExportStringStaticVariable("CLANG_DEFAULT_SHORT_VERSION", ClangDefaultShortVersion)
 
-	pctx.StaticVariableWithEnvOverride("ClangBase", "LLVM_PREBUILTS_BASE", ClangDefaultBase)
-	pctx.StaticVariableWithEnvOverride("ClangVersion", "LLVM_PREBUILTS_VERSION", ClangDefaultVersion)
+	android.RegisterEnvVar(android.StringEnvVar("LLVM_PREBUILTS_BASE", ClangDefaultBase, "cc", "Overrides the directory of the clang prebuilts."))
+	android.RegisterEnvVar(android.StringEnvVar("LLVM_PREBUILTS_VERSION", ClangDefaultVersion, "cc", "Overrides the version of the clang prebuilts."))
+	android.RegisterEnvVar(android.StringEnvVar("LLVM_RELEASE_VERSION", ClangDefaultShortVersion, "cc", "Overrides the short version of the clang prebuilts."))
+
+	pctx.StaticVariableWithEnvOverride("ClangBase", "LLVM_PREBUILTS_BASE")
+	pctx.StaticVariableWithEnvOverride("ClangVersion", "LLVM_PREBUILTS_VERSION")
 	pctx.StaticVariable("ClangPath", "${ClangBase}/${HostPrebuiltTag}/${ClangVersion}")
 	pctx.StaticVariable("ClangBin", "${ClangPath}/bin")
 
-	pctx.StaticVariableWithEnvOverride("ClangShortVersion", "LLVM_RELEASE_VERSION", ClangDefaultShortVersion)
+	pctx.StaticVariableWithEnvOverride("ClangShortVersion", "LLVM_RELEASE_VERSION")
 	pctx.StaticVariable("ClangAsanLibDir", "${ClangBase}/linux-x86/${ClangVersion}/lib64/clang/${ClangShortVersion}/lib/linux")
 
 	// These are tied to the version of LLVM directly in external/llvm, so they might trail the host prebuilts
@@ -451,19 +455,27 @@ func init() {
 		})
 
 	pctx.VariableFunc("CcWrapper", func(ctx android.PackageVarContext) string {
-		if override := ctx.Config().Getenv("CC_WRAPPER"); override != "" {
+		if override := ctx.Config().EnvString("CC_WRAPPER"); override != "" {
 			return override + " "
 		}
 		return ""
 	})
 
-	pctx.StaticVariableWithEnvOverride("RECXXPool", "RBE_CXX_POOL", remoteexec.DefaultPool)
-	pctx.StaticVariableWithEnvOverride("RECXXLinksPool", "RBE_CXX_LINKS_POOL", remoteexec.DefaultPool)
-	pctx.StaticVariableWithEnvOverride("REClangTidyPool", "RBE_CLANG_TIDY_POOL", remoteexec.DefaultPool)
-	pctx.StaticVariableWithEnvOverride("RECXXLinksExecStrategy", "RBE_CXX_LINKS_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
-	pctx.StaticVariableWithEnvOverride("REClangTidyExecStrategy", "RBE_CLANG_TIDY_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
-	pctx.StaticVariableWithEnvOverride("REAbiDumperExecStrategy", "RBE_ABI_DUMPER_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
-	pctx.StaticVariableWithEnvOverride("REAbiLinkerExecStrategy", "RBE_ABI_LINKER_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
+	android.RegisterEnvVar(android.StringEnvVar("RBE_CXX_POOL", remoteexec.DefaultPool, "cc", "RBE worker pool of C/C++ compiles."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_CXX_LINKS_POOL", remoteexec.DefaultPool, "cc", "RBE worker pool of C/C++ links."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_CLANG_TIDY_POOL", remoteexec.DefaultPool, "cc", "RBE worker pool of clang-tidy."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_CXX_LINKS_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "cc", "RBE execution strategy of C/C++ links."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_CLANG_TIDY_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "cc", "RBE execution strategy of clang-tidy."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_ABI_DUMPER_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "cc", "RBE execution strategy of header-abi-dumper."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_ABI_LINKER_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "cc", "RBE execution strategy of header-abi-linker."))
+
+	pctx.StaticVariableWithEnvOverride("RECXXPool", "RBE_CXX_POOL")
+	pctx.StaticVariableWithEnvOverride("RECXXLinksPool", "RBE_CXX_LINKS_POOL")
+	pctx.StaticVariableWithEnvOverride("REClangTidyPool", "RBE_CLANG_TIDY_POOL")
+	pctx.StaticVariableWithEnvOverride("RECXXLinksExecStrategy", "RBE_CXX_LINKS_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("REClangTidyExecStrategy", "RBE_CLANG_TIDY_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("REAbiDumperExecStrategy", "RBE_ABI_DUMPER_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("REAbiLinkerExecStrategy", "RBE_ABI_LINKER_EXEC_STRATEGY")
 }
 
 var HostPrebuiltTag = exportedVars.ExportVariableConfigMethod("HostPrebuiltTag", android.Config.PrebuiltOS)
@@ -482,14 +494,8 @@ var clangPathKey = android.NewOnceKey("clangPath")
 
 func clangPath(ctx android.PathContext) android.SourcePath {
 	return ctx.Config().OnceSourcePath(clangPathKey, func() android.SourcePath {
-		clangBase := ClangDefaultBase
-		if override := ctx.Config().Getenv("LLVM_PREBUILTS_BASE"); override != "" {
-			clangBase = override
-		}
-		clangVersion := ClangDefaultVersion
-		if override := ctx.Config().Getenv("LLVM_PREBUILTS_VERSION"); override != "" {
-			clangVersion = override
-		}
+		clangBase := ctx.Config().EnvString("LLVM_PREBUILTS_BASE")
+		clangVersion := ctx.Config().EnvString("LLVM_PREBUILTS_VERSION")
 		return android.PathForSource(ctx, clangBase, ctx.Config().PrebuiltOS(), clangVersion)
 	})
 }
@@ -208,7 +208,7 @@ func (pgo *pgo) begin(ctx BaseModuleContext) {
 	//
 	// TODO Validate that each benchmark instruments at least one module
 	pgo.Properties.ShouldProfileModule = false
-	pgoBenchmarks := ctx.Config().Getenv("ANDROID_PGO_INSTRUMENT")
+	pgoBenchmarks := ctx.Config().EnvString("ANDROID_PGO_INSTRUMENT")
 	pgoBenchmarksMap := make(map[string]bool)
 	for _, b := range strings.Split(pgoBenchmarks, ",") {
 		pgoBenchmarksMap[b] = true
@@ -233,7 +233,7 @@ func (pgo *pgo) begin(ctx BaseModuleContext) {
 		return
 	}
 
-	if !ctx.Config().IsEnvTrue("ANDROID_PGO_NO_PROFILE_USE") &&
+	if !ctx.Config().EnvBool("ANDROID_PGO_NO_PROFILE_USE") &&
 		proptools.BoolDefault(pgo.Properties.Pgo.Enable_profile_use, true) {
 		if profileFile := pgo.Properties.getPgoProfileFile(ctx); profileFile.Valid() {
 			pgo.Properties.PgoCompile = true
@@ -286,7 +286,7 @@ func (pgo *pgo) flags(ctx ModuleContext, flags Flags) Flags {
 		return props.addInstrumentationProfileGatherFlags(ctx, flags)
 	}
 
-	if !ctx.Config().IsEnvTrue("ANDROID_PGO_NO_PROFILE_USE") {
+	if !ctx.Config().EnvBool("ANDROID_PGO_NO_PROFILE_USE") {
 		flags = props.addProfileUseFlags(ctx, flags)
 	}
 
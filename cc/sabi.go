@@ -171,7 +171,7 @@ func shouldCreateSourceAbiDumpForLibrary(ctx android.BaseModuleContext) bool {
 // of their dependencies would be generated.
 func sabiDepsMutator(mctx android.TopDownMutatorContext) {
 	// Escape hatch to not check any ABI dump.
-	if mctx.Config().IsEnvTrue("SKIP_ABI_CHECKS") {
+	if mctx.Config().EnvBool("SKIP_ABI_CHECKS") {
 		return
 	}
 	// Only create ABI dump for native shared libraries and their static library dependencies.
@@ -38,10 +38,19 @@ type TidyProperties struct {
 
 	// Checks that should be treated as errors.
 	Tidy_checks_as_errors []string
+
+	// SARIF file with the known clang-tidy findings of the module, usually a copy of the
+	// tidy.sarif report of the module. If set, only the findings of tidy_checks_as_errors that are
+	// not in the baseline are errors.
+	Tidy_baseline *string `android:"path"`
 }
 
 type tidyFeature struct {
 	Properties TidyProperties
+
+	// The -checks_as_errors flag of tidy_sarif for the module report, set if the module has a
+	// tidy_baseline.
+	checksAsErrors string
 }
 
 var quotedFlagRegexp, _ = regexp.Compile(`^-?-[^=]+=('|").*('|")$`)
@@ -89,12 +98,12 @@ func (tidy *tidyFeature) flags(ctx ModuleContext, flags Flags) Flags {
 	// If explicitly enabled, by global WITH_TIDY or local tidy:true property,
 	// set flags.NeedTidyFiles to make this module depend on .tidy files.
 	// Note that locally set tidy:true is ignored if ALLOW_LOCAL_TIDY_TRUE is not set to true.
-	if ctx.Config().IsEnvTrue("WITH_TIDY") || (ctx.Config().IsEnvTrue("ALLOW_LOCAL_TIDY_TRUE") && Bool(tidy.Properties.Tidy)) {
+	if ctx.Config().EnvBool("WITH_TIDY") || (ctx.Config().EnvBool("ALLOW_LOCAL_TIDY_TRUE") && Bool(tidy.Properties.Tidy)) {
 		flags.NeedTidyFiles = true
 	}
 
 	// Add global WITH_TIDY_FLAGS and local tidy_flags.
-	withTidyFlags := ctx.Config().Getenv("WITH_TIDY_FLAGS")
+	withTidyFlags := ctx.Config().EnvString("WITH_TIDY_FLAGS")
 	if len(withTidyFlags) > 0 {
 		flags.TidyFlags = append(flags.TidyFlags, withTidyFlags)
 	}
@@ -104,7 +113,7 @@ func (tidy *tidyFeature) flags(ctx ModuleContext, flags Flags) Flags {
 	// Find the substring because the flag could also appear as --header-filter=...
 	// and with or without single or double quotes.
 	if !android.SubstringInList(flags.TidyFlags, "-header-filter=") {
-		defaultDirs := ctx.Config().Getenv("DEFAULT_TIDY_HEADER_DIRS")
+		defaultDirs := ctx.Config().EnvString("DEFAULT_TIDY_HEADER_DIRS")
 		headerFilter := "-header-filter="
 		// Default header filter should include only the module directory,
 		// not the out/soong/.../ModuleDir/...
@@ -198,20 +207,63 @@ func (tidy *tidyFeature) flags(ctx ModuleContext, flags Flags) Flags {
 	// Default clang-tidy flags does not contain -warning-as-errors.
 	// If a module has tidy_checks_as_errors, add the list to -warnings-as-errors
 	// and then append the TidyGlobalNoErrorChecks.
+	// With a tidy_baseline, the checks are passed to the module report instead, which only fails
+	// for the findings that are not in the baseline.
 	if len(tidy.Properties.Tidy_checks_as_errors) > 0 {
-		tidyChecksAsErrors := "-warnings-as-errors=" +
-			strings.Join(esc(ctx, "tidy_checks_as_errors", tidy.Properties.Tidy_checks_as_errors), ",") +
+		checksAsErrors := strings.Join(esc(ctx, "tidy_checks_as_errors", tidy.Properties.Tidy_checks_as_errors), ",") +
 			config.TidyGlobalNoErrorChecks()
-		flags.TidyFlags = append(flags.TidyFlags, tidyChecksAsErrors)
+		if tidy.Properties.Tidy_baseline != nil {
+			tidy.checksAsErrors = "-checks_as_errors=" + checksAsErrors
+		} else {
+			flags.TidyFlags = append(flags.TidyFlags, "-warnings-as-errors="+checksAsErrors)
+		}
 	}
 	return flags
 }
 
+// report merges the findings of clang-tidy for the objects into the tidy.sarif report of the
+// module, compared to the tidy_baseline if the module has one. The report is added to the tidy
+// files of the objects, and to the link dependent tidy files if they are needed, so that new
+// findings of tidy_checks_as_errors fail the build. It returns nil if clang-tidy didn't run.
+func (tidy *tidyFeature) report(ctx ModuleContext, objs *Objects) android.Path {
+	if len(objs.tidySarifFiles) == 0 {
+		return nil
+	}
+	reportFile := android.PathForModuleOut(ctx, "tidy.sarif")
+	flags := []string{"-module " + ctx.ModuleName(), "-dir " + ctx.ModuleDir()}
+	var implicits android.Paths
+	if tidy.Properties.Tidy_baseline != nil {
+		baseline := android.PathForModuleSrc(ctx, *tidy.Properties.Tidy_baseline)
+		implicits = append(implicits, baseline)
+		flags = append(flags, "-baseline "+baseline.String())
+		if tidy.checksAsErrors != "" {
+			flags = append(flags, tidy.checksAsErrors)
+		}
+	}
+	ctx.Build(pctx, android.BuildParams{
+		Rule:        tidyMerge,
+		Description: "clang-tidy report",
+		Output:      reportFile,
+		Inputs:      objs.tidySarifFiles,
+		Implicits:   implicits,
+		Args: map[string]string{
+			"flags": strings.Join(flags, " "),
+		},
+	})
+
+	objs.tidyFiles = append(objs.tidyFiles, reportFile)
+	if len(objs.tidyDepFiles) > 0 {
+		objs.tidyDepFiles = append(objs.tidyDepFiles, reportFile)
+	}
+	return reportFile
+}
+
 func init() {
 	android.RegisterSingletonType("tidy_phony_targets", TidyPhonySingleton)
 }
 
-// This TidyPhonySingleton generates both tidy-* and obj-* phony targets for C/C++ files.
+// This TidyPhonySingleton generates both tidy-* and obj-* phony targets for C/C++ files,
+// and the tidy-report target that merges the clang-tidy reports of all modules.
 func TidyPhonySingleton() android.Singleton {
 	return &tidyPhonySingleton{}
 }
@@ -264,11 +316,19 @@ func (m *tidyPhonySingleton) GenerateBuildActions(ctx android.SingletonContext)
 	// Also for obj-* directory phony targets.
 	objModulesInDirGroup := make(map[string]map[string]android.Paths)
 
+	var tidyReports android.Paths
+
 	// Collect tidy/obj targets from the 'final' modules.
 	ctx.VisitAllModules(func(module android.Module) {
 		if module == ctx.FinalModule(module) {
 			collectTidyObjModuleTargets(ctx, module, tidyModulesInDirGroup, objModulesInDirGroup)
 		}
+		if ctx.Config().KatiEnabled() && android.ShouldSkipAndroidMkProcessing(module) {
+			return
+		}
+		if m, ok := module.(*Module); ok && m.tidyReport != nil {
+			tidyReports = append(tidyReports, m.tidyReport)
+		}
 	})
 
 	suffix := ""
@@ -277,6 +337,29 @@ func (m *tidyPhonySingleton) GenerateBuildActions(ctx android.SingletonContext)
 	}
 	generateObjTidyPhonyTargets(ctx, suffix, "obj", objModulesInDirGroup)
 	generateObjTidyPhonyTargets(ctx, suffix, "tidy", tidyModulesInDirGroup)
+	generateTidyReport(ctx, suffix, tidyReports)
+}
+
+// generateTidyReport merges the clang-tidy reports of all modules into tidy/tidy.sarif, with the
+// number of findings per directory and module in tidy/summary.txt, built by the tidy-report
+// phony target.
+func generateTidyReport(ctx android.SingletonContext, suffix string, tidyReports android.Paths) {
+	if len(tidyReports) == 0 {
+		return
+	}
+	reportFile := android.PathForOutput(ctx, "tidy", "tidy.sarif")
+	summaryFile := android.PathForOutput(ctx, "tidy", "summary.txt")
+	ctx.Build(pctx, android.BuildParams{
+		Rule:           tidyMerge,
+		Description:    "clang-tidy report",
+		Output:         reportFile,
+		ImplicitOutput: summaryFile,
+		Inputs:         tidyReports,
+		Args: map[string]string{
+			"flags": "-summary " + summaryFile.String(),
+		},
+	})
+	ctx.Phony("tidy-report"+suffix, reportFile, summaryFile)
 }
 
 // The name for an obj/tidy module variant group phony target is Name_group-obj/tidy,
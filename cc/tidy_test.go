@@ -244,3 +244,40 @@ func TestWithTidy(t *testing.T) {
 		})
 	}
 }
+
+func TestTidyReport(t *testing.T) {
+	bp := `
+		cc_library_shared {
+			name: "libfoo",
+			srcs: ["foo.c"],
+			tidy_checks_as_errors: ["xyz-*", "abc"],
+			tidy_baseline: "tidy_baseline.sarif",
+		}
+		cc_library_shared {
+			name: "libbaz",
+			srcs: ["baz.c"],
+			tidy: false,
+		}`
+	ctx := android.GroupFixturePreparers(
+		prepareForCcTest,
+		android.FixtureAddTextFile("tidy_baseline.sarif", ""),
+		android.FixtureMergeEnv(map[string]string{"WITH_TIDY": "1"}),
+	).RunTestWithBp(t, bp)
+	variant := "android_arm64_armv8-a_shared"
+
+	libfoo := ctx.ModuleForTests("libfoo", variant)
+	android.AssertStringDoesNotContain(t, "tidyFlags with a baseline",
+		libfoo.Rule("clangTidy").Args["tidyFlags"], "-warnings-as-errors")
+	report := libfoo.Rule("tidyMerge")
+	android.AssertStringEquals(t, "report flags",
+		"-module libfoo -dir . -baseline tidy_baseline.sarif -checks_as_errors='xyz-*',abc,${config.TidyGlobalNoErrorChecks}",
+		report.Args["flags"])
+	android.AssertStringListContains(t, "libfoo depends on its report",
+		libfoo.Rule("ld").Validations.Strings(), report.Output.String())
+	if libbaz := ctx.ModuleForTests("libbaz", variant).MaybeRule("tidyMerge"); libbaz.Rule != nil {
+		t.Errorf("libbaz without clang-tidy should not have a report")
+	}
+
+	treeReport := ctx.SingletonForTests("tidy_phony_targets").Output("tidy/tidy.sarif")
+	android.AssertStringListContains(t, "tree report inputs", treeReport.Inputs.Strings(), report.Output.String())
+}
@@ -15,102 +15,19 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
-	"encoding/json"
-	"io"
-	"os"
-	"regexp"
-	"strings"
-	"unicode"
+	"android/soong/cmd/diff_target_files/allowlist"
 )
 
-type allowList struct {
-	path                string
-	ignoreMatchingLines []string
-}
-
-func parseAllowLists(allowLists []string, allowListFiles []string) ([]allowList, error) {
-	var ret []allowList
-
-	add := func(path string, ignoreMatchingLines []string) {
-		for _, x := range ret {
-			if x.path == path {
-				x.ignoreMatchingLines = append(x.ignoreMatchingLines, ignoreMatchingLines...)
-				return
-			}
-		}
-
-		ret = append(ret, allowList{
-			path:                path,
-			ignoreMatchingLines: ignoreMatchingLines,
-		})
-	}
-
-	for _, file := range allowListFiles {
-		newAllowlists, err := parseAllowListFile(file)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, w := range newAllowlists {
-			add(w.path, w.ignoreMatchingLines)
-		}
-	}
-
-	for _, s := range allowLists {
-		colon := strings.IndexRune(s, ':')
-		var ignoreMatchingLines []string
-		if colon >= 0 {
-			ignoreMatchingLines = []string{s[colon+1:]}
-		}
-		add(s, ignoreMatchingLines)
-	}
-
-	return ret, nil
-}
-
-func parseAllowListFile(file string) ([]allowList, error) {
-	r, err := os.Open(file)
-	if err != nil {
-		return nil, err
-	}
-	defer r.Close()
-
-	d := json.NewDecoder(newJSONCommentStripper(r))
-
-	var jsonAllowLists []struct {
-		Paths               []string
-		IgnoreMatchingLines []string
-	}
-
-	if err := d.Decode(&jsonAllowLists); err != nil {
-		return nil, err
-	}
-
-	var allowLists []allowList
-	for _, w := range jsonAllowLists {
-		for _, p := range w.Paths {
-			allowLists = append(allowLists, allowList{
-				path:                p,
-				ignoreMatchingLines: w.IgnoreMatchingLines,
-			})
-		}
-	}
-
-	return allowLists, err
-}
-
-func filterModifiedPaths(l [][2]*ZipArtifactFile, allowLists []allowList) ([][2]*ZipArtifactFile, error) {
+func filterModifiedPaths(l [][2]*ZipArtifactFile, allowLists []allowlist.AllowList) ([][2]*ZipArtifactFile, error) {
 outer:
 	for i := 0; i < len(l); i++ {
 		for _, w := range allowLists {
-			if match, err := Match(w.path, l[i][0].Name); err != nil {
+			if match, err := allowlist.Match(w.Path, l[i][0].Name); err != nil {
 				return l, err
 			} else if match {
-				if match, err := diffIgnoringMatchingLines(l[i][0], l[i][1], w.ignoreMatchingLines); err != nil {
+				if match, err := diffIgnoringMatchingLines(l[i][0], l[i][1], w.IgnoreMatchingLines); err != nil {
 					return l, err
-				} else if match || len(w.ignoreMatchingLines) == 0 {
+				} else if match || len(w.IgnoreMatchingLines) == 0 {
 					l = append(l[:i], l[i+1:]...)
 					i--
 				}
@@ -126,13 +43,13 @@ outer:
 	return l, nil
 }
 
-func filterNewPaths(l []*ZipArtifactFile, allowLists []allowList) ([]*ZipArtifactFile, error) {
+func filterNewPaths(l []*ZipArtifactFile, allowLists []allowlist.AllowList) ([]*ZipArtifactFile, error) {
 outer:
 	for i := 0; i < len(l); i++ {
 		for _, w := range allowLists {
-			if match, err := Match(w.path, l[i].Name); err != nil {
+			if match, err := allowlist.Match(w.Path, l[i].Name); err != nil {
 				return l, err
-			} else if match && len(w.ignoreMatchingLines) == 0 {
+			} else if match && len(w.IgnoreMatchingLines) == 0 {
 				l = append(l[:i], l[i+1:]...)
 				i--
 			}
@@ -148,51 +65,21 @@ outer:
 }
 
 func diffIgnoringMatchingLines(a *ZipArtifactFile, b *ZipArtifactFile, ignoreMatchingLines []string) (match bool, err error) {
-	lineMatchesIgnores := func(b []byte) (bool, error) {
-		for _, m := range ignoreMatchingLines {
-			if match, err := regexp.Match(m, b); err != nil {
-				return false, err
-			} else if match {
-				return match, nil
-			}
-		}
-		return false, nil
-	}
-
-	filter := func(z *ZipArtifactFile) ([]byte, error) {
-		var ret []byte
-
-		r, err := z.Open()
-		if err != nil {
-			return nil, err
-		}
-		s := bufio.NewScanner(r)
-
-		for s.Scan() {
-			if match, err := lineMatchesIgnores(s.Bytes()); err != nil {
-				return nil, err
-			} else if !match {
-				ret = append(ret, "\n"...)
-				ret = append(ret, s.Bytes()...)
-			}
-		}
-
-		return ret, nil
-	}
-
-	bufA, err := filter(a)
+	rA, err := a.Open()
 	if err != nil {
 		return false, err
 	}
-	bufB, err := filter(b)
+	defer rA.Close()
+	rB, err := b.Open()
 	if err != nil {
 		return false, err
 	}
+	defer rB.Close()
 
-	return bytes.Compare(bufA, bufB) == 0, nil
+	return allowlist.DiffIgnoringMatchingLines(rA, rB, ignoreMatchingLines)
 }
 
-func applyAllowLists(diff zipDiff, allowLists []allowList) (zipDiff, error) {
+func applyAllowLists(diff zipDiff, allowLists []allowlist.AllowList) (zipDiff, error) {
 	var err error
 
 	diff.modified, err = filterModifiedPaths(diff.modified, allowLists)
@@ -210,42 +97,3 @@ func applyAllowLists(diff zipDiff, allowLists []allowList) (zipDiff, error) {
 
 	return diff, nil
 }
-
-func newJSONCommentStripper(r io.Reader) *jsonCommentStripper {
-	return &jsonCommentStripper{
-		r: bufio.NewReader(r),
-	}
-}
-
-type jsonCommentStripper struct {
-	r   *bufio.Reader
-	b   []byte
-	err error
-}
-
-func (j *jsonCommentStripper) Read(buf []byte) (int, error) {
-	for len(j.b) == 0 {
-		if j.err != nil {
-			return 0, j.err
-		}
-
-		j.b, j.err = j.r.ReadBytes('\n')
-
-		if isComment(j.b) {
-			j.b = nil
-		}
-	}
-
-	n := copy(buf, j.b)
-	j.b = j.b[n:]
-	return n, nil
-}
-
-var commentPrefix = []byte("//")
-
-func isComment(b []byte) bool {
-	for len(b) > 0 && unicode.IsSpace(rune(b[0])) {
-		b = b[1:]
-	}
-	return bytes.HasPrefix(b, commentPrefix)
-}
@@ -19,6 +19,8 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+
+	"android/soong/cmd/diff_target_files/allowlist"
 )
 
 func bytesToZipArtifactFile(name string, data []byte) *ZipArtifactFile {
@@ -60,7 +62,7 @@ var f2 = bytesToZipArtifactFile("dir/f2", nil)
 func Test_applyAllowLists(t *testing.T) {
 	type args struct {
 		diff       zipDiff
-		allowLists []allowList
+		allowLists []allowlist.AllowList
 	}
 	tests := []struct {
 		name    string
@@ -74,7 +76,7 @@ func Test_applyAllowLists(t *testing.T) {
 				diff: zipDiff{
 					onlyInA: []*ZipArtifactFile{f1a, f2},
 				},
-				allowLists: []allowList{{path: "dir/f1"}},
+				allowLists: []allowlist.AllowList{{Path: "dir/f1"}},
 			},
 			want: zipDiff{
 				onlyInA: []*ZipArtifactFile{f2},
@@ -86,7 +88,7 @@ func Test_applyAllowLists(t *testing.T) {
 				diff: zipDiff{
 					onlyInA: []*ZipArtifactFile{f1a, f2},
 				},
-				allowLists: []allowList{{path: "dir/*"}},
+				allowLists: []allowlist.AllowList{{Path: "dir/*"}},
 			},
 			want: zipDiff{},
 		},
@@ -96,7 +98,7 @@ func Test_applyAllowLists(t *testing.T) {
 				diff: zipDiff{
 					modified: [][2]*ZipArtifactFile{{f1a, f1b}},
 				},
-				allowLists: []allowList{{path: "dir/*"}},
+				allowLists: []allowlist.AllowList{{Path: "dir/*"}},
 			},
 			want: zipDiff{},
 		},
@@ -106,7 +108,7 @@ func Test_applyAllowLists(t *testing.T) {
 				diff: zipDiff{
 					modified: [][2]*ZipArtifactFile{{f1a, f1b}},
 				},
-				allowLists: []allowList{{path: "dir/*", ignoreMatchingLines: []string{"foo: .*"}}},
+				allowLists: []allowlist.AllowList{{Path: "dir/*", IgnoreMatchingLines: []string{"foo: .*"}}},
 			},
 			want: zipDiff{},
 		},
@@ -0,0 +1,194 @@
+// Copyright 2019 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package allowlist parses the lists of files that are allowed to differ between two builds, like
+// known_nondeterminism.whitelist, and compares files while ignoring the lines they allow to differ.
+package allowlist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// An AllowList allows differences in the files whose names match Path, which may contain the
+// globs supported by Match. If IgnoreMatchingLines is set, only the lines that match one of its
+// regular expressions are allowed to differ.
+type AllowList struct {
+	Path                string
+	IgnoreMatchingLines []string
+}
+
+// Parse returns the allowlists from allowLists, which are in the form
+// <pattern>[:<regex of line to ignore>], and from the allowlist files in allowListFiles.
+func Parse(allowLists []string, allowListFiles []string) ([]AllowList, error) {
+	var ret []AllowList
+
+	add := func(path string, ignoreMatchingLines []string) {
+		for i := range ret {
+			if ret[i].Path == path {
+				ret[i].IgnoreMatchingLines = append(ret[i].IgnoreMatchingLines, ignoreMatchingLines...)
+				return
+			}
+		}
+
+		ret = append(ret, AllowList{
+			Path:                path,
+			IgnoreMatchingLines: ignoreMatchingLines,
+		})
+	}
+
+	for _, file := range allowListFiles {
+		newAllowlists, err := ParseFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range newAllowlists {
+			add(w.Path, w.IgnoreMatchingLines)
+		}
+	}
+
+	for _, s := range allowLists {
+		colon := strings.IndexRune(s, ':')
+		var ignoreMatchingLines []string
+		if colon >= 0 {
+			ignoreMatchingLines = []string{s[colon+1:]}
+			s = s[:colon]
+		}
+		add(s, ignoreMatchingLines)
+	}
+
+	return ret, nil
+}
+
+// ParseFile returns the allowlists in file, which contains a JSON list of objects with Paths and
+// IgnoreMatchingLines properties. Lines starting with // are comments.
+func ParseFile(file string) ([]AllowList, error) {
+	r, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	d := json.NewDecoder(newJSONCommentStripper(r))
+
+	var jsonAllowLists []struct {
+		Paths               []string
+		IgnoreMatchingLines []string
+	}
+
+	if err := d.Decode(&jsonAllowLists); err != nil {
+		return nil, err
+	}
+
+	var allowLists []AllowList
+	for _, w := range jsonAllowLists {
+		for _, p := range w.Paths {
+			allowLists = append(allowLists, AllowList{
+				Path:                p,
+				IgnoreMatchingLines: w.IgnoreMatchingLines,
+			})
+		}
+	}
+
+	return allowLists, err
+}
+
+// DiffIgnoringMatchingLines returns true if a and b have the same lines after removing the lines
+// that match any of the regular expressions in ignoreMatchingLines.
+func DiffIgnoringMatchingLines(a, b io.Reader, ignoreMatchingLines []string) (match bool, err error) {
+	lineMatchesIgnores := func(b []byte) (bool, error) {
+		for _, m := range ignoreMatchingLines {
+			if match, err := regexp.Match(m, b); err != nil {
+				return false, err
+			} else if match {
+				return match, nil
+			}
+		}
+		return false, nil
+	}
+
+	filter := func(r io.Reader) ([]byte, error) {
+		var ret []byte
+
+		s := bufio.NewScanner(r)
+
+		for s.Scan() {
+			if match, err := lineMatchesIgnores(s.Bytes()); err != nil {
+				return nil, err
+			} else if !match {
+				ret = append(ret, "\n"...)
+				ret = append(ret, s.Bytes()...)
+			}
+		}
+
+		return ret, s.Err()
+	}
+
+	bufA, err := filter(a)
+	if err != nil {
+		return false, err
+	}
+	bufB, err := filter(b)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Compare(bufA, bufB) == 0, nil
+}
+
+func newJSONCommentStripper(r io.Reader) *jsonCommentStripper {
+	return &jsonCommentStripper{
+		r: bufio.NewReader(r),
+	}
+}
+
+type jsonCommentStripper struct {
+	r   *bufio.Reader
+	b   []byte
+	err error
+}
+
+func (j *jsonCommentStripper) Read(buf []byte) (int, error) {
+	for len(j.b) == 0 {
+		if j.err != nil {
+			return 0, j.err
+		}
+
+		j.b, j.err = j.r.ReadBytes('\n')
+
+		if isComment(j.b) {
+			j.b = nil
+		}
+	}
+
+	n := copy(buf, j.b)
+	j.b = j.b[n:]
+	return n, nil
+}
+
+var commentPrefix = []byte("//")
+
+func isComment(b []byte) bool {
+	for len(b) > 0 && unicode.IsSpace(rune(b[0])) {
+		b = b[1:]
+	}
+	return bytes.HasPrefix(b, commentPrefix)
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allowlist
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "allowlist")
+	err := ioutil.WriteFile(file, []byte(`
+[
+  // Build date
+  {
+    "Paths": ["SYSTEM/build.prop", "VENDOR/build.prop"],
+    "IgnoreMatchingLines": ["ro\\..*build\\.date.*=.*"]
+  },
+  {
+    "Paths": ["**/*.log"]
+  }
+]
+`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Parse([]string{"SYSTEM/build.prop:ro.build.id=.*", "dir/*"}, []string{file})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []AllowList{
+		{Path: "SYSTEM/build.prop", IgnoreMatchingLines: []string{`ro\..*build\.date.*=.*`, "ro.build.id=.*"}},
+		{Path: "VENDOR/build.prop", IgnoreMatchingLines: []string{`ro\..*build\.date.*=.*`}},
+		{Path: "**/*.log"},
+		{Path: "dir/*"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %q\nwant %q", got, want)
+	}
+}
+
+func TestDiffIgnoringMatchingLines(t *testing.T) {
+	a := "a\nfoo: bar\nc\n"
+	b := "a\nfoo: baz\nc\n"
+
+	tests := []struct {
+		name   string
+		ignore []string
+		want   bool
+	}{
+		{name: "no ignores", want: false},
+		{name: "ignore other lines", ignore: []string{"bar: .*"}, want: false},
+		{name: "ignore different lines", ignore: []string{"foo: .*"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffIgnoringMatchingLines(strings.NewReader(a), strings.NewReader(b), tt.ignore)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("DiffIgnoringMatchingLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
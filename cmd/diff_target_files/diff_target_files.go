@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"android/soong/cmd/diff_target_files/allowlist"
 )
 
 var (
@@ -47,7 +49,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	allowLists, err := parseAllowLists(*allowLists, *allowListFiles)
+	allowLists, err := allowlist.Parse(*allowLists, *allowListFiles)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing allowlists: %v\n", err)
 		os.Exit(1)
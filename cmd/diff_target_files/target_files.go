@@ -17,6 +17,8 @@ package main
 import (
 	"fmt"
 	"strings"
+
+	"android/soong/cmd/diff_target_files/allowlist"
 )
 
 const targetFilesPattern = "*-target_files-*.zip"
@@ -72,7 +74,7 @@ outer:
 
 		if patterns != nil {
 			for _, pattern := range patterns {
-				match, _ := Match(pattern, f.Name)
+				match, _ := allowlist.Match(pattern, f.Name)
 				if match {
 					ret = append(ret, f)
 				}
@@ -40,6 +40,14 @@ type TargetConfig struct {
 	abis             map[android_bundle_proto.Abi_AbiAlias]int
 	allowPrereleased bool
 	stem             string
+	// Languages of the device's locales. If empty, the splits for every language are selected.
+	languages map[string]bool
+	// Map holding <texture compression format alias>:<its sequence number in the flag> info.
+	// If empty, only the fallback texture compression format splits are selected.
+	textureCompressionFormats map[android_bundle_proto.TextureCompressionFormat_TextureCompressionFormatAlias]int
+	deviceTier                int32
+	// Name of the device's country set. If empty, only the fallback country set splits are selected.
+	countrySet string
 }
 
 // An APK set is a zip archive. An entry 'toc.pb' describes its contents.
@@ -145,21 +153,129 @@ func (m apkTargetingMatcher) matches(config TargetConfig) bool {
 			languageTargetingMatcher{m.LanguageTargeting}.matches(config) &&
 			screenDensityTargetingMatcher{m.ScreenDensityTargeting}.matches(config) &&
 			sdkVersionTargetingMatcher{m.SdkVersionTargeting}.matches(config) &&
-			multiAbiTargetingMatcher{m.MultiAbiTargeting}.matches(config))
+			multiAbiTargetingMatcher{m.MultiAbiTargeting}.matches(config) &&
+			textureCompressionFormatTargetingMatcher{m.TextureCompressionFormatTargeting}.matches(config) &&
+			deviceTierTargetingMatcher{m.DeviceTierTargeting}.matches(config) &&
+			countrySetTargetingMatcher{m.CountrySetTargeting}.matches(config))
+}
+
+type countrySetTargetingMatcher struct {
+	*android_bundle_proto.CountrySetTargeting
+}
+
+func (m countrySetTargetingMatcher) matches(config TargetConfig) bool {
+	if m.CountrySetTargeting == nil {
+		return true
+	}
+	if len(m.GetValue()) == 0 {
+		// The fallback split is for the devices whose country set has no split of its own.
+		for _, a := range m.GetAlternatives() {
+			if a == config.countrySet {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range m.GetValue() {
+		if v == config.countrySet {
+			return true
+		}
+	}
+	return false
+}
+
+type deviceTierTargetingMatcher struct {
+	*android_bundle_proto.DeviceTierTargeting
+}
+
+func (m deviceTierTargetingMatcher) matches(config TargetConfig) bool {
+	if m.DeviceTierTargeting == nil {
+		return true
+	}
+	// Select the split for the highest tier that doesn't exceed the device's tier, or for the
+	// lowest tier if they all do.
+	var tiers []int32
+	for _, v := range m.GetValue() {
+		tiers = append(tiers, v.GetValue())
+	}
+	for _, a := range m.GetAlternatives() {
+		tiers = append(tiers, a.GetValue())
+	}
+	if len(tiers) == 0 {
+		return true
+	}
+	best, lowest := int32(-1), tiers[0]
+	for _, tier := range tiers {
+		if tier <= config.deviceTier && tier > best {
+			best = tier
+		}
+		if tier < lowest {
+			lowest = tier
+		}
+	}
+	if best < 0 {
+		best = lowest
+	}
+	for _, v := range m.GetValue() {
+		if v.GetValue() == best {
+			return true
+		}
+	}
+	return false
 }
 
 type languageTargetingMatcher struct {
 	*android_bundle_proto.LanguageTargeting
 }
 
-func (m languageTargetingMatcher) matches(_ TargetConfig) bool {
-	if m.LanguageTargeting == nil {
+func (m languageTargetingMatcher) matches(config TargetConfig) bool {
+	if m.LanguageTargeting == nil || len(config.languages) == 0 {
 		return true
 	}
-	log.Fatal("language based entry selection is not implemented")
+	if len(m.GetValue()) > 0 {
+		// A language split matches if the device has any of its languages.
+		for _, v := range m.GetValue() {
+			if config.languages[localeLanguage(v)] {
+				return true
+			}
+		}
+		return false
+	}
+	// A split without languages has the resources for the languages that don't have their
+	// own split. Like bundletool, select it unless every device language has its own split.
+	alternatives := make(map[string]bool)
+	for _, a := range m.GetAlternatives() {
+		alternatives[localeLanguage(a)] = true
+	}
+	for language := range config.languages {
+		if !alternatives[language] {
+			return true
+		}
+	}
 	return false
 }
 
+// Obsolete ISO-639 language codes that are still used by Java and by old bundles.
+var obsoleteLanguageCodes = map[string]string{
+	"iw": "he",
+	"in": "id",
+	"ji": "yi",
+}
+
+// localeLanguage returns the language of a locale, which may be a BCP-47 tag like "zh-Hant-TW",
+// an Android resource qualifier like "en-rUS" or "b+sr+Latn", or a locale like "en_US".
+func localeLanguage(locale string) string {
+	locale = strings.TrimPrefix(locale, "b+")
+	if i := strings.IndexAny(locale, "-_+"); i >= 0 {
+		locale = locale[:i]
+	}
+	language := strings.ToLower(locale)
+	if current, ok := obsoleteLanguageCodes[language]; ok {
+		return current
+	}
+	return language
+}
+
 type moduleMetadataMatcher struct {
 	*android_bundle_proto.ModuleMetadata
 }
@@ -280,12 +396,37 @@ type textureCompressionFormatTargetingMatcher struct {
 	*android_bundle_proto.TextureCompressionFormatTargeting
 }
 
-func (m textureCompressionFormatTargetingMatcher) matches(_ TargetConfig) bool {
+func (m textureCompressionFormatTargetingMatcher) matches(config TargetConfig) bool {
 	if m.TextureCompressionFormatTargeting == nil {
 		return true
 	}
-	log.Fatal("texture based entry selection is not implemented")
-	return false
+	if len(m.GetValue()) == 0 {
+		// The fallback split is for the devices that support none of the targeted formats.
+		for _, a := range m.GetAlternatives() {
+			if _, ok := config.textureCompressionFormats[a.Alias]; ok {
+				return false
+			}
+		}
+		return true
+	}
+	// Find the one that appears first in the texture compression formats flag.
+	formatIdx := math.MaxInt32
+	for _, v := range m.GetValue() {
+		if i, ok := config.textureCompressionFormats[v.Alias]; ok && i < formatIdx {
+			formatIdx = i
+		}
+	}
+	if formatIdx == math.MaxInt32 {
+		return false
+	}
+	// See if any alternatives appear before the above one.
+	for _, a := range m.GetAlternatives() {
+		if i, ok := config.textureCompressionFormats[a.Alias]; ok && i < formatIdx {
+			// There is a better alternative. Skip this one.
+			return false
+		}
+	}
+	return true
 }
 
 type userCountriesTargetingMatcher struct {
@@ -440,8 +581,9 @@ var (
 	outputFile   = flag.String("o", "", "output file for primary entry")
 	zipFile      = flag.String("zip", "", "output file containing additional extracted entries")
 	targetConfig = TargetConfig{
-		screenDpi: map[android_bundle_proto.ScreenDensity_DensityAlias]bool{},
-		abis:      map[android_bundle_proto.Abi_AbiAlias]int{},
+		screenDpi:                 map[android_bundle_proto.ScreenDensity_DensityAlias]bool{},
+		abis:                      map[android_bundle_proto.Abi_AbiAlias]int{},
+		textureCompressionFormats: map[android_bundle_proto.TextureCompressionFormat_TextureCompressionFormatAlias]int{},
 	}
 	extractSingle = flag.Bool("extract-single", false,
 		"extract a single target and output it uncompressed. only available for standalone apks and apexes.")
@@ -470,6 +612,55 @@ func (a abiFlagValue) Set(abiList string) error {
 	return nil
 }
 
+// Parse locale values
+type localeFlagValue struct {
+	targetConfig *TargetConfig
+}
+
+func (l localeFlagValue) String() string {
+	return "all"
+}
+
+func (l localeFlagValue) Set(localeList string) error {
+	if localeList == "all" {
+		return nil
+	}
+	for _, locale := range strings.Split(localeList, ",") {
+		language := localeLanguage(locale)
+		if language == "" {
+			return fmt.Errorf("bad locale value: %q", locale)
+		}
+		if l.targetConfig.languages == nil {
+			l.targetConfig.languages = make(map[string]bool)
+		}
+		l.targetConfig.languages[language] = true
+	}
+	return nil
+}
+
+// Parse texture compression format values
+type textureCompressionFormatFlagValue struct {
+	targetConfig *TargetConfig
+}
+
+func (t textureCompressionFormatFlagValue) String() string {
+	return "none"
+}
+
+func (t textureCompressionFormatFlagValue) Set(formatList string) error {
+	if formatList == "none" {
+		return nil
+	}
+	for i, format := range strings.Split(formatList, ",") {
+		v, ok := android_bundle_proto.TextureCompressionFormat_TextureCompressionFormatAlias_value[format]
+		if !ok {
+			return fmt.Errorf("bad texture compression format value: %q", format)
+		}
+		t.targetConfig.textureCompressionFormats[android_bundle_proto.TextureCompressionFormat_TextureCompressionFormatAlias(v)] = i
+	}
+	return nil
+}
+
 // Parse screen density values
 type screenDensityFlagValue struct {
 	targetConfig *TargetConfig
@@ -501,7 +692,8 @@ func processArgs() {
 	flag.Usage = func() {
 		fmt.Fprintln(os.Stderr, `usage: extract_apks -o <output-file> [-zip <output-zip-file>] `+
 			`-sdk-version value -abis value `+
-			`-screen-densities value {-stem value | -extract-single} [-allow-prereleased] `+
+			`-screen-densities value [-locales value] [-texture-compression-formats value] [-device-tier value] `+
+			`[-country-set value] {-stem value | -extract-single} [-allow-prereleased] `+
 			`[-apkcerts <apkcerts output file> -partition <partition>] <APK set>`)
 		flag.PrintDefaults()
 		os.Exit(2)
@@ -511,6 +703,13 @@ func processArgs() {
 		"comma-separated ABIs list of ARMEABI ARMEABI_V7A ARM64_V8A X86 X86_64 MIPS MIPS64")
 	flag.Var(screenDensityFlagValue{&targetConfig}, "screen-densities",
 		"'all' or comma-separated list of screen density names (NODPI LDPI MDPI TVDPI HDPI XHDPI XXHDPI XXXHDPI)")
+	flag.Var(localeFlagValue{&targetConfig}, "locales",
+		"'all' or comma-separated list of device locales (e.g. en-US,fr,zh-Hant-TW). Selects the splits for their languages.")
+	flag.Var(textureCompressionFormatFlagValue{&targetConfig}, "texture-compression-formats",
+		"'none' or comma-separated list of the supported texture compression formats, most preferred first "+
+			"(ETC1_RGB8 PALETTED THREE_DC ATC LATC DXT1 S3TC PVRTC ASTC ETC2)")
+	deviceTier := flag.Uint("device-tier", 0, "device tier")
+	flag.StringVar(&targetConfig.countrySet, "country-set", "", "name of the device's country set")
 	flag.BoolVar(&targetConfig.allowPrereleased, "allow-prereleased", false,
 		"allow prereleased")
 	flag.StringVar(&targetConfig.stem, "stem", "", "output entries base name in the output zip file")
@@ -521,6 +720,7 @@ func processArgs() {
 		flag.Usage()
 	}
 	targetConfig.sdkVersion = int32(*version)
+	targetConfig.deviceTier = int32(*deviceTier)
 
 }
 
@@ -420,6 +420,269 @@ bundletool {
 	}
 }
 
+func TestSelectApks_Languages(t *testing.T) {
+	testCases := []testDesc{
+		{
+			protoText: `
+variant {
+  apk_set {
+    module_metadata {
+      name: "base" targeting {} delivery_type: INSTALL_TIME }
+    apk_description {
+      targeting {}
+      path: "splits/base-master.apk"
+      split_apk_metadata { is_master_split: true } }
+    apk_description {
+      targeting {
+        language_targeting {
+          alternatives: "de"
+          alternatives: "fr"
+          alternatives: "iw" } }
+      path: "splits/base-other_lang.apk"
+      split_apk_metadata { split_id: "config.other_lang" } }
+    apk_description {
+      targeting {
+        language_targeting {
+          value: "de"
+          alternatives: "fr"
+          alternatives: "iw" } }
+      path: "splits/base-de.apk"
+      split_apk_metadata { split_id: "config.de" } }
+    apk_description {
+      targeting {
+        language_targeting {
+          value: "fr"
+          alternatives: "de"
+          alternatives: "iw" } }
+      path: "splits/base-fr.apk"
+      split_apk_metadata { split_id: "config.fr" } }
+    apk_description {
+      targeting {
+        language_targeting {
+          value: "iw"
+          alternatives: "de"
+          alternatives: "fr" } }
+      path: "splits/base-iw.apk"
+      split_apk_metadata { split_id: "config.iw" } } }
+}
+bundletool {
+  version: "1.8.0" }
+`,
+			configs: []testConfigDesc{
+				{
+					name:         "all",
+					targetConfig: TargetConfig{},
+					expected: SelectionResult{
+						"base",
+						[]string{
+							"splits/base-master.apk",
+							"splits/base-other_lang.apk",
+							"splits/base-de.apk",
+							"splits/base-fr.apk",
+							"splits/base-iw.apk",
+						},
+					},
+				},
+				{
+					name: "split languages only",
+					targetConfig: TargetConfig{
+						languages: map[string]bool{"fr": true, "he": true},
+					},
+					expected: SelectionResult{
+						"base",
+						[]string{
+							"splits/base-master.apk",
+							"splits/base-fr.apk",
+							"splits/base-iw.apk",
+						},
+					},
+				},
+				{
+					name: "fallback",
+					targetConfig: TargetConfig{
+						languages: map[string]bool{"de": true, "ja": true},
+					},
+					expected: SelectionResult{
+						"base",
+						[]string{
+							"splits/base-master.apk",
+							"splits/base-other_lang.apk",
+							"splits/base-de.apk",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		var toc bp.BuildApksResult
+		if err := prototext.Unmarshal([]byte(testCase.protoText), &toc); err != nil {
+			t.Fatal(err)
+		}
+		for _, config := range testCase.configs {
+			actual := selectApks(&toc, config.targetConfig)
+			if !reflect.DeepEqual(config.expected, actual) {
+				t.Errorf("%s: expected %v, got %v", config.name, config.expected, actual)
+			}
+		}
+	}
+}
+
+func TestSelectApks_DeviceTargeting(t *testing.T) {
+	testCases := []testDesc{
+		{
+			protoText: `
+variant {
+  apk_set {
+    module_metadata {
+      name: "base" targeting {} delivery_type: INSTALL_TIME }
+    apk_description {
+      targeting {}
+      path: "splits/base-master.apk"
+      split_apk_metadata { is_master_split: true } }
+    apk_description {
+      targeting {
+        texture_compression_format_targeting {
+          alternatives { alias: ASTC }
+          alternatives { alias: ETC2 } } }
+      path: "splits/base-other_tcf.apk"
+      split_apk_metadata { split_id: "config.other_tcf" } }
+    apk_description {
+      targeting {
+        texture_compression_format_targeting {
+          value { alias: ASTC }
+          alternatives { alias: ETC2 } } }
+      path: "splits/base-astc.apk"
+      split_apk_metadata { split_id: "config.astc" } }
+    apk_description {
+      targeting {
+        texture_compression_format_targeting {
+          value { alias: ETC2 }
+          alternatives { alias: ASTC } } }
+      path: "splits/base-etc2.apk"
+      split_apk_metadata { split_id: "config.etc2" } }
+    apk_description {
+      targeting {
+        device_tier_targeting {
+          value { value: 0 }
+          alternatives { value: 2 } } }
+      path: "splits/base-tier_0.apk"
+      split_apk_metadata { split_id: "config.tier_0" } }
+    apk_description {
+      targeting {
+        device_tier_targeting {
+          value { value: 2 }
+          alternatives { value: 0 } } }
+      path: "splits/base-tier_2.apk"
+      split_apk_metadata { split_id: "config.tier_2" } }
+    apk_description {
+      targeting {
+        country_set_targeting {
+          alternatives: "latam"
+          alternatives: "sea" } }
+      path: "splits/base-other_countries.apk"
+      split_apk_metadata { split_id: "config.other_countries" } }
+    apk_description {
+      targeting {
+        country_set_targeting {
+          value: "latam"
+          alternatives: "sea" } }
+      path: "splits/base-countries_latam.apk"
+      split_apk_metadata { split_id: "config.countries_latam" } } }
+}
+bundletool {
+  version: "1.13.0" }
+`,
+			configs: []testConfigDesc{
+				{
+					name:         "defaults",
+					targetConfig: TargetConfig{},
+					expected: SelectionResult{
+						"base",
+						[]string{
+							"splits/base-master.apk",
+							"splits/base-other_tcf.apk",
+							"splits/base-tier_0.apk",
+							"splits/base-other_countries.apk",
+						},
+					},
+				},
+				{
+					name: "preferred format, highest tier and country set",
+					targetConfig: TargetConfig{
+						textureCompressionFormats: map[bp.TextureCompressionFormat_TextureCompressionFormatAlias]int{
+							bp.TextureCompressionFormat_ETC2: 0,
+							bp.TextureCompressionFormat_ASTC: 1,
+						},
+						deviceTier: 3,
+						countrySet: "latam",
+					},
+					expected: SelectionResult{
+						"base",
+						[]string{
+							"splits/base-master.apk",
+							"splits/base-etc2.apk",
+							"splits/base-tier_2.apk",
+							"splits/base-countries_latam.apk",
+						},
+					},
+				},
+				{
+					name: "missing tier and other country set",
+					targetConfig: TargetConfig{
+						textureCompressionFormats: map[bp.TextureCompressionFormat_TextureCompressionFormatAlias]int{
+							bp.TextureCompressionFormat_DXT1: 0,
+							bp.TextureCompressionFormat_ASTC: 1,
+						},
+						deviceTier: 1,
+						countrySet: "eu",
+					},
+					expected: SelectionResult{
+						"base",
+						[]string{
+							"splits/base-master.apk",
+							"splits/base-astc.apk",
+							"splits/base-tier_0.apk",
+							"splits/base-other_countries.apk",
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		var toc bp.BuildApksResult
+		if err := prototext.Unmarshal([]byte(testCase.protoText), &toc); err != nil {
+			t.Fatal(err)
+		}
+		for _, config := range testCase.configs {
+			actual := selectApks(&toc, config.targetConfig)
+			if !reflect.DeepEqual(config.expected, actual) {
+				t.Errorf("%s: expected %v, got %v", config.name, config.expected, actual)
+			}
+		}
+	}
+}
+
+func TestLocaleLanguage(t *testing.T) {
+	testCases := map[string]string{
+		"en":         "en",
+		"en-US":      "en",
+		"en_US":      "en",
+		"fr-rCA":     "fr",
+		"zh-Hant-TW": "zh",
+		"b+sr+Latn":  "sr",
+		"FIL":        "fil",
+		"iw":         "he",
+		"in-ID":      "id",
+	}
+	for locale, expected := range testCases {
+		if actual := localeLanguage(locale); actual != expected {
+			t.Errorf("localeLanguage(%q) = %q, expected %q", locale, actual, expected)
+		}
+	}
+}
+
 type testZip2ZipWriter struct {
 	entries map[string]string
 }
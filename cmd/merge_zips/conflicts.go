@@ -0,0 +1,263 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/google/blueprint/pathtools"
+
+	"android/soong/jar"
+	"android/soong/third_party/zip"
+)
+
+// A conflictPolicy selects what happens when more than one input zip contains an entry with the
+// same name.
+type conflictPolicy int
+
+const (
+	// conflictIdenticalOnly allows duplicates with identical contents and keeps the first one. It
+	// is the default.
+	conflictIdenticalOnly conflictPolicy = iota
+	// conflictError doesn't allow any duplicates.
+	conflictError
+	// conflictFirstWins keeps the entry from the first input zip that contains it.
+	conflictFirstWins
+	// conflictLastWins keeps the entry from the last input zip that contains it.
+	conflictLastWins
+	// conflictConcatenate concatenates the different contents of all the entries, for files like
+	// META-INF/services/* that list one item per line.
+	conflictConcatenate
+)
+
+var conflictPolicyNames = map[string]conflictPolicy{
+	"identical-only": conflictIdenticalOnly,
+	"error":          conflictError,
+	"first-wins":     conflictFirstWins,
+	"last-wins":      conflictLastWins,
+	"concatenate":    conflictConcatenate,
+}
+
+func (p conflictPolicy) String() string {
+	for name, policy := range conflictPolicyNames {
+		if policy == p {
+			return name
+		}
+	}
+	return fmt.Sprintf("conflictPolicy(%d)", int(p))
+}
+
+// A conflictRule applies a conflictPolicy to the entries whose names match glob.
+type conflictRule struct {
+	glob   string
+	policy conflictPolicy
+}
+
+// conflictRules is a list of conflictRules where the last rule that matches an entry wins, like
+// the -align rules. It can be used as a flag.Value that parses rules in the form <glob>:<policy>.
+type conflictRules []conflictRule
+
+func (r *conflictRules) String() string {
+	var rules []string
+	for _, rule := range *r {
+		rules = append(rules, rule.glob+":"+rule.policy.String())
+	}
+	return strings.Join(rules, " ")
+}
+
+func (r *conflictRules) Set(s string) error {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return fmt.Errorf("invalid conflict rule %q, must be <glob>:<policy>", s)
+	}
+	return r.add(s[:i], s[i+1:])
+}
+
+func (r *conflictRules) add(glob, policyName string) error {
+	policy, ok := conflictPolicyNames[policyName]
+	if !ok {
+		return fmt.Errorf("invalid conflict policy %q, must be one of error, first-wins, last-wins, "+
+			"identical-only or concatenate", policyName)
+	}
+	if _, err := pathtools.Match(glob, "x"); err != nil {
+		return fmt.Errorf("invalid glob %q: %w", glob, err)
+	}
+	*r = append(*r, conflictRule{glob, policy})
+	return nil
+}
+
+// conflictRulesFile is a flag.Value that adds the rules in a file to a conflictRules.
+type conflictRulesFile struct {
+	rules *conflictRules
+}
+
+func (f conflictRulesFile) String() string {
+	return `""`
+}
+
+func (f conflictRulesFile) Set(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := f.rules.parse(file); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// parse adds the rules in r, which contains a <glob> <policy> pair per line. Empty lines and lines
+// starting with # are ignored.
+func (r *conflictRules) parse(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return fmt.Errorf("line %d: expected <glob> <policy>, got %q", line, text)
+		}
+		if err := r.add(fields[0], fields[1]); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// policy returns the conflict policy for the entry name, or def if no rule matches it.
+func (r conflictRules) policy(name string, def conflictPolicy) conflictPolicy {
+	for i := len(r) - 1; i >= 0; i-- {
+		if match, _ := pathtools.Match(r[i].glob, name); match {
+			return r[i].policy
+		}
+	}
+	return def
+}
+
+// replacesEntries returns true if any rule may replace an entry after it was added, which requires
+// delaying writing the entries until all the inputs have been read.
+func (r conflictRules) replacesEntries() bool {
+	for _, rule := range r {
+		if rule.policy == conflictLastWins || rule.policy == conflictConcatenate {
+			return true
+		}
+	}
+	return false
+}
+
+// a ZipEntryConcatenation is a ZipEntryContents whose content is the concatenation of the contents
+// of other entries, skipping the ones that are identical to an earlier one
+type ZipEntryConcatenation struct {
+	sources []ZipEntryContents
+}
+
+func (ce *ZipEntryConcatenation) String() string {
+	var names []string
+	for _, source := range ce.sources {
+		names = append(names, source.String())
+	}
+	return strings.Join(names, " + ")
+}
+
+func (ce *ZipEntryConcatenation) IsDir() bool {
+	return false
+}
+
+func (ce *ZipEntryConcatenation) CRC32() uint32 {
+	content, err := ce.content()
+	if err != nil {
+		return 0
+	}
+	return crc32.ChecksumIEEE(content)
+}
+
+func (ce *ZipEntryConcatenation) Size() uint64 {
+	content, err := ce.content()
+	if err != nil {
+		return 0
+	}
+	return uint64(len(content))
+}
+
+func (ce *ZipEntryConcatenation) Open() (io.ReadCloser, error) {
+	content, err := ce.content()
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
+// add adds source to the concatenation unless its contents are identical to one of the sources
+// already in it, and returns whether it was added.
+func (ce *ZipEntryConcatenation) add(source ZipEntryContents) bool {
+	for _, s := range ce.sources {
+		if s.CRC32() == source.CRC32() && s.Size() == source.Size() {
+			return false
+		}
+	}
+	ce.sources = append(ce.sources, source)
+	return true
+}
+
+// content returns the concatenated contents, separated by newlines if a source doesn't end with
+// one.
+func (ce *ZipEntryConcatenation) content() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	for _, source := range ce.sources {
+		r, err := source.Open()
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(buf, r)
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", source, err)
+		}
+		if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (ce *ZipEntryConcatenation) WriteToZip(dest string, zw *zip.Writer) error {
+	content, err := ce.content()
+	if err != nil {
+		return err
+	}
+	fh := &zip.FileHeader{
+		Name:               dest,
+		Method:             zip.Deflate,
+		UncompressedSize64: uint64(len(content)),
+	}
+	fh.SetMode(0644)
+	fh.SetModTime(jar.DefaultTime)
+	w, err := zw.CreateHeader(fh)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"android/soong/jar"
+	"android/soong/third_party/zip"
+)
+
+func TestConflictRules(t *testing.T) {
+	var rules conflictRules
+	err := rules.parse(strings.NewReader(`
+# Service files list one implementation per line.
+META-INF/services/* concatenate
+
+**/*.properties   last-wins
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rules.Set("foo/**/*.properties:first-wins"); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]conflictPolicy{
+		"META-INF/services/java.sql.Driver": conflictConcatenate,
+		"META-INF/MANIFEST.MF":              conflictIdenticalOnly,
+		"a.properties":                      conflictLastWins,
+		"bar/a.properties":                  conflictLastWins,
+		"foo/bar/a.properties":              conflictFirstWins,
+	}
+	for name, want := range testCases {
+		if got := rules.policy(name, conflictIdenticalOnly); got != want {
+			t.Errorf("policy(%q) = %s, want %s", name, got, want)
+		}
+	}
+	if !rules.replacesEntries() {
+		t.Errorf("expected replacesEntries() to be true for %s", rules.String())
+	}
+
+	for _, invalid := range []string{"concatenate", "*:merge", "[:error"} {
+		if err := rules.Set(invalid); err == nil {
+			t.Errorf("expected error for rule %q", invalid)
+		}
+	}
+	err = rules.parse(strings.NewReader("META-INF/services/*\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected error on line 1, got %v", err)
+	}
+}
+
+func TestMergeZipsConcatenate(t *testing.T) {
+	service1 := testZipEntry{"META-INF/services/foo.Service", 0644, []byte("foo.Impl1")}
+	service2 := testZipEntry{"META-INF/services/foo.Service", 0644, []byte("foo.Impl2\n")}
+	service3 := testZipEntry{"META-INF/services/foo.Service", 0644, []byte("foo.Impl3\n")}
+
+	inputZips := []InputZip{
+		&testInputZip{name: "in0", entries: []testZipEntry{metainfDir, service1, a}},
+		&testInputZip{name: "in1", entries: []testZipEntry{metainfDir, service2, bc}},
+		&testInputZip{name: "in2", entries: []testZipEntry{service1, a2}},
+		&testInputZip{name: "in3", entries: []testZipEntry{service3}},
+	}
+
+	var conflicts conflictRules
+	for _, rule := range []string{"META-INF/services/*:concatenate", "a:last-wins"} {
+		if err := conflicts.Set(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := &bytes.Buffer{}
+	report := &bytes.Buffer{}
+	writer := zip.NewWriter(out)
+	err := mergeZips(inputZips, writer, "", "", false, true, false, false, false,
+		nil, nil, nil, conflicts, report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		jar.MetaDir:   "",
+		service1.name: "foo.Impl1\nfoo.Impl2\nfoo.Impl3\n",
+		a.name:        string(a2.data),
+		bc.name:       string(bc.data),
+	}
+	if len(zr.File) != len(want) {
+		t.Errorf("want %d entries, got:\n%s", len(want), dumpZip(out.Bytes()))
+	}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(contents) != want[f.Name] {
+			t.Errorf("%s: want %q, got %q", f.Name, want[f.Name], contents)
+		}
+	}
+
+	wantReport := "META-INF/\tin0\n" +
+		"META-INF/services/foo.Service\tin0\tin1\tin3\n" +
+		"a\tin2\n" +
+		"b/c\tin1\n"
+	if report.String() != wantReport {
+		t.Errorf("want report:\n%s\ngot:\n%s", wantReport, report.String())
+	}
+}
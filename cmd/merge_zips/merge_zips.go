@@ -15,6 +15,7 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
@@ -33,6 +34,7 @@ import (
 
 	"android/soong/jar"
 	"android/soong/third_party/zip"
+	soong_zip "android/soong/zip"
 )
 
 // Input zip: we can open it, close it, and obtain an array of entries
@@ -50,6 +52,7 @@ type ZipEntryContents interface {
 	IsDir() bool
 	CRC32() uint32
 	Size() uint64
+	Open() (io.ReadCloser, error)
 	WriteToZip(dest string, zw *zip.Writer) error
 }
 
@@ -92,6 +95,13 @@ func (ze ZipEntryFromZip) Size() uint64 {
 	return ze.size
 }
 
+func (ze ZipEntryFromZip) Open() (io.ReadCloser, error) {
+	if err := ze.inputZip.Open(); err != nil {
+		return nil, err
+	}
+	return ze.inputZip.Entries()[ze.index].Open()
+}
+
 func (ze ZipEntryFromZip) WriteToZip(dest string, zw *zip.Writer) error {
 	if err := ze.inputZip.Open(); err != nil {
 		return err
@@ -121,6 +131,10 @@ func (be ZipEntryFromBuffer) Size() uint64 {
 	return uint64(len(be.content))
 }
 
+func (be ZipEntryFromBuffer) Open() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(be.content)), nil
+}
+
 func (be ZipEntryFromBuffer) WriteToZip(dest string, zw *zip.Writer) error {
 	w, err := zw.CreateHeader(be.fh)
 	if err != nil {
@@ -139,24 +153,34 @@ func (be ZipEntryFromBuffer) WriteToZip(dest string, zw *zip.Writer) error {
 
 // Processing state.
 type OutputZip struct {
-	outputWriter     *zip.Writer
-	stripDirEntries  bool
-	emulateJar       bool
-	sortEntries      bool
-	ignoreDuplicates bool
-	excludeDirs      []string
-	excludeFiles     []string
-	sourceByDest     map[string]ZipEntryContents
+	outputWriter    *zip.Writer
+	stripDirEntries bool
+	emulateJar      bool
+	sortEntries     bool
+	defaultPolicy   conflictPolicy
+	conflicts       conflictRules
+	excludeDirs     []string
+	excludeFiles    []string
+	sourceByDest    map[string]ZipEntryContents
+	// The entry names in the order they were added.
+	order []string
+	// The inputs that contributed to each entry, for the sources report.
+	originsByDest map[string][]string
 }
 
 func NewOutputZip(outputWriter *zip.Writer, sortEntries, emulateJar, stripDirEntries, ignoreDuplicates bool) *OutputZip {
+	defaultPolicy := conflictIdenticalOnly
+	if ignoreDuplicates {
+		defaultPolicy = conflictFirstWins
+	}
 	return &OutputZip{
-		outputWriter:     outputWriter,
-		stripDirEntries:  stripDirEntries,
-		emulateJar:       emulateJar,
-		sortEntries:      sortEntries,
-		sourceByDest:     make(map[string]ZipEntryContents, 0),
-		ignoreDuplicates: ignoreDuplicates,
+		outputWriter:    outputWriter,
+		stripDirEntries: stripDirEntries,
+		emulateJar:      emulateJar,
+		sortEntries:     sortEntries,
+		defaultPolicy:   defaultPolicy,
+		sourceByDest:    make(map[string]ZipEntryContents, 0),
+		originsByDest:   make(map[string][]string),
 	}
 }
 
@@ -171,15 +195,27 @@ func (oz *OutputZip) setExcludeFiles(excludeFiles []string) {
 	oz.excludeFiles = excludeFiles
 }
 
-// Adds an entry with given name whose source is given ZipEntryContents. Returns old ZipEntryContents
-// if entry with given name already exists.
-func (oz *OutputZip) addZipEntry(name string, source ZipEntryContents) (ZipEntryContents, error) {
+func (oz *OutputZip) setConflictRules(conflicts conflictRules) {
+	oz.conflicts = conflicts
+}
+
+// Returns true if writing the entries has to be delayed until all the inputs have been read, either
+// because they need to be rearranged or because a conflict policy may replace them.
+func (oz *OutputZip) delayWrites() bool {
+	return oz.emulateJar || oz.sortEntries || oz.conflicts.replacesEntries()
+}
+
+// Adds an entry with given name whose source is given ZipEntryContents, read from the input origin.
+// Returns old ZipEntryContents if entry with given name already exists.
+func (oz *OutputZip) addZipEntry(name string, source ZipEntryContents, origin string) (ZipEntryContents, error) {
 	if existingSource, exists := oz.sourceByDest[name]; exists {
 		return existingSource, nil
 	}
 	oz.sourceByDest[name] = source
+	oz.order = append(oz.order, name)
+	oz.originsByDest[name] = []string{origin}
 	// Delay writing an entry if entries need to be rearranged.
-	if oz.emulateJar || oz.sortEntries {
+	if oz.delayWrites() {
 		return nil, nil
 	}
 	return nil, source.WriteToZip(name, oz.outputWriter)
@@ -188,7 +224,7 @@ func (oz *OutputZip) addZipEntry(name string, source ZipEntryContents) (ZipEntry
 // Adds an entry for the manifest (META-INF/MANIFEST.MF from the given file
 func (oz *OutputZip) addManifest(manifestPath string) error {
 	if !oz.stripDirEntries {
-		if _, err := oz.addZipEntry(jar.MetaDir, ZipEntryFromBuffer{jar.MetaDirFileHeader(), nil}); err != nil {
+		if _, err := oz.addZipEntry(jar.MetaDir, ZipEntryFromBuffer{jar.MetaDirFileHeader(), nil}, manifestPath); err != nil {
 			return err
 		}
 	}
@@ -196,7 +232,7 @@ func (oz *OutputZip) addManifest(manifestPath string) error {
 	if err == nil {
 		fh, buf, err := jar.ManifestFileContents(contents)
 		if err == nil {
-			_, err = oz.addZipEntry(jar.ManifestFile, ZipEntryFromBuffer{fh, buf})
+			_, err = oz.addZipEntry(jar.ManifestFile, ZipEntryFromBuffer{fh, buf}, manifestPath)
 		}
 	}
 	return err
@@ -213,7 +249,7 @@ func (oz *OutputZip) addZipEntryFromFile(name string, path string) error {
 		}
 		fh.SetMode(0700)
 		fh.SetModTime(jar.DefaultTime)
-		_, err = oz.addZipEntry(name, ZipEntryFromBuffer{fh, buf})
+		_, err = oz.addZipEntry(name, ZipEntryFromBuffer{fh, buf}, path)
 	}
 	return err
 }
@@ -227,7 +263,7 @@ func (oz *OutputZip) addEmptyEntry(entry string) error {
 	}
 	fh.SetMode(0700)
 	fh.SetModTime(jar.DefaultTime)
-	_, err := oz.addZipEntry(entry, ZipEntryFromBuffer{fh, emptyBuf})
+	_, err := oz.addZipEntry(entry, ZipEntryFromBuffer{fh, emptyBuf}, "generated")
 	return err
 }
 
@@ -278,7 +314,7 @@ func (oz *OutputZip) copyEntry(inputZip InputZip, index int) error {
 	if oz.stripDirEntries && entry.IsDir() {
 		return nil
 	}
-	existingEntry, err := oz.addZipEntry(entry.name, entry)
+	existingEntry, err := oz.addZipEntry(entry.name, entry, inputZip.Name())
 	if err != nil {
 		return err
 	}
@@ -292,16 +328,37 @@ func (oz *OutputZip) copyEntry(inputZip InputZip, index int) error {
 			entry.name, existingEntry, entry)
 	}
 
-	if oz.ignoreDuplicates ||
-		// Skip manifest and module info files that are not from the first input file
-		(oz.emulateJar && entry.name == jar.ManifestFile || entry.name == jar.ModuleInfoClass) ||
-		// Identical entries
-		(existingEntry.CRC32() == entry.CRC32() && existingEntry.Size() == entry.Size()) ||
+	// Skip manifest and module info files that are not from the first input file
+	if (oz.emulateJar && entry.name == jar.ManifestFile || entry.name == jar.ModuleInfoClass) ||
 		// Directory entries
 		entry.IsDir() {
 		return nil
 	}
 
+	identical := existingEntry.CRC32() == entry.CRC32() && existingEntry.Size() == entry.Size()
+	switch oz.conflicts.policy(entry.name, oz.defaultPolicy) {
+	case conflictFirstWins:
+		return nil
+	case conflictIdenticalOnly:
+		if identical {
+			return nil
+		}
+	case conflictLastWins:
+		oz.sourceByDest[entry.name] = entry
+		oz.originsByDest[entry.name] = []string{inputZip.Name()}
+		return nil
+	case conflictConcatenate:
+		concatenation, ok := existingEntry.(*ZipEntryConcatenation)
+		if !ok {
+			concatenation = &ZipEntryConcatenation{sources: []ZipEntryContents{existingEntry}}
+		}
+		if concatenation.add(entry) {
+			oz.sourceByDest[entry.name] = concatenation
+			oz.originsByDest[entry.name] = append(oz.originsByDest[entry.name], inputZip.Name())
+		}
+		return nil
+	}
+
 	return fmt.Errorf("Duplicate path %v found in %v and %v\n", entry.name, existingEntry, inputZip.Name())
 }
 
@@ -327,6 +384,27 @@ func (oz *OutputZip) alphanumericSorted() []string {
 	return entries
 }
 
+// Returns the entry names in the order they are written to the output zip.
+func (oz *OutputZip) outputOrder() []string {
+	if oz.emulateJar {
+		return oz.jarSorted()
+	} else if oz.sortEntries {
+		return oz.alphanumericSorted()
+	}
+	return oz.order
+}
+
+// Writes each entry and the inputs it came from to w, one tab separated line per entry.
+func (oz *OutputZip) writeSourcesReport(w io.Writer) error {
+	for _, entry := range oz.outputOrder() {
+		fields := append([]string{entry}, oz.originsByDest[entry]...)
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (oz *OutputZip) writeEntries(entries []string) error {
 	for _, entry := range entries {
 		source, _ := oz.sourceByDest[entry]
@@ -533,11 +611,13 @@ func (miz *ManagedInputZip) Entries() []*zip.File {
 // Actual processing.
 func mergeZips(inputZips []InputZip, writer *zip.Writer, manifest, pyMain string,
 	sortEntries, emulateJar, emulatePar, stripDirEntries, ignoreDuplicates bool,
-	excludeFiles, excludeDirs []string, zipsToNotStrip map[string]bool) error {
+	excludeFiles, excludeDirs []string, zipsToNotStrip map[string]bool,
+	conflicts conflictRules, sourcesReport io.Writer) error {
 
 	out := NewOutputZip(writer, sortEntries, emulateJar, stripDirEntries, ignoreDuplicates)
 	out.setExcludeFiles(excludeFiles)
 	out.setExcludeDirs(excludeDirs)
+	out.setConflictRules(conflicts)
 	if manifest != "" {
 		if err := out.addManifest(manifest); err != nil {
 			return err
@@ -575,18 +655,21 @@ func mergeZips(inputZips []InputZip, writer *zip.Writer, manifest, pyMain string
 				}
 			}
 		}
-		// Unless we need to rearrange the entries, the input zip can now be closed.
-		if !(emulateJar || sortEntries) {
+		// Unless we need to rearrange or replace the entries, the input zip can now be closed.
+		if !out.delayWrites() {
 			if err := inputZip.Close(); err != nil {
 				return err
 			}
 		}
 	}
 
-	if emulateJar {
-		return out.writeEntries(out.jarSorted())
-	} else if sortEntries {
-		return out.writeEntries(out.alphanumericSorted())
+	if out.delayWrites() {
+		if err := out.writeEntries(out.outputOrder()); err != nil {
+			return err
+		}
+	}
+	if sourcesReport != nil {
+		return out.writeSourcesReport(sourcesReport)
 	}
 	return nil
 }
@@ -622,17 +705,24 @@ var (
 	excludeDirs      fileList
 	excludeFiles     fileList
 	zipsToNotStrip   = make(zipsToNotStripSet)
+	alignment        soong_zip.AlignmentRules
 	stripDirEntries  = flag.Bool("D", false, "strip directory entries from the output zip file")
 	manifest         = flag.String("m", "", "manifest file to insert in jar")
 	pyMain           = flag.String("pm", "", "__main__.py file to insert in par")
 	prefix           = flag.String("prefix", "", "A file to prefix to the zip file")
 	ignoreDuplicates = flag.Bool("ignore-duplicates", false, "take each entry from the first zip it exists in and don't warn")
+	conflicts        conflictRules
+	sourcesReport    = flag.String("sources-report", "", "write each output entry and the inputs it came from to file")
 )
 
 func init() {
 	flag.Var(&excludeDirs, "stripDir", "directories to be excluded from the output zip, accepts wildcards")
 	flag.Var(&excludeFiles, "stripFile", "files to be excluded from the output zip, accepts wildcards")
 	flag.Var(&zipsToNotStrip, "zipToNotStrip", "the input zip file which is not applicable for stripping")
+	flag.Var(&alignment, "align", "[<glob>:]<alignment> to align the data of uncompressed files, the last matching rule wins")
+	flag.Var(&conflicts, "conflict", "<glob>:<policy> to resolve duplicate entries, where policy is one of "+
+		"error, first-wins, last-wins, identical-only or concatenate, the last matching rule wins")
+	flag.Var(conflictRulesFile{&conflicts}, "conflict-policy", "file with a <glob> <policy> conflict rule per line")
 }
 
 type FileInputZip struct {
@@ -677,7 +767,7 @@ func (fiz *FileInputZip) Open() error {
 
 func main() {
 	flag.Usage = func() {
-		fmt.Fprintln(os.Stderr, "usage: merge_zips [-jpsD] [-m manifest] [--prefix script] [-pm __main__.py] OutputZip [inputs...]")
+		fmt.Fprintln(os.Stderr, "usage: merge_zips [-jpsD] [-m manifest] [--prefix script] [-pm __main__.py] [-align [glob:]alignment] [-conflict glob:policy] [-conflict-policy file] [-sources-report file] OutputZip [inputs...]")
 		flag.PrintDefaults()
 	}
 
@@ -737,6 +827,7 @@ func main() {
 		}
 	}()
 	writer.SetOffset(offset)
+	alignment.SetAlignment(writer)
 
 	if *manifest != "" && !*emulateJar {
 		log.Fatal(errors.New("must specify -j when specifying a manifest via -m"))
@@ -752,9 +843,18 @@ func main() {
 	for i, input := range inputs {
 		inputZips[i] = inputZipsManager.Manage(&FileInputZip{name: input})
 	}
+	var report io.Writer
+	if *sourcesReport != "" {
+		reportFile, err := os.Create(*sourcesReport)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer reportFile.Close()
+		report = reportFile
+	}
 	err = mergeZips(inputZips, writer, *manifest, *pyMain, *sortEntries, *emulateJar, *emulatePar,
 		*stripDirEntries, *ignoreDuplicates, []string(excludeFiles), []string(excludeDirs),
-		map[string]bool(zipsToNotStrip))
+		map[string]bool(zipsToNotStrip), conflicts, report)
 	if err != nil {
 		log.Fatal(err)
 	}
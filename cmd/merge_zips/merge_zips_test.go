@@ -95,6 +95,7 @@ func TestMergeZips(t *testing.T) {
 		ignoreDuplicates bool
 		stripDirEntries  bool
 		zipsToNotStrip   map[string]bool
+		conflicts        []string
 
 		out []testZipEntry
 		err string
@@ -128,6 +129,50 @@ func TestMergeZips(t *testing.T) {
 			},
 			out: []testZipEntry{a},
 		},
+		{
+			name: "duplicates take last",
+			in: [][]testZipEntry{
+				{a, bc},
+				{a2},
+				{a3},
+			},
+			out: []testZipEntry{a3, bc},
+
+			conflicts: []string{"a:last-wins"},
+		},
+		{
+			name: "duplicates identical error",
+			in: [][]testZipEntry{
+				{a},
+				{a},
+			},
+			out: []testZipEntry{a},
+			err: "duplicate",
+
+			conflicts: []string{"*:error"},
+		},
+		{
+			name: "duplicates policy overrides ignore duplicates",
+			in: [][]testZipEntry{
+				{a, ba},
+				{a2, ba},
+			},
+			out: []testZipEntry{a},
+			err: "duplicate",
+
+			ignoreDuplicates: true,
+			conflicts:        []string{"*:error", "b/*:identical-only"},
+		},
+		{
+			name: "duplicates last matching policy wins",
+			in: [][]testZipEntry{
+				{a, bDir, bc},
+				{a2, bDir, bc},
+			},
+			out: []testZipEntry{a2, bDir, bc},
+
+			conflicts: []string{"**/*:error", "b/*:identical-only", "a:last-wins"},
+		},
 		{
 			name: "sort",
 			in: [][]testZipEntry{
@@ -250,9 +295,16 @@ func TestMergeZips(t *testing.T) {
 			out := &bytes.Buffer{}
 			writer := zip.NewWriter(out)
 
+			var conflicts conflictRules
+			for _, rule := range test.conflicts {
+				if err := conflicts.Set(rule); err != nil {
+					t.Fatal(err)
+				}
+			}
+
 			err := mergeZips(inputZips, writer, "", "",
 				test.sort, test.jar, false, test.stripDirEntries, test.ignoreDuplicates,
-				test.stripFiles, test.stripDirs, test.zipsToNotStrip)
+				test.stripFiles, test.stripDirs, test.zipsToNotStrip, conflicts, nil)
 
 			closeErr := writer.Close()
 			if closeErr != nil {
@@ -0,0 +1,270 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"android/soong/cmd/diff_target_files/allowlist"
+)
+
+// Directories in the output directory that belong to the build system itself rather than to the
+// build, relative to the output directory.
+var skippedDirs = map[string]bool{
+	".module_paths":        true,
+	".path":                true,
+	".temp":                true,
+	"dist":                 true,
+	"soong/.bootstrap":     true,
+	"soong/.minibootstrap": true,
+	"soong/.temp":          true,
+}
+
+// Files in the output directory that belong to the build system itself rather than to the build.
+// The files at the top of the output directory and of the soong directory are logs, ninja files
+// and build configuration. Depfiles, response files and sbox manifests describe the commands that
+// ran, which contain the path to the output directory.
+var skippedFiles = []string{
+	"*",
+	"soong/*",
+	"**/*.d",
+	"**/*.rsp",
+	"**/*.sbox.textproto",
+}
+
+type diffKind string
+
+const (
+	modified diffKind = "modified"
+	onlyInA  diffKind = "only_in_a"
+	onlyInB  diffKind = "only_in_b"
+)
+
+// a difference is a file that differs between the two builds
+type difference struct {
+	// path is relative to the output directories
+	path  string
+	kind  diffKind
+	sizeA int64
+	sizeB int64
+
+	// owner is the module and rule that produced the file, if known
+	owner *owner
+}
+
+// an artifact is a file in an output directory
+type artifact struct {
+	size int64
+	// link is the target of the file if it is a symlink
+	link   string
+	isLink bool
+}
+
+// listArtifacts returns the files produced by the build in outDir, keyed by their path relative
+// to outDir
+func listArtifacts(outDir string) (map[string]artifact, error) {
+	artifacts := make(map[string]artifact)
+	err := filepath.Walk(outDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(outDir, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if skippedDirs[rel] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, pattern := range skippedFiles {
+			if match, err := allowlist.Match(pattern, rel); err != nil {
+				return err
+			} else if match {
+				return nil
+			}
+		}
+
+		a := artifact{size: info.Size()}
+		if info.Mode()&os.ModeSymlink != 0 {
+			a.isLink = true
+			if a.link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		} else if !info.Mode().IsRegular() {
+			return nil
+		}
+		artifacts[rel] = a
+		return nil
+	})
+	return artifacts, err
+}
+
+// compareOutDirs returns the files that differ between outA and outB, except for the differences
+// allowed by allowLists, sorted by path. Up to jobs files are compared in parallel.
+func compareOutDirs(outA, outB string, allowLists []allowlist.AllowList, jobs int) ([]*difference, error) {
+	artifactsA, err := listArtifacts(outA)
+	if err != nil {
+		return nil, err
+	}
+	artifactsB, err := listArtifacts(outB)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []*difference
+	var candidates []*difference
+	for path, a := range artifactsA {
+		b, ok := artifactsB[path]
+		switch {
+		case !ok:
+			diffs = append(diffs, &difference{path: path, kind: onlyInA, sizeA: a.size})
+		case a.isLink != b.isLink || a.link != b.link || a.size != b.size:
+			diffs = append(diffs, &difference{path: path, kind: modified, sizeA: a.size, sizeB: b.size})
+		case !a.isLink:
+			candidates = append(candidates, &difference{path: path, kind: modified, sizeA: a.size, sizeB: b.size})
+		}
+	}
+	for path, b := range artifactsB {
+		if _, ok := artifactsA[path]; !ok {
+			diffs = append(diffs, &difference{path: path, kind: onlyInB, sizeB: b.size})
+		}
+	}
+
+	// Compare the contents of the files that have the same size in parallel.
+	var lock sync.Mutex
+	var firstErr error
+	work := make(chan *difference)
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range work {
+				same, err := sameContents(filepath.Join(outA, d.path), filepath.Join(outB, d.path))
+				lock.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				} else if err == nil && !same {
+					diffs = append(diffs, d)
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	for _, d := range candidates {
+		work <- d
+	}
+	close(work)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	diffs, err = filterAllowed(diffs, outA, outB, allowLists)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].path < diffs[j].path
+	})
+	return diffs, nil
+}
+
+// sameContents returns whether the files at a and b have the same contents
+func sameContents(a, b string) (bool, error) {
+	fa, err := os.Open(a)
+	if err != nil {
+		return false, err
+	}
+	defer fa.Close()
+	fb, err := os.Open(b)
+	if err != nil {
+		return false, err
+	}
+	defer fb.Close()
+
+	bufA := make([]byte, 64*1024)
+	bufB := make([]byte, 64*1024)
+	for {
+		nA, errA := io.ReadFull(fa, bufA)
+		nB, errB := io.ReadFull(fb, bufB)
+		if !bytes.Equal(bufA[:nA], bufB[:nB]) {
+			return false, nil
+		}
+		if errA == io.EOF || errA == io.ErrUnexpectedEOF {
+			return errB == io.EOF || errB == io.ErrUnexpectedEOF, nil
+		} else if errA != nil {
+			return false, fmt.Errorf("reading %s: %w", a, errA)
+		} else if errB != nil && errB != io.EOF && errB != io.ErrUnexpectedEOF {
+			return false, fmt.Errorf("reading %s: %w", b, errB)
+		}
+	}
+}
+
+// filterAllowed removes the differences that are allowed by allowLists. Like diff_target_files,
+// the first allowlist that matches the path of a modified file decides whether its differences
+// are allowed.
+func filterAllowed(diffs []*difference, outA, outB string, allowLists []allowlist.AllowList) ([]*difference, error) {
+	var ret []*difference
+outer:
+	for _, d := range diffs {
+		for _, w := range allowLists {
+			if match, err := allowlist.Match(w.Path, d.path); err != nil {
+				return nil, err
+			} else if !match {
+				continue
+			}
+
+			if len(w.IgnoreMatchingLines) == 0 {
+				continue outer
+			}
+			if d.kind != modified {
+				continue
+			}
+			if same, err := sameIgnoringMatchingLines(filepath.Join(outA, d.path),
+				filepath.Join(outB, d.path), w.IgnoreMatchingLines); err != nil {
+				return nil, err
+			} else if same {
+				continue outer
+			}
+			break
+		}
+		ret = append(ret, d)
+	}
+	return ret, nil
+}
+
+func sameIgnoringMatchingLines(a, b string, ignoreMatchingLines []string) (bool, error) {
+	fa, err := os.Open(a)
+	if err != nil {
+		return false, err
+	}
+	defer fa.Close()
+	fb, err := os.Open(b)
+	if err != nil {
+		return false, err
+	}
+	defer fb.Close()
+	return allowlist.DiffIgnoringMatchingLines(fa, fb, ignoreMatchingLines)
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"android/soong/cmd/diff_target_files/allowlist"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for path, contents := range files {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(contents), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCompareOutDirs(t *testing.T) {
+	outA := filepath.Join(t.TempDir(), "out_a")
+	outB := filepath.Join(t.TempDir(), "out_b")
+
+	writeFiles(t, outA, map[string]string{
+		// build system files are ignored
+		".ninja_log":                       "a",
+		"soong/build.ninja":                "a",
+		"soong/.bootstrap/bin/soong_build": "a",
+		"soong/.intermediates/foo/foo.d":   "a",
+
+		"soong/.intermediates/foo/same":      "same",
+		"soong/.intermediates/foo/size":      "a",
+		"soong/.intermediates/foo/contents":  "a",
+		"soong/.intermediates/foo/only_a":    "a",
+		"target/product/x/system/build.prop": "ro.build.date=1\nro.build.id=a\n",
+		"target/product/x/system/allowed":    "a",
+	})
+	writeFiles(t, outB, map[string]string{
+		".ninja_log":                       "b",
+		"soong/build.ninja":                "b",
+		"soong/.bootstrap/bin/soong_build": "b",
+		"soong/.intermediates/foo/foo.d":   "b",
+
+		"soong/.intermediates/foo/same":      "same",
+		"soong/.intermediates/foo/size":      "bb",
+		"soong/.intermediates/foo/contents":  "b",
+		"soong/.intermediates/foo/only_b":    "b",
+		"target/product/x/system/build.prop": "ro.build.date=2\nro.build.id=a\n",
+		"target/product/x/system/allowed":    "b",
+	})
+
+	allowLists := []allowlist.AllowList{
+		{Path: "target/product/*/system/build.prop", IgnoreMatchingLines: []string{"ro.build.date=.*"}},
+		{Path: "**/allowed"},
+	}
+
+	diffs, err := compareOutDirs(outA, outB, allowLists, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*difference{
+		{path: "soong/.intermediates/foo/contents", kind: modified, sizeA: 1, sizeB: 1},
+		{path: "soong/.intermediates/foo/only_a", kind: onlyInA, sizeA: 1},
+		{path: "soong/.intermediates/foo/only_b", kind: onlyInB, sizeB: 1},
+		{path: "soong/.intermediates/foo/size", kind: modified, sizeA: 1, sizeB: 2},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("compareOutDirs() =")
+		for _, d := range diffs {
+			t.Errorf("  %+v", *d)
+		}
+		t.Errorf("want:")
+		for _, d := range want {
+			t.Errorf("  %+v", *d)
+		}
+	}
+}
+
+func TestSameContents(t *testing.T) {
+	dir := t.TempDir()
+	large := make([]byte, 200*1024)
+	largeChanged := append([]byte(nil), large...)
+	largeChanged[len(largeChanged)-1] = 1
+
+	writeFiles(t, dir, map[string]string{
+		"empty":         "",
+		"empty2":        "",
+		"large":         string(large),
+		"large2":        string(large),
+		"large_changed": string(largeChanged),
+		"short":         "abc",
+	})
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"empty", "empty2", true},
+		{"large", "large2", true},
+		{"large", "large_changed", false},
+		{"large", "short", false},
+		{"short", "empty", false},
+	}
+	for _, tt := range tests {
+		got, err := sameContents(filepath.Join(dir, tt.a), filepath.Join(dir, tt.b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("sameContents(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
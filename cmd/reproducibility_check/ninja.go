@@ -0,0 +1,168 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"android/soong/ui/build/ninjafile"
+)
+
+// an owner is the module, if any, and the ninja rule that produced a file
+type owner struct {
+	module     string
+	variant    string
+	moduleType string
+	rule       string
+}
+
+// attributeDifferences sets the owner of each difference from the ninja files and
+// module-info.json files of the builds. The files that only exist in the second build are
+// attributed using its own ninja files.
+func attributeDifferences(diffs []*difference, topA, outA, topB, outB string) error {
+	pathsA := make(map[string]bool)
+	pathsB := make(map[string]bool)
+	for _, d := range diffs {
+		if d.kind == onlyInB {
+			pathsB[d.path] = true
+		} else {
+			pathsA[d.path] = true
+		}
+	}
+
+	attribute := func(topDir, outDir string, paths map[string]bool) error {
+		if len(paths) == 0 {
+			return nil
+		}
+		owners, err := buildOwners(topDir, outDir, paths)
+		if err != nil {
+			return err
+		}
+		for _, d := range diffs {
+			if o, ok := owners[d.path]; ok && paths[d.path] {
+				d.owner = &o
+			}
+		}
+		return nil
+	}
+
+	if err := attribute(topA, outA, pathsA); err != nil {
+		return err
+	}
+	return attribute(topB, outB, pathsB)
+}
+
+// buildOwners returns the owners of paths, which are relative to outDir, from the ninja files and
+// module-info.json files of the build that ran in topDir
+func buildOwners(topDir, outDir string, paths map[string]bool) (map[string]owner, error) {
+	ninjaFiles, err := filepath.Glob(filepath.Join(outDir, "combined-*.ninja"))
+	if err != nil {
+		return nil, err
+	}
+	if len(ninjaFiles) == 0 {
+		ninjaFiles = []string{filepath.Join(outDir, "soong", "build.ninja")}
+	}
+
+	// Ninja runs in topDir, so the paths in the ninja files are relative to it.
+	relPath := func(path string) (string, bool) {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(topDir, path)
+		}
+		rel, err := filepath.Rel(outDir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			return "", false
+		}
+		return rel, paths[rel]
+	}
+
+	owners := make(map[string]owner)
+	seen := make(map[string]bool)
+	for len(ninjaFiles) > 0 {
+		file := ninjaFiles[0]
+		ninjaFiles = ninjaFiles[1:]
+		if seen[file] {
+			continue
+		}
+		seen[file] = true
+
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		included, err := ninjafile.Scan(f, func(s ninjafile.Statement) {
+			o := owner{
+				module:     s.Module.Name,
+				variant:    s.Module.Variant,
+				moduleType: s.Module.Type,
+				rule:       s.Rule,
+			}
+			for _, output := range s.Outputs {
+				if rel, ok := relPath(output); ok {
+					owners[rel] = o
+				}
+			}
+		})
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, include := range included {
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(topDir, include)
+			}
+			ninjaFiles = append(ninjaFiles, include)
+		}
+	}
+
+	// Make modules don't have module headers in the ninja files, find the modules that installed
+	// files from module-info.json instead.
+	moduleInfos, err := filepath.Glob(filepath.Join(outDir, "target", "product", "*", "module-info.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, moduleInfo := range moduleInfos {
+		data, err := ioutil.ReadFile(moduleInfo)
+		if err != nil {
+			return nil, err
+		}
+		var modules map[string]struct {
+			Module_name string
+			Installed   []string
+		}
+		if err := json.Unmarshal(data, &modules); err != nil {
+			return nil, err
+		}
+		for name, module := range modules {
+			if module.Module_name != "" {
+				name = module.Module_name
+			}
+			for _, installed := range module.Installed {
+				if rel, ok := relPath(installed); ok {
+					o := owners[rel]
+					if o.module == "" {
+						o.module = name
+						owners[rel] = o
+					}
+				}
+			}
+		}
+	}
+
+	return owners, nil
+}
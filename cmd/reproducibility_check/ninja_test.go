@@ -0,0 +1,83 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildOwners(t *testing.T) {
+	top := t.TempDir()
+	outDir := filepath.Join(top, "out_repro_a")
+
+	writeFiles(t, top, map[string]string{
+		"out_repro_a/combined-x.ninja": "builddir = out_repro_a\n" +
+			"subninja out_repro_a/build-x.ninja\n" +
+			"subninja out_repro_a/soong/build.ninja\n",
+		"out_repro_a/build-x.ninja": "rule rule1\n" +
+			"  command = cp $in $out\n" +
+			"build out_repro_a/target/product/x/system/etc/make.txt: rule1 make.txt\n" +
+			"build out_repro_a/target/product/x/system/bin/foo: rule1 $\n" +
+			"    out_repro_a/soong/.intermediates/foo/android_x86_64/foo\n",
+		"out_repro_a/soong/build.ninja": strings.Join([]string{
+			"# # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # #",
+			"# Module:  foo",
+			"# Variant: android_x86_64",
+			"# Type:    cc_binary",
+			"# Factory: android/soong/cc.BinaryFactory",
+			"# Defined: foo/Android.bp:1:1",
+			"",
+			"build out_repro_a/soong/.intermediates/foo/android_x86_64/foo: g.cc.ld $",
+			"        out_repro_a/soong/.intermediates/foo/android_x86_64/foo.o",
+			"",
+			"# # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # #",
+			"# Singleton: api_levels",
+			"# Factory:   android/soong/android.ApiLevelsSingleton",
+			"",
+			"build out_repro_a/soong/api_levels.json: g.android.WriteFile",
+			"",
+		}, "\n"),
+		"out_repro_a/target/product/x/module-info.json": `{
+			"make_module": {
+				"installed": ["out_repro_a/target/product/x/system/etc/make.txt"]
+			}
+		}`,
+	})
+
+	paths := map[string]bool{
+		"target/product/x/system/etc/make.txt":        true,
+		"target/product/x/system/bin/foo":             true,
+		"soong/.intermediates/foo/android_x86_64/foo": true,
+		"soong/api_levels.json":                       true,
+	}
+
+	got, err := buildOwners(top, outDir, paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]owner{
+		"target/product/x/system/etc/make.txt":        {module: "make_module", rule: "rule1"},
+		"target/product/x/system/bin/foo":             {rule: "rule1"},
+		"soong/.intermediates/foo/android_x86_64/foo": {module: "foo", variant: "android_x86_64", moduleType: "cc_binary", rule: "g.cc.ld"},
+		"soong/api_levels.json":                       {rule: "g.android.WriteFile"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildOwners() = %+v\nwant %+v", got, want)
+	}
+}
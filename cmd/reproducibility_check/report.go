@@ -0,0 +1,116 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// formatReport returns a human readable list of diffs grouped by the module that produced them
+func formatReport(diffs []*difference, nameA, nameB string) string {
+	if len(diffs) == 0 {
+		return fmt.Sprintf("No differences between %s and %s\n", nameA, nameB)
+	}
+
+	groups := make(map[string][]*difference)
+	for _, d := range diffs {
+		group := ""
+		if d.owner != nil && d.owner.module != "" {
+			group = d.owner.module
+			var details []string
+			if d.owner.moduleType != "" {
+				details = append(details, d.owner.moduleType)
+			}
+			if d.owner.variant != "" {
+				details = append(details, d.owner.variant)
+			}
+			if len(details) > 0 {
+				group += " (" + strings.Join(details, ", ") + ")"
+			}
+		}
+		groups[group] = append(groups[group], d)
+	}
+
+	var names []string
+	for name := range groups {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := groups[""]; ok {
+		names = append(names, "")
+	}
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "%d files differ between %s and %s:\n", len(diffs), nameA, nameB)
+	for _, name := range names {
+		if name == "" {
+			fmt.Fprintf(sb, "\nNot produced by a known module:\n")
+		} else {
+			fmt.Fprintf(sb, "\n%s:\n", name)
+		}
+		for _, d := range groups[name] {
+			switch d.kind {
+			case modified:
+				fmt.Fprintf(sb, "  modified:     %s (%d -> %d bytes)", d.path, d.sizeA, d.sizeB)
+			case onlyInA:
+				fmt.Fprintf(sb, "  only in %s: %s", nameA, d.path)
+			case onlyInB:
+				fmt.Fprintf(sb, "  only in %s: %s", nameB, d.path)
+			}
+			if d.owner != nil && d.owner.rule != "" {
+				fmt.Fprintf(sb, " [rule %s]", d.owner.rule)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+type jsonDifference struct {
+	Path       string `json:"path"`
+	Kind       string `json:"kind"`
+	SizeA      int64  `json:"size_a"`
+	SizeB      int64  `json:"size_b"`
+	Module     string `json:"module,omitempty"`
+	Variant    string `json:"variant,omitempty"`
+	ModuleType string `json:"module_type,omitempty"`
+	Rule       string `json:"rule,omitempty"`
+}
+
+// jsonReport returns diffs as a JSON list
+func jsonReport(diffs []*difference) ([]byte, error) {
+	ret := make([]jsonDifference, 0, len(diffs))
+	for _, d := range diffs {
+		j := jsonDifference{
+			Path:  d.path,
+			Kind:  string(d.kind),
+			SizeA: d.sizeA,
+			SizeB: d.sizeB,
+		}
+		if d.owner != nil {
+			j.Module = d.owner.module
+			j.Variant = d.owner.variant
+			j.ModuleType = d.owner.moduleType
+			j.Rule = d.owner.rule
+		}
+		ret = append(ret, j)
+	}
+	return json.MarshalIndent(ret, "", "  ")
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+)
+
+func TestFormatReport(t *testing.T) {
+	diffs := []*difference{
+		{path: "soong/.intermediates/foo/foo", kind: modified, sizeA: 1, sizeB: 2,
+			owner: &owner{module: "foo", variant: "android_x86_64", moduleType: "cc_binary", rule: "g.cc.ld"}},
+		{path: "target/product/x/system/etc/make.txt", kind: onlyInB},
+	}
+
+	got := formatReport(diffs, "out_a", "out_b")
+	want := "2 files differ between out_a and out_b:\n" +
+		"\n" +
+		"foo (cc_binary, android_x86_64):\n" +
+		"  modified:     soong/.intermediates/foo/foo (1 -> 2 bytes) [rule g.cc.ld]\n" +
+		"\n" +
+		"Not produced by a known module:\n" +
+		"  only in out_b: target/product/x/system/etc/make.txt\n"
+	if got != want {
+		t.Errorf("formatReport() = %q\nwant %q", got, want)
+	}
+}
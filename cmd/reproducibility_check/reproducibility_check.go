@@ -0,0 +1,173 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// reproducibility_check builds the same targets twice, in different output directories, with
+// different timestamps and optionally from source trees at different paths, and reports every
+// installed or intermediate file that differs between the two builds along with the Soong module
+// and ninja rule that produced it.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"android/soong/cmd/diff_target_files/allowlist"
+)
+
+const defaultAllowListFile = "build/soong/cmd/diff_target_files/known_nondeterminism.whitelist"
+
+var (
+	outDirA   = flag.String("out_a", "out_repro_a", "output directory of the first build")
+	outDirB   = flag.String("out_b", "out_repro_b", "output directory of the second build")
+	topDirB   = flag.String("top_b", "", "source tree to run the second build from, to check that the outputs don't depend on its path (default: the current directory)")
+	skipBuild = flag.Bool("skip_build", false, "compare the existing contents of the output directories without building")
+	jsonFile  = flag.String("json", "", "file to write a JSON report of the differences to")
+	jobs      = flag.Int("j", runtime.NumCPU(), "number of files to compare in parallel")
+
+	allowLists     = newMultiString("allowlist", "allowlist patterns in the form <pattern>[:<regex of line to ignore>], relative to the output directories")
+	allowListFiles = newMultiString("allowlist_file", "files containing allowlist definitions (default: "+defaultAllowListFile+")")
+)
+
+func newMultiString(name, usage string) *multiString {
+	var f multiString
+	flag.Var(&f, name, usage)
+	return &f
+}
+
+type multiString []string
+
+func (ms *multiString) String() string     { return strings.Join(*ms, ", ") }
+func (ms *multiString) Set(s string) error { *ms = append(*ms, s); return nil }
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: reproducibility_check [flags] [targets...]\n\n")
+	fmt.Fprintf(os.Stderr, "Builds targets twice and reports the differences between the two builds.\n")
+	fmt.Fprintf(os.Stderr, "Must be run from the top of the source tree.\n\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if err := run(flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(targets []string) error {
+	topDirA, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	topB := topDirA
+	if *topDirB != "" {
+		if topB, err = filepath.Abs(*topDirB); err != nil {
+			return err
+		}
+	}
+
+	outA, err := filepath.Abs(*outDirA)
+	if err != nil {
+		return err
+	}
+	outB, err := filepath.Abs(*outDirB)
+	if err != nil {
+		return err
+	}
+	if outA == outB {
+		return fmt.Errorf("-out_a and -out_b must be different directories")
+	}
+
+	files := *allowListFiles
+	if len(files) == 0 {
+		if _, err := os.Stat(defaultAllowListFile); err == nil {
+			files = []string{defaultAllowListFile}
+		}
+	}
+	allowLists, err := allowlist.Parse(*allowLists, files)
+	if err != nil {
+		return fmt.Errorf("parsing allowlists: %w", err)
+	}
+
+	if !*skipBuild {
+		// Build a day apart, as different users on different hosts, so that anything that
+		// embeds the time or the environment of the build shows up as a difference.
+		buildDate := time.Now()
+		if err := runBuild(topDirA, outA, buildDate, "a", targets); err != nil {
+			return err
+		}
+		if err := runBuild(topB, outB, buildDate.Add(24*time.Hour), "b", targets); err != nil {
+			return err
+		}
+	}
+
+	diffs, err := compareOutDirs(outA, outB, allowLists, *jobs)
+	if err != nil {
+		return err
+	}
+
+	if err := attributeDifferences(diffs, topDirA, outA, topB, outB); err != nil {
+		// The differences are still useful without knowing which modules they came from.
+		fmt.Fprintln(os.Stderr, "warning: failed to find the modules that produced the differences:", err)
+	}
+
+	fmt.Print(formatReport(diffs, *outDirA, *outDirB))
+
+	if *jsonFile != "" {
+		data, err := jsonReport(diffs)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(*jsonFile, data, 0666); err != nil {
+			return err
+		}
+	}
+
+	if len(diffs) > 0 {
+		return fmt.Errorf("%d files differ between the two builds", len(diffs))
+	}
+	return nil
+}
+
+// runBuild builds targets from the source tree at topDir into outDir, as if it were buildDate
+func runBuild(topDir, outDir string, buildDate time.Time, suffix string, targets []string) error {
+	fmt.Fprintf(os.Stderr, "Building %s in %s from %s\n", strings.Join(targets, " "), outDir, topDir)
+
+	args := append([]string{"--make-mode"}, targets...)
+	cmd := exec.Command(filepath.Join(topDir, "build/soong/soong_ui.bash"), args...)
+	cmd.Dir = topDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"OUT_DIR="+outDir,
+		"BUILD_DATETIME="+strconv.FormatInt(buildDate.Unix(), 10),
+		"BUILD_USERNAME=reproducibility-check-"+suffix,
+		"BUILD_HOSTNAME=reproducibility-check-"+suffix,
+	)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("build in %s failed: %w", outDir, err)
+	}
+	return nil
+}
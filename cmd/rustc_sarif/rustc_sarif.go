@@ -0,0 +1,200 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// rustc_sarif records the diagnostics of rustc and clippy-driver as SARIF. It either runs rustc or
+// clippy-driver with --error-format=json on a crate, printing the rendered diagnostics and writing
+// them as SARIF, or merges the SARIF files of crates into the report of a module, or the reports
+// of modules into a single report, optionally comparing the diagnostics to a baseline.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"android/soong/sarif"
+)
+
+var (
+	output        = flag.String("o", "", "SARIF file to write")
+	tool          = flag.String("tool", "rustc", "name of the tool that reported the diagnostics")
+	merge         = flag.Bool("merge", false, "merge the SARIF files given as arguments instead of running a command")
+	module        = flag.String("module", "", "module to attribute the merged diagnostics to")
+	dir           = flag.String("dir", "", "directory of the module to attribute the merged diagnostics to")
+	baseline      = flag.String("baseline", "", "SARIF file with the known diagnostics of the module")
+	lintsAsErrors = flag.String("lints_as_errors", "", "comma separated lints whose new diagnostics are errors")
+	summary       = flag.String("summary", "", "file to write the number of diagnostics per directory and module to")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s -o <sarif> [-tool <name>] -- <rustc command> [args...]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s -merge -o <sarif> [options] <sarif|@rsp>...\n", os.Args[0])
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if *output == "" || flag.NArg() == 0 {
+		usage()
+	}
+
+	if *merge {
+		if err := mergeReports(flag.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	exitCode, err := runRustc(flag.Arg(0), flag.Args()[1:], *tool, *output, os.Stdout, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+		os.Exit(1)
+	}
+	os.Exit(exitCode)
+}
+
+// runRustc runs the rustc command, which must emit its diagnostics as JSON on stderr, prints the
+// rendered diagnostics and writes them to the SARIF file. The SARIF file is written even if the
+// command fails so that the diagnostics that failed the build are recorded. It returns the exit
+// code of the command.
+func runRustc(command string, args []string, toolName, sarifFile string, stdout, stderr io.Writer) (int, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Stdout = stdout
+	pipe, err := cmd.StderrPipe()
+	if err != nil {
+		return 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	results, parseErr := parseDiagnostics(pipe, stderr)
+	err = cmd.Wait()
+
+	exitCode := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		return 0, err
+	}
+	if parseErr != nil {
+		return 0, parseErr
+	}
+
+	if err := sarif.NewLog(toolName, results).WriteFile(sarifFile); err != nil {
+		return 0, err
+	}
+	return exitCode, nil
+}
+
+// A diagnostic emitted by rustc with --error-format=json, see
+// https://doc.rust-lang.org/rustc/json.html.
+type diagnostic struct {
+	MessageType string           `json:"$message_type"`
+	Message     string           `json:"message"`
+	Code        *diagnosticCode  `json:"code"`
+	Level       string           `json:"level"`
+	Spans       []diagnosticSpan `json:"spans"`
+	Rendered    *string          `json:"rendered"`
+}
+
+type diagnosticCode struct {
+	Code string `json:"code"`
+}
+
+type diagnosticSpan struct {
+	FileName    string `json:"file_name"`
+	LineStart   int    `json:"line_start"`
+	ColumnStart int    `json:"column_start"`
+	IsPrimary   bool   `json:"is_primary"`
+}
+
+// result returns the diagnostic as a SARIF result, or nil if it is neither an error nor a warning
+// about a location in a source file, like the "aborting due to previous error" summary.
+func (d *diagnostic) result() *sarif.Result {
+	var level string
+	switch {
+	case d.Level == "warning":
+		level = sarif.LevelWarning
+	case strings.HasPrefix(d.Level, "error"):
+		level = sarif.LevelError
+	default:
+		return nil
+	}
+	for _, span := range d.Spans {
+		if !span.IsPrimary {
+			continue
+		}
+		rule := ""
+		if d.Code != nil {
+			rule = d.Code.Code
+		}
+		return sarif.NewResult(rule, level, d.Message, filepath.Clean(span.FileName),
+			span.LineStart, span.ColumnStart)
+	}
+	return nil
+}
+
+// parseDiagnostics returns the diagnostics in the stderr of rustc, and writes their rendered
+// form to w. Lines that are not diagnostics are copied to w.
+func parseDiagnostics(r io.Reader, w io.Writer) ([]*sarif.Result, error) {
+	var results []*sarif.Result
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		var d diagnostic
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &d) != nil ||
+			(d.MessageType == "" && d.Level == "") {
+			fmt.Fprintln(w, line)
+			continue
+		}
+		// Skip the other messages, like future incompatibility reports.
+		if d.MessageType != "" && d.MessageType != "diagnostic" {
+			continue
+		}
+		if d.Rendered != nil {
+			fmt.Fprint(w, *d.Rendered)
+		}
+		if result := d.result(); result != nil {
+			results = append(results, result)
+		}
+	}
+	return results, scanner.Err()
+}
+
+// mergeReports merges the SARIF files into the output file. If a baseline is given, the new
+// diagnostics of the lints in lints_as_errors are errors and fail the merge after the output file
+// is written.
+func mergeReports(args []string) error {
+	files, err := sarif.ReadInputs(args)
+	if err != nil {
+		return err
+	}
+	return sarif.MergeReport(*tool, files, *output, *summary, sarif.MergeOptions{
+		Module:        *module,
+		Directory:     *dir,
+		Baseline:      *baseline,
+		RulesAsErrors: *lintsAsErrors,
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"android/soong/sarif"
+)
+
+const testStderr = `{"$message_type":"diagnostic","message":"unused variable: ` + "`x`" + `","code":{"code":"unused_variables","explanation":null},"level":"warning","spans":[{"file_name":"a/./src/lib.rs","byte_start":40,"byte_end":41,"line_start":3,"line_end":3,"column_start":9,"column_end":10,"is_primary":true,"text":[],"label":null,"suggested_replacement":null,"suggestion_applicability":null,"expansion":null}],"children":[],"rendered":"warning: unused variable: ` + "`x`" + `\n"}
+{"$message_type":"diagnostic","message":"unneeded ` + "`return`" + ` statement","code":{"code":"clippy::needless_return","explanation":null},"level":"error","spans":[{"file_name":"a/src/other.rs","line_start":10,"line_end":10,"column_start":1,"column_end":5,"is_primary":false},{"file_name":"a/src/lib.rs","line_start":7,"line_end":7,"column_start":5,"column_end":13,"is_primary":true}],"children":[],"rendered":"error: unneeded ` + "`return`" + ` statement\n"}
+{"$message_type":"diagnostic","message":"aborting due to previous error","code":null,"level":"error","spans":[],"children":[],"rendered":"error: aborting due to previous error\n"}
+{"$message_type":"future_incompat","future_incompat_report":[]}
+not a diagnostic
+`
+
+func TestParseDiagnostics(t *testing.T) {
+	var rendered bytes.Buffer
+	results, err := parseDiagnostics(strings.NewReader(testStderr), &rendered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, result := range results {
+		got = append(got, result.String())
+	}
+	want := []string{
+		"a/src/lib.rs:3:9: warning: unused variable: `x` [unused_variables]",
+		"a/src/lib.rs:7:5: error: unneeded `return` statement [clippy::needless_return]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	wantRendered := "warning: unused variable: `x`\n" +
+		"error: unneeded `return` statement\n" +
+		"error: aborting due to previous error\n" +
+		"not a diagnostic\n"
+	if rendered.String() != wantRendered {
+		t.Errorf("want rendered %q, got %q", wantRendered, rendered.String())
+	}
+}
+
+func TestRunRustc(t *testing.T) {
+	dir := t.TempDir()
+	stderrFile := filepath.Join(dir, "stderr")
+	if err := os.WriteFile(stderrFile, []byte(testStderr), 0666); err != nil {
+		t.Fatal(err)
+	}
+	sarifFile := filepath.Join(dir, "libfoo.rlib.sarif")
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := runRustc("sh", []string{"-c", "echo out; cat " + stderrFile + " >&2; exit 1"},
+		"clippy", sarifFile, &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exitCode != 1 {
+		t.Errorf("want exit code 1, got %d", exitCode)
+	}
+	if got, want := stdout.String(), "out\n"; got != want {
+		t.Errorf("want stdout %q, got %q", want, got)
+	}
+	if !strings.Contains(stderr.String(), "error: unneeded `return` statement\n") {
+		t.Errorf("want the rendered diagnostics in stderr, got %q", stderr.String())
+	}
+
+	log, err := sarif.ReadFile(sarifFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := log.Runs[0].Tool.Driver.Name; got != "clippy" {
+		t.Errorf("want tool clippy, got %q", got)
+	}
+	if got := len(log.Results()); got != 2 {
+		t.Errorf("want 2 results, got %d", got)
+	}
+}
@@ -0,0 +1,411 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"android/soong/cmd/sbox/sbox_proto"
+	"android/soong/response"
+)
+
+// The action cache stores the outputs of sandboxed commands in a local directory so that they can
+// be restored instead of rerunning the commands, for example after the out directory was removed
+// or when switching back to a branch that was built before.
+//
+// The key of an entry is a hash of everything that can affect the outputs of the commands in a
+// manifest: the command lines, the contents of the files copied into the sandbox or listed in the
+// manifest as inputs, the output paths and the environment.  The cache is content-addressed: an
+// entry maps the key to the hashes of the output files, which are stored once per unique content.
+//
+// The layout of the cache directory is:
+//
+//	ac/<first 2 digits of key>/<key>    JSON encoded actionCacheEntry
+//	cas/<first 2 digits of hash>/<hash> contents of an output file
+//	trimmed                             touched when the cache was last trimmed
+//
+// If the cache has a maximum size, it is trimmed by removing the least recently used entries and
+// output files, ordered by their modification times that restoring and storing update.
+//
+// Manifests that use depfiles are never cached, as the inputs discovered by the depfile are not
+// known before the command runs.
+
+// The version of the format of the action cache, which is also part of the key of every entry.
+// Incrementing it invalidates all existing entries.
+const actionCacheVersion = 1
+
+// The minimum time between two trims of the cache, which avoids walking the whole cache every time
+// an entry is stored.
+const actionCacheTrimInterval = 10 * time.Minute
+
+type actionCache struct {
+	dir string
+
+	// The file to append a byte to for every restored entry, if not empty.  It is specific to the
+	// out directory, so that the hits of concurrent builds sharing the cache are counted apart.
+	hitsFile string
+
+	// The maximum size of the files in the cache in bytes, or 0 if it is unlimited.
+	maxSize int64
+}
+
+// actionCacheEntry is the list of outputs of all the commands in a manifest.
+type actionCacheEntry struct {
+	Outputs []actionCacheOutput `json:"outputs"`
+}
+
+type actionCacheOutput struct {
+	// The path of the output, relative to the $PWD when sbox was run.
+	Path string `json:"path"`
+
+	// The hash of the contents of the output.
+	Hash string `json:"hash"`
+
+	// The permissions of the output.
+	Mode os.FileMode `json:"mode"`
+}
+
+// manifestIsCacheable returns true if the outputs of the commands in the manifest can be cached.
+func manifestIsCacheable(manifest *sbox_proto.Manifest) bool {
+	if manifest.GetOutputDepfile() != "" {
+		return false
+	}
+	for _, command := range manifest.Commands {
+		if strings.Contains(command.GetCommand(), depFilePlaceholder) {
+			return false
+		}
+	}
+	return true
+}
+
+// actionCacheKey returns the key of the commands in the manifest when they are run with the given
+// environment.  It returns an error if any of the inputs can't be read, in which case the commands
+// should be run without the cache so that they report the error.
+func actionCacheKey(manifest *sbox_proto.Manifest, env []string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "version %d\n", actionCacheVersion)
+
+	env = append([]string(nil), env...)
+	sort.Strings(env)
+	for _, e := range env {
+		fmt.Fprintf(h, "env %q\n", e)
+	}
+
+	for i, command := range manifest.Commands {
+		fmt.Fprintf(h, "command %d %q chdir=%t\n", i, command.GetCommand(), command.GetChdir())
+
+		for _, copyPair := range command.CopyBefore {
+			fileHash, err := hashFile(copyPair.GetFrom())
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "copy_before %q %q %t %s\n", copyPair.GetFrom(), copyPair.GetTo(),
+				copyPair.GetExecutable(), fileHash)
+		}
+
+		for _, rspFile := range command.RspFiles {
+			if err := hashRspFile(h, rspFile); err != nil {
+				return "", err
+			}
+		}
+
+		for _, input := range command.Inputs {
+			fileHash, err := hashFile(input)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "input %q %s\n", input, fileHash)
+		}
+
+		for _, copyPair := range command.CopyAfter {
+			fmt.Fprintf(h, "copy_after %q %q %t\n", copyPair.GetFrom(), copyPair.GetTo(),
+				copyPair.GetExecutable())
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashRspFile adds the path mappings of an rsp file and the contents of the files listed in it
+// to the hash.
+func hashRspFile(h hash.Hash, rspFile *sbox_proto.RspFile) error {
+	in, err := os.Open(rspFile.GetFile())
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	files, err := response.ReadRspFile(in)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(h, "rsp_file %q\n", rspFile.GetFile())
+	for _, mapping := range rspFile.PathMappings {
+		fmt.Fprintf(h, "path_mapping %q %q\n", mapping.GetFrom(), mapping.GetTo())
+	}
+	for _, file := range files {
+		fileHash, err := hashFile(file)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "rsp_input %q %s\n", file, fileHash)
+	}
+	return nil
+}
+
+// hashFile returns the hex encoded sha256 hash of the contents of a file.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (c *actionCache) entryPath(key string) string {
+	return filepath.Join(c.dir, "ac", key[:2], key)
+}
+
+func (c *actionCache) blobPath(hash string) string {
+	return filepath.Join(c.dir, "cas", hash[:2], hash)
+}
+
+// restore copies the outputs of the commands in the manifest from the cache entry with the given
+// key into place.  It returns false if there is no entry for the key.  If it returns an error the
+// outputs may have been partially restored, and the commands should be run to replace them.
+func (c *actionCache) restore(key string, manifest *sbox_proto.Manifest, write writeType) (bool, error) {
+	data, err := ioutil.ReadFile(c.entryPath(key))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	var entry actionCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return false, fmt.Errorf("corrupt action cache entry %s: %w", c.entryPath(key), err)
+	}
+
+	for _, command := range manifest.Commands {
+		if err := clearOutputDirectory(command.CopyAfter, outputDir, write); err != nil {
+			return false, err
+		}
+	}
+
+	for _, output := range entry.Outputs {
+		blob := c.blobPath(output.Hash)
+		if err := copyOneFile(blob, output.Path, false, requireFromExists, write); err != nil {
+			return false, fmt.Errorf("failed to restore %q from the action cache: %w", output.Path, err)
+		}
+		if err := os.Chmod(output.Path, output.Mode); err != nil {
+			return false, err
+		}
+		// Mark the output file as recently used so that trimming keeps it.
+		touch(blob)
+	}
+	touch(c.entryPath(key))
+
+	return true, nil
+}
+
+// recordHit counts a restored entry in the hits file. Appends of a single byte are atomic, so
+// concurrent sbox processes don't lose hits.
+func (c *actionCache) recordHit() error {
+	if c.hitsFile == "" {
+		return nil
+	}
+	f, err := os.OpenFile(c.hitsFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write([]byte{'.'})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// store adds the outputs of the commands in the manifest, which must have been run successfully,
+// to the cache under the given key.
+func (c *actionCache) store(key string, manifest *sbox_proto.Manifest) error {
+	var entry actionCacheEntry
+	for _, command := range manifest.Commands {
+		for _, copyPair := range command.CopyAfter {
+			output := copyPair.GetTo()
+			info, err := os.Stat(output)
+			if err != nil {
+				return err
+			}
+			fileHash, err := hashFile(output)
+			if err != nil {
+				return err
+			}
+			if err := c.storeBlob(output, fileHash); err != nil {
+				return err
+			}
+			entry.Outputs = append(entry.Outputs, actionCacheOutput{
+				Path: output,
+				Hash: fileHash,
+				Mode: info.Mode().Perm(),
+			})
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	err = writeFileAtomically(c.entryPath(key), func(f *os.File) error {
+		_, err := f.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return c.maybeTrim()
+}
+
+// storeBlob copies a file into the content-addressed store if it isn't already there.
+func (c *actionCache) storeBlob(path, hash string) error {
+	blob := c.blobPath(hash)
+	if _, err := os.Stat(blob); err == nil {
+		return touch(blob)
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	return writeFileAtomically(blob, func(f *os.File) error {
+		_, err := io.Copy(f, in)
+		return err
+	})
+}
+
+// maybeTrim trims the cache if it has a maximum size and it wasn't trimmed in the last
+// actionCacheTrimInterval, so that only one of the sbox processes that store entries pays for
+// walking the cache.
+func (c *actionCache) maybeTrim() error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+	stamp := filepath.Join(c.dir, "trimmed")
+	if info, err := os.Stat(stamp); err == nil && time.Since(info.ModTime()) < actionCacheTrimInterval {
+		return nil
+	}
+	if err := ioutil.WriteFile(stamp, nil, 0666); err != nil {
+		return err
+	}
+	return c.trim()
+}
+
+// trim removes the least recently used entries and output files from the cache until it uses less
+// than 90% of its maximum size, so that it isn't trimmed again right away.
+func (c *actionCache) trim() error {
+	type cacheFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []cacheFile
+	var total int64
+	for _, subdir := range []string{"ac", "cas"} {
+		err := filepath.Walk(filepath.Join(c.dir, subdir), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				// Files may be removed concurrently by other sbox processes.
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			// Skip the temporary files of entries that are being written.
+			if info.Mode().IsRegular() && !strings.Contains(info.Name(), ".tmp") {
+				files = append(files, cacheFile{path, info.Size(), info.ModTime()})
+				total += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if total <= c.maxSize {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	target := c.maxSize - c.maxSize/10
+	for _, f := range files {
+		if total <= target {
+			break
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= f.size
+	}
+	return nil
+}
+
+// touch sets the modification time of a file in the cache to now.
+func touch(path string) error {
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
+
+// writeFileAtomically writes a file in the cache through a temporary file in the same directory,
+// so that concurrent sbox processes never see a partially written file.
+func writeFileAtomically(path string, write func(f *os.File) error) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	err = write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
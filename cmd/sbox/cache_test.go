@@ -0,0 +1,225 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"android/soong/cmd/sbox/sbox_proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestActionCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sbox_cache_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile := func(path, contents string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(contents), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tool := filepath.Join(dir, "tool")
+	input := filepath.Join(dir, "input")
+	output := filepath.Join(dir, "gen", "output")
+	writeFile(tool, "tool")
+	writeFile(input, "input")
+
+	manifest := &sbox_proto.Manifest{
+		Commands: []*sbox_proto.Command{{
+			Command: proto.String("__SBOX_SANDBOX_DIR__/tools/tool " + input + " > __SBOX_SANDBOX_DIR__/out/output"),
+			CopyBefore: []*sbox_proto.Copy{{
+				From: proto.String(tool),
+				To:   proto.String("tools/tool"),
+			}},
+			CopyAfter: []*sbox_proto.Copy{{
+				From: proto.String("out/output"),
+				To:   proto.String(output),
+			}},
+			Inputs: []string{input},
+		}},
+	}
+	env := []string{"PATH=/bin", "LANG=C"}
+
+	key, err := actionCacheKey(manifest, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The key doesn't depend on the order of the environment.
+	if other, _ := actionCacheKey(manifest, []string{"LANG=C", "PATH=/bin"}); other != key {
+		t.Errorf("key depends on the order of the environment")
+	}
+
+	// The key changes when the contents of an input, a tool, the command or the environment change.
+	writeFile(input, "new input")
+	if other, _ := actionCacheKey(manifest, env); other == key {
+		t.Errorf("key did not change when the input changed")
+	}
+	writeFile(input, "input")
+	writeFile(tool, "new tool")
+	if other, _ := actionCacheKey(manifest, env); other == key {
+		t.Errorf("key did not change when the tool changed")
+	}
+	writeFile(tool, "tool")
+	if other, _ := actionCacheKey(manifest, append(env, "FOO=bar")); other == key {
+		t.Errorf("key did not change when the environment changed")
+	}
+	if other, _ := actionCacheKey(manifest, env); other != key {
+		t.Errorf("key is not stable")
+	}
+
+	// Missing inputs are an error.
+	os.Remove(input)
+	if _, err := actionCacheKey(manifest, env); err == nil {
+		t.Errorf("expected error for missing input")
+	}
+	writeFile(input, "input")
+
+	cache := &actionCache{dir: filepath.Join(dir, "cache")}
+	outputDir = filepath.Join(dir, "gen")
+
+	if hit, err := cache.restore(key, manifest, alwaysWrite); hit || err != nil {
+		t.Fatalf("restore() on empty cache = %v, %v", hit, err)
+	}
+
+	writeFile(output, "output")
+	if err := os.Chmod(output, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.store(key, manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the output directory as if the out directory was cleaned, and add a stale file.
+	os.RemoveAll(outputDir)
+	writeFile(filepath.Join(outputDir, "stale"), "stale")
+
+	if hit, err := cache.restore(key, manifest, alwaysWrite); !hit || err != nil {
+		t.Fatalf("restore() = %v, %v", hit, err)
+	}
+
+	if data, err := ioutil.ReadFile(output); err != nil || string(data) != "output" {
+		t.Errorf("restored output = %q, %v, want %q", data, err, "output")
+	}
+	if info, err := os.Stat(output); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("restored output mode = %v, %v, want %v", info.Mode().Perm(), err, os.FileMode(0755))
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "stale")); !os.IsNotExist(err) {
+		t.Errorf("expected stale file to be removed, got %v", err)
+	}
+}
+
+func TestActionCacheTrim(t *testing.T) {
+	dir := t.TempDir()
+	cache := &actionCache{dir: dir, maxSize: 1000}
+
+	// Three 400 byte files, used from the oldest to the most recent.
+	files := []string{
+		cache.blobPath("aa01"),
+		cache.entryPath("bb02"),
+		cache.blobPath("cc03"),
+	}
+	now := time.Now()
+	for i, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, make([]byte, 400), 0666); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(time.Duration(i-len(files)) * time.Hour)
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := cache.maybeTrim(); err != nil {
+		t.Fatal(err)
+	}
+	// The least recently used files are removed until the cache is below 900 bytes.
+	for i, file := range files {
+		_, err := os.Stat(file)
+		if removed := os.IsNotExist(err); removed != (i < 1) {
+			t.Errorf("%s: removed = %v, %v", file, removed, err)
+		}
+	}
+
+	// The cache isn't trimmed again until actionCacheTrimInterval has passed.
+	if err := ioutil.WriteFile(files[0], make([]byte, 800), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.maybeTrim(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(files[0]); err != nil {
+		t.Errorf("cache trimmed again: %v", err)
+	}
+}
+
+func TestManifestIsCacheable(t *testing.T) {
+	command := func(command string) *sbox_proto.Command {
+		return &sbox_proto.Command{Command: proto.String(command)}
+	}
+
+	tests := []struct {
+		name     string
+		manifest *sbox_proto.Manifest
+		want     bool
+	}{
+		{
+			name: "plain",
+			manifest: &sbox_proto.Manifest{
+				Commands: []*sbox_proto.Command{command("cp in out")},
+			},
+			want: true,
+		},
+		{
+			name: "depfile placeholder",
+			manifest: &sbox_proto.Manifest{
+				Commands: []*sbox_proto.Command{command("cp in out"), command("gcc -MD " + depFilePlaceholder)},
+			},
+			want: false,
+		},
+		{
+			name: "output depfile",
+			manifest: &sbox_proto.Manifest{
+				Commands:      []*sbox_proto.Command{command("cp in out")},
+				OutputDepfile: proto.String("out.d"),
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := manifestIsCacheable(tt.manifest); got != tt.want {
+				t.Errorf("manifestIsCacheable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"android/soong/cmd/sbox/sbox_proto"
+	"android/soong/response"
+)
+
+// The hermeticity check runs the sandboxed commands under a tracer that records every file they
+// open or execute, and fails if any of them is a file in the source tree that was not declared as
+// an input in the manifest.  Files in the sandbox, in the output directory and in the directories
+// on $PATH are always allowed, as are files outside the source tree.
+
+// undeclaredInputsError is returned when a command read files that are not declared as inputs.
+type undeclaredInputsError struct {
+	// The module that generated the manifest, if known.
+	module string
+
+	files []string
+}
+
+func (e *undeclaredInputsError) Error() string {
+	const maxFiles = 10
+
+	msg := "sbox command"
+	if e.module != "" {
+		msg = fmt.Sprintf("sbox command for module %q", e.module)
+	}
+	msg += fmt.Sprintf(" read %d files that are not declared as inputs:", len(e.files))
+	for i, file := range e.files {
+		if i == maxFiles {
+			msg += fmt.Sprintf("\n  ...%v more", len(e.files)-maxFiles)
+			break
+		}
+		msg += "\n  " + file
+	}
+	return msg
+}
+
+// tracedExitError is returned when a command that was run under the tracer fails.
+type tracedExitError struct {
+	status syscall.WaitStatus
+}
+
+func (e *tracedExitError) Error() string {
+	if e.status.Signaled() {
+		return "signal: " + e.status.Signal().String()
+	}
+	return fmt.Sprintf("exit status %d", e.status.ExitStatus())
+}
+
+// verifyHermeticity returns an error listing the files that the command opened that are in the
+// source tree, which is the current directory, but not declared as inputs in the manifest.
+func verifyHermeticity(command *sbox_proto.Command, opened []string, tempDir string) error {
+	top, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	declared, err := declaredInputs(command)
+	if err != nil {
+		return err
+	}
+
+	allowedDirs := []string{tempDir, outputDir}
+	allowedDirs = append(allowedDirs, filepath.SplitList(os.Getenv("PATH"))...)
+
+	undeclared := undeclaredInputs(opened, declared, allowedDirs, top)
+	if len(undeclared) > 0 {
+		return &undeclaredInputsError{files: undeclared}
+	}
+	return nil
+}
+
+// declaredInputs returns the files that the command declares as inputs: the files copied into the
+// sandbox, the rsp files and the files listed in them, and the inputs that are read in place.
+func declaredInputs(command *sbox_proto.Command) ([]string, error) {
+	var inputs []string
+	for _, copyPair := range command.CopyBefore {
+		inputs = append(inputs, copyPair.GetFrom())
+	}
+	for _, rspFile := range command.RspFiles {
+		inputs = append(inputs, rspFile.GetFile())
+
+		in, err := os.Open(rspFile.GetFile())
+		if err != nil {
+			return nil, err
+		}
+		files, err := response.ReadRspFile(in)
+		in.Close()
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, files...)
+	}
+	inputs = append(inputs, command.Inputs...)
+	return inputs, nil
+}
+
+// undeclaredInputs returns the sorted list of files in opened that are under top, are not in
+// declared and are not under any of allowedDirs.  Paths in opened are absolute, other paths may
+// be relative to top.  Directories and files that no longer exist are ignored.
+func undeclaredInputs(opened, declared, allowedDirs []string, top string) []string {
+	// The tracer records paths as the kernel sees them, which have had any symlinks in the
+	// working directory resolved, so compare against both the literal and the resolved paths.
+	paths := func(path string) []string {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(top, path)
+		}
+		ret := []string{filepath.Clean(path)}
+		if resolved, err := filepath.EvalSymlinks(path); err == nil && resolved != ret[0] {
+			ret = append(ret, resolved)
+		}
+		return ret
+	}
+
+	declaredSet := make(map[string]bool)
+	for _, input := range declared {
+		for _, path := range paths(input) {
+			declaredSet[path] = true
+		}
+	}
+
+	var allowed []string
+	for _, dir := range allowedDirs {
+		if dir != "" {
+			allowed = append(allowed, paths(dir)...)
+		}
+	}
+	tops := paths(top)
+
+	under := func(path string, dirs []string) bool {
+		for _, dir := range dirs {
+			if path == dir || strings.HasPrefix(path, dir+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	seen := make(map[string]bool)
+	var undeclared []string
+	for _, file := range opened {
+		if seen[file] {
+			continue
+		}
+		seen[file] = true
+
+		if !under(file, tops) || under(file, allowed) {
+			continue
+		}
+		if info, err := os.Stat(file); err != nil || info.IsDir() {
+			continue
+		}
+
+		isDeclared := false
+		for _, path := range paths(file) {
+			if declaredSet[path] {
+				isDeclared = true
+			}
+		}
+		if isDeclared {
+			continue
+		}
+
+		rel := file
+		for _, t := range tops {
+			if strings.HasPrefix(file, t+"/") {
+				rel = strings.TrimPrefix(file, t+"/")
+				break
+			}
+		}
+		undeclared = append(undeclared, rel)
+	}
+
+	sort.Strings(undeclared)
+	return undeclared
+}
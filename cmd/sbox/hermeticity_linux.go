@@ -0,0 +1,263 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// The tracer uses ptrace to stop every process and thread started by the command at the entry and
+// exit of each system call, and records the paths passed to the system calls that open or execute
+// files when they succeed.  Registers are read with PTRACE_PEEKUSER using the layout of struct
+// user_regs_struct, so only x86_64 is supported.
+
+const ptraceOptions = syscall.PTRACE_O_TRACESYSGOOD |
+	syscall.PTRACE_O_TRACEFORK |
+	syscall.PTRACE_O_TRACEVFORK |
+	syscall.PTRACE_O_TRACECLONE |
+	syscall.PTRACE_O_TRACEEXEC |
+	ptraceOExitKill
+
+// PTRACE_O_EXITKILL kills the traced processes if the tracer exits.
+const ptraceOExitKill = 0x100000
+
+// Offsets of registers in struct user_regs_struct on x86_64.
+const (
+	regRdi     = 14 * 8
+	regRsi     = 13 * 8
+	regRax     = 10 * 8
+	regOrigRax = 15 * 8
+)
+
+// System call numbers on x86_64.
+const (
+	sysOpen     = 2
+	sysExecve   = 59
+	sysOpenat   = 257
+	sysExecveat = 322
+	sysOpenat2  = 437
+)
+
+const atFdCwd = -100
+
+// runTraced runs cmd under the tracer and returns the absolute paths of the files that it and all
+// of its children opened or executed.  cmd.Stdout must be the same as cmd.Stderr.
+func runTraced(cmd *exec.Cmd) ([]string, error) {
+	if runtime.GOARCH != "amd64" {
+		return nil, fmt.Errorf("the hermeticity check is not supported on %s", runtime.GOARCH)
+	}
+
+	// All ptrace requests must be made from the thread that started the command.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// exec.Cmd copies output through a goroutine that it waits for when it reaps the command in
+	// Wait, but the tracer reaps the command itself.  Pass the write end of a pipe directly to the
+	// command instead.
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	output := cmd.Stdout
+	cmd.Stdout, cmd.Stderr = w, w
+	copied := make(chan struct{})
+	go func() {
+		io.Copy(output, r)
+		close(copied)
+	}()
+	defer func() {
+		<-copied
+		r.Close()
+	}()
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{Ptrace: true}
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return traceProcesses(cmd.Process.Pid)
+}
+
+// traceProcesses traces the process root, which must be stopped at its first exec, and all of its
+// children until they have all exited.
+func traceProcesses(root int) ([]string, error) {
+	var ws syscall.WaitStatus
+	if _, err := syscall.Wait4(root, &ws, syscall.WALL, nil); err != nil {
+		return nil, err
+	}
+	if !ws.Stopped() {
+		return nil, fmt.Errorf("traced command did not stop")
+	}
+	if err := syscall.PtraceSetOptions(root, ptraceOptions); err != nil {
+		syscall.Kill(root, syscall.SIGKILL)
+		return nil, fmt.Errorf("failed to trace command: %w", err)
+	}
+	if err := syscall.PtraceSyscall(root, 0); err != nil {
+		return nil, err
+	}
+
+	var opened []string
+	var rootStatus syscall.WaitStatus
+
+	live := map[int]bool{root: true}
+	// The processes that are stopped inside a system call, and the path passed to it, if any.
+	inSyscall := make(map[int]bool)
+	paths := make(map[int]string)
+
+	for len(live) > 0 {
+		pid, err := syscall.Wait4(-1, &ws, syscall.WALL, nil)
+		if err == syscall.EINTR {
+			continue
+		} else if err != nil {
+			return opened, err
+		}
+
+		if ws.Exited() || ws.Signaled() {
+			delete(live, pid)
+			delete(inSyscall, pid)
+			delete(paths, pid)
+			if pid == root {
+				rootStatus = ws
+			}
+			continue
+		}
+		if !ws.Stopped() {
+			continue
+		}
+
+		signal := 0
+		switch sig := ws.StopSignal(); {
+		case sig == syscall.SIGTRAP|0x80:
+			// A system call stop.
+			if !inSyscall[pid] {
+				inSyscall[pid] = true
+				if path := syscallPath(pid); path != "" {
+					paths[pid] = path
+				}
+			} else {
+				inSyscall[pid] = false
+				if path, ok := paths[pid]; ok {
+					delete(paths, pid)
+					if ret, err := peekUser(pid, regRax); err == nil && int64(ret) >= 0 {
+						opened = append(opened, path)
+					}
+				}
+			}
+		case sig == syscall.SIGTRAP && ws.TrapCause() != 0:
+			// A fork, vfork, clone or exec event.  New children are traced automatically.
+		case sig == syscall.SIGSTOP && !live[pid]:
+			// The initial stop of a new child.
+		default:
+			signal = int(sig)
+		}
+		live[pid] = true
+
+		// The process may have been killed while it was stopped, ignore errors.
+		syscall.PtraceSyscall(pid, signal)
+	}
+
+	if !rootStatus.Exited() || rootStatus.ExitStatus() != 0 {
+		return opened, &tracedExitError{rootStatus}
+	}
+	return opened, nil
+}
+
+// syscallPath returns the absolute path passed to the system call that the process is entering if
+// it opens or executes a file, otherwise an empty string.
+func syscallPath(pid int) string {
+	nr, err := peekUser(pid, regOrigRax)
+	if err != nil {
+		return ""
+	}
+
+	dirfd := atFdCwd
+	var pathReg uintptr
+	switch nr {
+	case sysOpen, sysExecve:
+		pathReg = regRdi
+	case sysOpenat, sysOpenat2, sysExecveat:
+		fd, err := peekUser(pid, regRdi)
+		if err != nil {
+			return ""
+		}
+		dirfd = int(int32(fd))
+		pathReg = regRsi
+	default:
+		return ""
+	}
+
+	addr, err := peekUser(pid, pathReg)
+	if err != nil {
+		return ""
+	}
+	path, err := peekString(pid, uintptr(addr))
+	if err != nil || path == "" {
+		return ""
+	}
+
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	var dir string
+	if dirfd == atFdCwd {
+		dir, err = os.Readlink(fmt.Sprintf("/proc/%d/cwd", pid))
+	} else {
+		dir, err = os.Readlink(fmt.Sprintf("/proc/%d/fd/%d", pid, dirfd))
+	}
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, path)
+}
+
+// peekUser reads a word from the user area of a stopped process.
+func peekUser(pid int, offset uintptr) (uint64, error) {
+	var data uint64
+	_, _, errno := syscall.Syscall6(syscall.SYS_PTRACE, syscall.PTRACE_PEEKUSR, uintptr(pid), offset,
+		uintptr(unsafe.Pointer(&data)), 0, 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return data, nil
+}
+
+// peekString reads a null terminated string from the memory of a stopped process.
+func peekString(pid int, addr uintptr) (string, error) {
+	const maxLen = 4096
+
+	var buf []byte
+	word := make([]byte, 8)
+	for len(buf) < maxLen {
+		n, err := syscall.PtracePeekData(pid, addr+uintptr(len(buf)), word)
+		if err != nil {
+			return "", err
+		}
+		if i := bytes.IndexByte(word[:n], 0); i >= 0 {
+			return string(append(buf, word[:i]...)), nil
+		}
+		buf = append(buf, word[:n]...)
+	}
+	return "", fmt.Errorf("path too long")
+}
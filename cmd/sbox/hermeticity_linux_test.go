@@ -0,0 +1,84 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+)
+
+func TestRunTraced(t *testing.T) {
+	if runtime.GOARCH != "amd64" {
+		t.Skip("the hermeticity check is only supported on x86_64")
+	}
+
+	dir, err := ioutil.TempDir("", "sbox_trace_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "b.txt"), []byte("b"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read one file by relative path from a child process, and one by absolute path from a
+	// grandchild.
+	cmd := exec.Command("bash", "-c", "cat a.txt; (cat "+filepath.Join(dir, "b.txt")+"); cat missing.txt; exit 3")
+	cmd.Dir = dir
+	buf := &bytes.Buffer{}
+	cmd.Stdout = buf
+	cmd.Stderr = buf
+
+	opened, err := runTraced(cmd)
+	if errors.Is(err, syscall.EPERM) {
+		t.Skip("ptrace is not permitted")
+	}
+
+	var exitErr *tracedExitError
+	if !errors.As(err, &exitErr) || exitErr.status.ExitStatus() != 3 {
+		t.Fatalf("expected exit status 3, got %v", err)
+	}
+	if g := buf.String(); !bytes.HasPrefix([]byte(g), []byte("ab")) {
+		t.Errorf("unexpected output %q", g)
+	}
+
+	openedSet := make(map[string]bool)
+	for _, path := range opened {
+		openedSet[path] = true
+	}
+	for _, want := range []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")} {
+		if !openedSet[want] {
+			t.Errorf("expected %q to be opened, got %q", want, opened)
+		}
+	}
+	if missing := filepath.Join(dir, "missing.txt"); openedSet[missing] {
+		t.Errorf("expected failed open of %q to be ignored", missing)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUndeclaredInputs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sbox_hermeticity_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// The tracer reports paths with symlinks resolved.
+	top, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range []string{
+		"declared.txt",
+		"undeclared.txt",
+		"other/undeclared.h",
+		"out/.path/tool",
+		"out/soong/.temp/sbox/1234/in.txt",
+		"out/soong/gen/out.txt",
+		"prebuilts/tool",
+	} {
+		path := filepath.Join(top, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, nil, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(top, "prebuilts/tool"), filepath.Join(top, "tool_link")); err != nil {
+		t.Fatal(err)
+	}
+
+	opened := []string{
+		"/usr/lib/libc.so",
+		filepath.Join(top, "declared.txt"),
+		filepath.Join(top, "undeclared.txt"),
+		filepath.Join(top, "undeclared.txt"),
+		filepath.Join(top, "other/undeclared.h"),
+		filepath.Join(top, "other"),
+		filepath.Join(top, "missing.txt"),
+		filepath.Join(top, "out/.path/tool"),
+		filepath.Join(top, "out/soong/.temp/sbox/1234/in.txt"),
+		filepath.Join(top, "out/soong/gen/out.txt"),
+		filepath.Join(top, "prebuilts/tool"),
+	}
+	declared := []string{"declared.txt", "tool_link"}
+	allowedDirs := []string{"out/soong/.temp/sbox/1234", "out/soong/gen", filepath.Join(top, "out/.path")}
+
+	got := undeclaredInputs(opened, declared, allowedDirs, top)
+	want := []string{"other/undeclared.h", "undeclared.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("undeclaredInputs() = %q, want %q", got, want)
+	}
+}
+
+func TestUndeclaredInputsError(t *testing.T) {
+	err := &undeclaredInputsError{module: "gen", files: []string{"a.txt", "b.txt"}}
+	want := "sbox command for module \"gen\" read 2 files that are not declared as inputs:\n  a.txt\n  b.txt"
+	if g := err.Error(); g != want {
+		t.Errorf("Error() = %q, want %q", g, want)
+	}
+
+	var files []string
+	for i := 0; i < 12; i++ {
+		files = append(files, "file")
+	}
+	err = &undeclaredInputsError{files: files}
+	if g := err.Error(); !strings.HasPrefix(g, "sbox command read 12 files") || !strings.HasSuffix(g, "...2 more") {
+		t.Errorf("unexpected Error() %q", g)
+	}
+}
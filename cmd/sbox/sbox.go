@@ -38,11 +38,15 @@ import (
 )
 
 var (
-	sandboxesRoot  string
-	outputDir      string
-	manifestFile   string
-	keepOutDir     bool
-	writeIfChanged bool
+	sandboxesRoot    string
+	outputDir        string
+	manifestFile     string
+	keepOutDir       bool
+	writeIfChanged   bool
+	cacheDir         string
+	cacheHitsFile    string
+	cacheMaxSizeMB   int64
+	checkHermeticity bool
 )
 
 const (
@@ -61,6 +65,14 @@ func init() {
 		"whether to keep the sandbox directory when done")
 	flag.BoolVar(&writeIfChanged, "write-if-changed", false,
 		"only write the output files if they have changed")
+	flag.StringVar(&cacheDir, "cache-dir", "",
+		"directory of a local cache of the outputs of the sandboxed command(s)")
+	flag.StringVar(&cacheHitsFile, "cache-hits-file", "",
+		"file to append a byte to when the outputs are restored from the cache")
+	flag.Int64Var(&cacheMaxSizeMB, "cache-max-size-mb", 0,
+		"size in MiB to trim the cache to by removing the least recently used outputs, 0 for no limit")
+	flag.BoolVar(&checkHermeticity, "check-hermeticity", false,
+		"fail if the command(s) read files in the source tree that are not declared as inputs")
 }
 
 func usageViolation(violation string) {
@@ -124,6 +136,27 @@ func run() error {
 		return fmt.Errorf("at least one commands entry is required in %q", manifestFile)
 	}
 
+	// Look up the outputs of the commands in the action cache if it is enabled.  Any errors
+	// accessing the cache fall back to running the commands.
+	var cache *actionCache
+	var cacheKey string
+	if cacheDir != "" && manifestIsCacheable(manifest) {
+		if key, err := actionCacheKey(manifest, os.Environ()); err == nil {
+			cache = &actionCache{dir: cacheDir, hitsFile: cacheHitsFile, maxSize: cacheMaxSizeMB << 20}
+			cacheKey = key
+			// Always run the commands when checking hermeticity, as restoring their outputs
+			// from the cache would skip the check.
+			if !checkHermeticity {
+				hit, err := cache.restore(cacheKey, manifest, writeType(writeIfChanged))
+				if err == nil && hit {
+					// The hit count is only used for build metrics, ignore failures to record it.
+					cache.recordHit()
+					return nil
+				}
+			}
+		}
+	}
+
 	// setup sandbox directory
 	err = os.MkdirAll(sandboxesRoot, 0777)
 	if err != nil {
@@ -176,6 +209,10 @@ func run() error {
 			// case a user wants to inspect it for debugging purposes.  Soong will delete
 			// it at the beginning of the next build anyway.
 			keepOutDir = true
+			var undeclared *undeclaredInputsError
+			if errors.As(err, &undeclared) {
+				undeclared.module = manifest.GetModule()
+			}
 			return err
 		}
 		if depFile != "" {
@@ -197,6 +234,11 @@ func run() error {
 		}
 	}
 
+	if cache != nil {
+		// Failing to store the outputs in the cache doesn't fail the command.
+		cache.store(cacheKey, manifest)
+	}
+
 	return nil
 }
 
@@ -309,7 +351,12 @@ func runCommand(command *sbox_proto.Command, tempDir string, commandIndex int) (
 			return "", fmt.Errorf("Failed to update PATH: %w", err)
 		}
 	}
-	err = cmd.Run()
+	var opened []string
+	if checkHermeticity {
+		opened, err = runTraced(cmd)
+	} else {
+		err = cmd.Run()
+	}
 
 	if err != nil {
 		// The command failed, do a best effort copy of output files out of the sandbox.  This is
@@ -321,7 +368,8 @@ func runCommand(command *sbox_proto.Command, tempDir string, commandIndex int) (
 
 	// If the command  was executed but failed with an error, print a debugging message before
 	// the command's output so it doesn't scroll the real error message off the screen.
-	if exit, ok := err.(*exec.ExitError); ok && !exit.Success() {
+	_, tracedExitFailed := err.(*tracedExitError)
+	if exit, ok := err.(*exec.ExitError); ok && !exit.Success() || tracedExitFailed {
 		fmt.Fprintf(os.Stderr,
 			"The failing command was run inside an sbox sandbox in temporary directory\n"+
 				"%s\n"+
@@ -342,6 +390,13 @@ func runCommand(command *sbox_proto.Command, tempDir string, commandIndex int) (
 		return "", err
 	}
 
+	if checkHermeticity {
+		err = verifyHermeticity(command, opened, tempDir)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	// the created files match the declared files; now move them
 	err = moveFiles(command.CopyAfter, tempDir, "", writeType(writeIfChanged))
 	if err != nil {
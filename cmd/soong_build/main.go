@@ -48,11 +48,12 @@ var (
 	delveListen string
 	delvePath   string
 
-	moduleGraphFile   string
-	moduleActionsFile string
-	docFile           string
-	bazelQueryViewDir string
-	bp2buildMarker    string
+	moduleGraphFile      string
+	moduleGraphProtoFile string
+	moduleActionsFile    string
+	docFile              string
+	bazelQueryViewDir    string
+	bp2buildMarker       string
 
 	cmdlineArgs bootstrap.Args
 )
@@ -78,6 +79,7 @@ func init() {
 
 	// Flags representing various modes soong_build can run in
 	flag.StringVar(&moduleGraphFile, "module_graph_file", "", "JSON module graph file to output")
+	flag.StringVar(&moduleGraphProtoFile, "module_graph_proto_file", "", "binary proto module graph file to output, for soong_query")
 	flag.StringVar(&moduleActionsFile, "module_actions_file", "", "JSON file to output inputs/outputs of actions of modules")
 	flag.StringVar(&docFile, "soong_docs", "", "build documentation file to output")
 	flag.StringVar(&bazelQueryViewDir, "bazel_queryview_dir", "", "path to the bazel queryview directory relative to --top")
@@ -129,7 +131,7 @@ func newConfig(availableEnv map[string]string) android.Config {
 		buildMode = android.Bp2build
 	} else if bazelQueryViewDir != "" {
 		buildMode = android.GenerateQueryView
-	} else if moduleGraphFile != "" {
+	} else if moduleGraphFile != "" || moduleGraphProtoFile != "" {
 		buildMode = android.GenerateModuleGraph
 	} else if docFile != "" {
 		buildMode = android.GenerateDocFile
@@ -271,9 +273,20 @@ func doChosenActivity(ctx *android.Context, configuration android.Config, extraN
 			writeDepFile(queryviewMarkerFile, *ctx.EventHandler, ninjaDeps)
 			return queryviewMarkerFile
 		} else if configuration.BuildMode == android.GenerateModuleGraph {
-			writeJsonModuleGraphAndActions(ctx, moduleGraphFile, moduleActionsFile)
-			writeDepFile(moduleGraphFile, *ctx.EventHandler, ninjaDeps)
-			return moduleGraphFile
+			outputFile := moduleGraphFile
+			if moduleGraphFile != "" {
+				writeJsonModuleGraphAndActions(ctx, moduleGraphFile, moduleActionsFile)
+			} else {
+				outputFile = moduleGraphProtoFile
+			}
+			if moduleGraphProtoFile != "" {
+				if err := android.WriteModuleGraph(ctx, moduleGraphProtoFile); err != nil {
+					fmt.Fprintf(os.Stderr, "error writing the module graph: %s\n", err)
+					os.Exit(1)
+				}
+			}
+			writeDepFile(outputFile, *ctx.EventHandler, ninjaDeps)
+			return outputFile
 		} else if configuration.BuildMode == android.GenerateDocFile {
 			// TODO: we could make writeDocs() return the list of documentation files
 			// written and add them to the .d file. Then soong_docs would be re-run
@@ -336,7 +349,7 @@ func main() {
 		usedEnvFile,
 	}
 
-	if configuration.Getenv("ALLOW_MISSING_DEPENDENCIES") == "true" {
+	if configuration.EnvBool("ALLOW_MISSING_DEPENDENCIES") {
 		configuration.SetAllowMissingDependencies()
 	}
 
@@ -358,9 +371,41 @@ func main() {
 	ctx.EventHandler.End("soong_build")
 	writeMetrics(configuration, *ctx.EventHandler, logDir)
 
+	// The registry is declared as an output of the main soong_build invocation only, the other
+	// invocations must not write it.
+	switch configuration.BuildMode {
+	case android.AnalysisNoBazel, android.BazelDevMode, android.BazelProdMode:
+		writeEnvRegistryFile(configuration)
+	}
+	reportEnvRegistryErrors(configuration)
 	writeUsedEnvironmentFile(configuration, finalOutputFile)
 }
 
+// writeEnvRegistryFile writes the registry of the environment variables that affect the build,
+// which soong_ui reads to validate the environment and to implement --list-env.
+func writeEnvRegistryFile(configuration android.Config) {
+	path := shared.JoinPath(topDir, configuration.SoongOutDir(), shared.EnvRegistryFile)
+	data, err := shared.EnvRegistryContents(android.RegisteredEnvVars())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error writing environment registry file '%s': %s\n", path, err)
+		os.Exit(1)
+	}
+
+	err = ioutil.WriteFile(path, data, 0666)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error writing environment registry file '%s': %s\n", path, err)
+		os.Exit(1)
+	}
+}
+
+// reportEnvRegistryErrors warns about the environment variables that were read without being
+// registered with the type they were read as.
+func reportEnvRegistryErrors(configuration android.Config) {
+	for _, err := range configuration.EnvRegistryErrors() {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", err)
+	}
+}
+
 func writeUsedEnvironmentFile(configuration android.Config, finalOutputFile string) {
 	if usedEnvFile == "" {
 		return
@@ -542,7 +587,7 @@ func runBp2Build(configuration android.Config, extraNinjaDeps []string) {
 			os.Exit(1)
 		}
 
-		pathsToIgnoredBuildFiles := getPathsToIgnoredBuildFiles(configuration.Bp2buildPackageConfig, topDir, existingBazelRelatedFiles, configuration.IsEnvTrue("BP2BUILD_VERBOSE"))
+		pathsToIgnoredBuildFiles := getPathsToIgnoredBuildFiles(configuration.Bp2buildPackageConfig, topDir, existingBazelRelatedFiles, configuration.EnvBool("BP2BUILD_VERBOSE"))
 		excludes = append(excludes, pathsToIgnoredBuildFiles...)
 
 		excludes = append(excludes, getTemporaryExcludes()...)
@@ -562,7 +607,7 @@ func runBp2Build(configuration android.Config, extraNinjaDeps []string) {
 	// Only report metrics when in bp2build mode. The metrics aren't relevant
 	// for queryview, since that's a total repo-wide conversion and there's a
 	// 1:1 mapping for each module.
-	if configuration.IsEnvTrue("BP2BUILD_VERBOSE") {
+	if configuration.EnvBool("BP2BUILD_VERBOSE") {
 		metrics.Print()
 	}
 	writeBp2BuildMetrics(&metrics, configuration, eventHandler)
@@ -0,0 +1,231 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"android/soong/android/module_graph_proto"
+)
+
+// supportedVersion is the newest version of the module graph that soong_query understands.
+const supportedVersion = 1
+
+// graph indexes a module_graph_proto.ModuleGraph by name and by reverse dependencies. Modules are
+// referred to by their index in the module graph.
+type graph struct {
+	modules []*module_graph_proto.Module
+	byName  map[string][]int
+	reverse [][]*module_graph_proto.Dependency
+
+	// tagFilter selects the dependencies that are followed by the queries, nil follows all of them.
+	tagFilter *regexp.Regexp
+}
+
+func newGraph(g *module_graph_proto.ModuleGraph) (*graph, error) {
+	if g.Version == 0 || g.Version > supportedVersion {
+		return nil, fmt.Errorf("unsupported module graph version %d, soong_query supports up to version %d",
+			g.Version, supportedVersion)
+	}
+
+	ret := &graph{
+		modules: g.Modules,
+		byName:  make(map[string][]int),
+		reverse: make([][]*module_graph_proto.Dependency, len(g.Modules)),
+	}
+	for i, m := range g.Modules {
+		ret.byName[m.Name] = append(ret.byName[m.Name], i)
+		for _, dep := range m.Deps {
+			if int(dep.Module) >= len(g.Modules) {
+				return nil, fmt.Errorf("module %s depends on module index %d, but there are only %d modules",
+					ret.label(i), dep.Module, len(g.Modules))
+			}
+			ret.reverse[dep.Module] = append(ret.reverse[dep.Module],
+				&module_graph_proto.Dependency{Module: uint32(i), Tag: dep.Tag})
+		}
+	}
+	return ret, nil
+}
+
+// label returns the name of a module variant in the form accepted by lookup.
+func (g *graph) label(i int) string {
+	m := g.modules[i]
+	if m.Variant == "" {
+		return m.Name
+	}
+	return m.Name + "{" + m.Variant + "}"
+}
+
+// lookup returns the modules that match a pattern, which is either the name of a module to
+// select all its variants, or name{variant} to select a single variant.
+func (g *graph) lookup(pattern string) ([]int, error) {
+	name, variant := pattern, ""
+	hasVariant := false
+	if i := strings.IndexByte(pattern, '{'); i >= 0 && strings.HasSuffix(pattern, "}") {
+		name, variant, hasVariant = pattern[:i], pattern[i+1:len(pattern)-1], true
+	}
+
+	modules := g.byName[name]
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no module named %q", name)
+	}
+	if !hasVariant {
+		return modules, nil
+	}
+
+	var variants []string
+	for _, i := range modules {
+		if g.modules[i].Variant == variant {
+			return []int{i}, nil
+		}
+		variants = append(variants, g.modules[i].Variant)
+	}
+	return nil, fmt.Errorf("module %q has no variant %q, available variants:\n  %s",
+		name, variant, strings.Join(variants, "\n  "))
+}
+
+// edges returns the dependencies or reverse dependencies of a module that match the tag filter.
+func (g *graph) edges(i int, reverse bool) []*module_graph_proto.Dependency {
+	edges := g.modules[i].Deps
+	if reverse {
+		edges = g.reverse[i]
+	}
+	if g.tagFilter == nil {
+		return edges
+	}
+	var ret []*module_graph_proto.Dependency
+	for _, edge := range edges {
+		if g.tagFilter.MatchString(edge.Tag) {
+			ret = append(ret, edge)
+		}
+	}
+	return ret
+}
+
+// reachable returns the modules that can be reached from the given modules by following at most
+// depth dependencies, or reverse dependencies if reverse is true. A negative depth follows
+// dependencies without limit. The given modules are included in the result, which is sorted.
+func (g *graph) reachable(from []int, depth int, reverse bool) []int {
+	seen := make(map[int]bool)
+	queue := append([]int(nil), from...)
+	for _, i := range from {
+		seen[i] = true
+	}
+	for level := 0; len(queue) > 0 && (depth < 0 || level < depth); level++ {
+		var next []int
+		for _, i := range queue {
+			for _, edge := range g.edges(i, reverse) {
+				if dep := int(edge.Module); !seen[dep] {
+					seen[dep] = true
+					next = append(next, dep)
+				}
+			}
+		}
+		queue = next
+	}
+	return sortedKeys(seen)
+}
+
+// deps returns all the modules that the given modules transitively depend on, including the given
+// modules themselves.
+func (g *graph) deps(from []int, depth int) []int {
+	return g.reachable(from, depth, false)
+}
+
+// rdeps returns all the modules that transitively depend on the given modules, including the given
+// modules themselves.
+func (g *graph) rdeps(to []int, depth int) []int {
+	return g.reachable(to, depth, true)
+}
+
+// somepath returns one of the shortest dependency paths from one of the modules in from to one of
+// the modules in to, or nil if there is no such path.
+func (g *graph) somepath(from, to []int) []int {
+	targets := make(map[int]bool)
+	for _, i := range to {
+		targets[i] = true
+	}
+
+	parent := make(map[int]int)
+	queue := append([]int(nil), from...)
+	for _, i := range from {
+		parent[i] = -1
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		if targets[i] {
+			var path []int
+			for ; i >= 0; i = parent[i] {
+				path = append([]int{i}, path...)
+			}
+			return path
+		}
+		for _, edge := range g.edges(i, false) {
+			if dep := int(edge.Module); !hasKey(parent, dep) {
+				parent[dep] = i
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// allpaths returns all the modules that are on a dependency path from one of the modules in from
+// to one of the modules in to, sorted.
+func (g *graph) allpaths(from, to []int) []int {
+	reverse := make(map[int]bool)
+	for _, i := range g.rdeps(to, -1) {
+		reverse[i] = true
+	}
+	// Only the modules that reach one of the targets can be on a path, don't follow the
+	// dependencies of the others.
+	onPath := make(map[int]bool)
+	var queue []int
+	for _, i := range from {
+		if reverse[i] && !onPath[i] {
+			onPath[i] = true
+			queue = append(queue, i)
+		}
+	}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, edge := range g.edges(i, false) {
+			if dep := int(edge.Module); reverse[dep] && !onPath[dep] {
+				onPath[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return sortedKeys(onPath)
+}
+
+func hasKey(m map[int]int, k int) bool {
+	_, ok := m[k]
+	return ok
+}
+
+func sortedKeys(m map[int]bool) []int {
+	ret := make([]int, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Ints(ret)
+	return ret
+}
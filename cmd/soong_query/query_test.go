@@ -0,0 +1,214 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+
+	"android/soong/android/module_graph_proto"
+)
+
+func dep(module uint32, tag string) *module_graph_proto.Dependency {
+	return &module_graph_proto.Dependency{Module: module, Tag: tag}
+}
+
+// testGraph returns a graph where app depends on lib{a} and java, java depends on lib{b}, both
+// variants of lib depend on base, and unrelated only depends on base.
+func testGraph(t *testing.T) *graph {
+	t.Helper()
+	g, err := newGraph(&module_graph_proto.ModuleGraph{
+		Version: 1,
+		Modules: []*module_graph_proto.Module{
+			{Name: "app", Type: "android_app", Deps: []*module_graph_proto.Dependency{
+				dep(2, "java.jniDependencyTag"), dep(1, "java.dependencyTag")},
+				Outputs: []string{"out/app.apk"}},
+			{Name: "java", Type: "java_library", Deps: []*module_graph_proto.Dependency{
+				dep(3, "java.jniDependencyTag")}},
+			{Name: "lib", Variant: "a", Type: "cc_library", Deps: []*module_graph_proto.Dependency{
+				dep(4, "cc.libraryDependencyTag")}},
+			{Name: "lib", Variant: "b", Type: "cc_library", Deps: []*module_graph_proto.Dependency{
+				dep(4, "cc.libraryDependencyTag")}},
+			{Name: "base", Type: "cc_library", Providers: []string{"cc.SharedLibraryInfo"}},
+			{Name: "unrelated", Type: "cc_binary", Deps: []*module_graph_proto.Dependency{
+				dep(4, "cc.libraryDependencyTag")}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestQueries(t *testing.T) {
+	testCases := []struct {
+		name   string
+		query  string
+		args   []string
+		depth  int
+		tags   string
+		output string
+		want   []string
+		err    string
+	}{
+		{
+			name:  "deps",
+			query: "deps",
+			args:  []string{"app"},
+			depth: -1,
+			want:  []string{"app", "java", "lib{a}", "lib{b}", "base"},
+		},
+		{
+			name:  "direct deps",
+			query: "deps",
+			args:  []string{"app"},
+			depth: 1,
+			want:  []string{"app", "java", "lib{a}"},
+		},
+		{
+			name:  "deps filtered by tag",
+			query: "deps",
+			args:  []string{"app"},
+			depth: -1,
+			tags:  `^java\.`,
+			want:  []string{"app", "java", "lib{a}", "lib{b}"},
+		},
+		{
+			name:  "rdeps of a variant",
+			query: "rdeps",
+			args:  []string{"lib{b}"},
+			depth: -1,
+			want:  []string{"app", "java", "lib{b}"},
+		},
+		{
+			name:  "rdeps of all variants",
+			query: "rdeps",
+			args:  []string{"base"},
+			depth: 1,
+			want:  []string{"lib{a}", "lib{b}", "base", "unrelated"},
+		},
+		{
+			name:  "somepath",
+			query: "somepath",
+			args:  []string{"app", "base"},
+			want:  []string{"app", "lib{a}", "base"},
+		},
+		{
+			name:  "somepath without a path",
+			query: "somepath",
+			args:  []string{"unrelated", "lib"},
+		},
+		{
+			name:  "allpaths",
+			query: "allpaths",
+			args:  []string{"app", "base"},
+			want:  []string{"app", "java", "lib{a}", "lib{b}", "base"},
+		},
+		{
+			name:  "allpaths through a variant",
+			query: "allpaths",
+			args:  []string{"app", "lib{b}"},
+			want:  []string{"app", "java", "lib{b}"},
+		},
+		{
+			name:   "type output",
+			query:  "deps",
+			args:   []string{"java"},
+			depth:  1,
+			output: "type",
+			want:   []string{"java_library java", "cc_library lib{b}"},
+		},
+		{
+			name:   "outputs output",
+			query:  "deps",
+			args:   []string{"app"},
+			depth:  -1,
+			output: "outputs",
+			want:   []string{"out/app.apk"},
+		},
+		{
+			name:   "providers output",
+			query:  "deps",
+			args:   []string{"java"},
+			depth:  -1,
+			output: "providers",
+			want:   []string{"base cc.SharedLibraryInfo"},
+		},
+		{
+			name:  "unknown module",
+			query: "deps",
+			args:  []string{"missing"},
+			err:   `no module named "missing"`,
+		},
+		{
+			name:  "unknown variant",
+			query: "deps",
+			args:  []string{"lib{c}"},
+			err:   "module \"lib\" has no variant \"c\", available variants:\n  a\n  b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := testGraph(t)
+			if tc.tags != "" {
+				g.tagFilter = regexp.MustCompile(tc.tags)
+			}
+			output := tc.output
+			if output == "" {
+				output = "label"
+			}
+
+			buf := &bytes.Buffer{}
+			found, err := runQuery(buf, g, tc.query, tc.args, tc.depth, output)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("want error %q, got %v", tc.err, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			if buf.Len() > 0 {
+				got = strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			}
+			if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Errorf("want:\n%s\ngot:\n%s", strings.Join(tc.want, "\n"), strings.Join(got, "\n"))
+			}
+			if found != (len(tc.want) > 0) {
+				t.Errorf("want found %v, got %v", len(tc.want) > 0, found)
+			}
+		})
+	}
+}
+
+func TestNewGraphErrors(t *testing.T) {
+	_, err := newGraph(&module_graph_proto.ModuleGraph{Version: supportedVersion + 1})
+	if err == nil || !strings.Contains(err.Error(), "unsupported module graph version") {
+		t.Errorf("want unsupported version error, got %v", err)
+	}
+
+	_, err = newGraph(&module_graph_proto.ModuleGraph{
+		Version: supportedVersion,
+		Modules: []*module_graph_proto.Module{{Name: "a", Deps: []*module_graph_proto.Dependency{dep(1, "")}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "module a depends on module index 1") {
+		t.Errorf("want invalid index error, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// soong_query answers questions about the dependencies between Soong modules, using the module
+// graph written by `m json-module-graph` to $OUT_DIR/soong/module-graph.pb.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"google.golang.org/protobuf/proto"
+
+	"android/soong/android/module_graph_proto"
+)
+
+var (
+	graphFile = flag.String("graph", defaultGraphFile(), "module graph written by m json-module-graph")
+	depth     = flag.Int("depth", -1, "maximum number of dependencies to follow for deps and rdeps, -1 for no limit")
+	tags      = flag.String("tags", "", "only follow dependencies whose tag type matches this regular expression")
+	output    = flag.String("output", "label", "what to print for each module: label, type, providers or outputs")
+)
+
+func defaultGraphFile() string {
+	outDir := os.Getenv("OUT_DIR")
+	if outDir == "" {
+		outDir = "out"
+	}
+	return filepath.Join(outDir, "soong", "module-graph.pb")
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: soong_query [flags] <query> <module>...\n\n")
+	fmt.Fprintf(os.Stderr, "Queries:\n")
+	fmt.Fprintf(os.Stderr, "  deps <module>            the modules that <module> transitively depends on\n")
+	fmt.Fprintf(os.Stderr, "  rdeps <module>           the modules that transitively depend on <module>\n")
+	fmt.Fprintf(os.Stderr, "  somepath <from> <to>     one of the shortest dependency paths from <from> to <to>\n")
+	fmt.Fprintf(os.Stderr, "  allpaths <from> <to>     all the modules on a dependency path from <from> to <to>\n\n")
+	fmt.Fprintf(os.Stderr, "Modules are either a module name, which selects all its variants, or\n")
+	fmt.Fprintf(os.Stderr, "name{variant} to select a single variant, e.g. 'libc{android_arm64_armv8-a_shared}'.\n\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+// queryArgs is the number of module arguments that each query takes.
+var queryArgs = map[string]int{
+	"deps":     1,
+	"rdeps":    1,
+	"somepath": 2,
+	"allpaths": 2,
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 1 || queryArgs[flag.Arg(0)] != flag.NArg()-1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	g, err := loadGraph(*graphFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+	if *tags != "" {
+		g.tagFilter, err = regexp.Compile(*tags)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error: invalid -tags:", err)
+			os.Exit(2)
+		}
+	}
+
+	found, err := runQuery(os.Stdout, g, flag.Arg(0), flag.Args()[1:], *depth, *output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+	if !found {
+		os.Exit(1)
+	}
+}
+
+func loadGraph(file string) (*graph, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w, run `m json-module-graph` to generate it", err)
+		}
+		return nil, err
+	}
+	var g module_graph_proto.ModuleGraph
+	if err := proto.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	return newGraph(&g)
+}
+
+// runQuery runs a query and prints the resulting modules to w. It returns false if the result is
+// empty.
+func runQuery(w io.Writer, g *graph, query string, args []string, depth int, output string) (bool, error) {
+	switch output {
+	case "label", "type", "providers", "outputs":
+	default:
+		return false, fmt.Errorf("unknown output %q, must be label, type, providers or outputs", output)
+	}
+
+	var modules [][]int
+	for _, arg := range args {
+		m, err := g.lookup(arg)
+		if err != nil {
+			return false, err
+		}
+		modules = append(modules, m)
+	}
+
+	var result []int
+	switch query {
+	case "deps":
+		result = g.deps(modules[0], depth)
+	case "rdeps":
+		result = g.rdeps(modules[0], depth)
+	case "somepath":
+		result = g.somepath(modules[0], modules[1])
+	case "allpaths":
+		result = g.allpaths(modules[0], modules[1])
+	default:
+		return false, fmt.Errorf("unknown query %q", query)
+	}
+
+	for _, i := range result {
+		m := g.modules[i]
+		switch output {
+		case "label":
+			fmt.Fprintln(w, g.label(i))
+		case "type":
+			fmt.Fprintln(w, m.Type, g.label(i))
+		case "providers":
+			for _, provider := range m.Providers {
+				fmt.Fprintln(w, g.label(i), provider)
+			}
+		case "outputs":
+			for _, out := range m.Outputs {
+				fmt.Fprintln(w, out)
+			}
+		}
+	}
+	return len(result) > 0, nil
+}
@@ -28,6 +28,8 @@ import (
 
 	"android/soong/shared"
 	"android/soong/ui/build"
+	"android/soong/ui/build/explain"
+	"android/soong/ui/build/history"
 	"android/soong/ui/logger"
 	"android/soong/ui/metrics"
 	"android/soong/ui/signal"
@@ -51,6 +53,9 @@ type command struct {
 	// Sets a prefix string to use for filenames of log files.
 	logsPrefix string
 
+	// Append a record of the build to the build history in the out directory.
+	recordHistory bool
+
 	// Creates the build configuration based on the args and build context.
 	config func(ctx build.Context, args ...string) build.Config
 
@@ -64,11 +69,12 @@ type command struct {
 // list of supported commands (flags) supported by soong ui
 var commands = []command{
 	{
-		flag:        "--make-mode",
-		description: "build the modules by the target name (i.e. soong_docs)",
-		config:      build.NewConfig,
-		stdio:       stdio,
-		run:         runMake,
+		flag:          "--make-mode",
+		description:   "build the modules by the target name (i.e. soong_docs)",
+		recordHistory: true,
+		config:        build.NewConfig,
+		stdio:         stdio,
+		run:           runMake,
 	}, {
 		flag:         "--dumpvar-mode",
 		description:  "print the value of the legacy make variable VAR to stdout",
@@ -86,11 +92,57 @@ var commands = []command{
 		stdio:        customStdio,
 		run:          dumpVars,
 	}, {
-		flag:        "--build-mode",
-		description: "build modules based on the specified build action",
-		config:      buildActionConfig,
+		flag:          "--build-mode",
+		description:   "build modules based on the specified build action",
+		recordHistory: true,
+		config:        buildActionConfig,
+		stdio:         stdio,
+		run:           runMake,
+	}, {
+		flag:         "--history",
+		description:  "print the history of builds in the out directory and the regressions of the last build",
+		simpleOutput: true,
+		logsPrefix:   "history-",
+		config:       dumpVarConfig,
+		stdio:        customStdio,
+		run:          printHistory,
+	}, {
+		flag:          "--explain",
+		description:   "build the modules by the target name and explain why each action reran",
+		recordHistory: true,
+		config:        build.NewConfig,
+		stdio:         stdio,
+		run:           runExplain,
+	}, {
+		flag:         "--watch-finder",
+		description:  "keep the database of build files in the source tree up to date until interrupted, so that builds start faster",
+		simpleOutput: true,
+		logsPrefix:   "watch-finder-",
+		config:       dumpVarConfig,
+		stdio:        stdio,
+		run:          watchFinder,
+	}, {
+		flag:         "--whoowns",
+		description:  "print the Soong modules that write or install the given files",
+		simpleOutput: true,
+		logsPrefix:   "whoowns-",
+		config:       whoOwnsConfig,
+		stdio:        customStdio,
+		run:          whoOwns,
+	}, {
+		flag:        "--ide-export",
+		description: "write compile_commands.json, rust-project.json and java deps for the given directories and their dependencies",
+		config:      ideExportConfig,
 		stdio:       stdio,
-		run:         runMake,
+		run:         ideExport,
+	}, {
+		flag:         "--list-env",
+		description:  "print the environment variables that affect the build and their values",
+		simpleOutput: true,
+		logsPrefix:   "listenv-",
+		config:       listEnvConfig,
+		stdio:        customStdio,
+		run:          listEnv,
 	},
 }
 
@@ -192,7 +244,13 @@ func main() {
 	stat.AddOutput(status.NewVerboseLog(log, filepath.Join(logsDir, c.logsPrefix+"verbose.log")))
 	stat.AddOutput(status.NewErrorLog(log, filepath.Join(logsDir, c.logsPrefix+"error.log")))
 	stat.AddOutput(status.NewProtoErrorLog(log, buildErrorFile))
-	stat.AddOutput(status.NewCriticalPath(log))
+	criticalPath := status.NewCriticalPath(log)
+	stat.AddOutput(criticalPath)
+	actionCounter := history.NewActionCounter()
+	if cacheDir, ok := config.Environment().Get("SOONG_SBOX_CACHE_DIR"); ok && cacheDir != "" {
+		actionCounter.CountSboxCacheHits(config.SoongOutDir())
+	}
+	stat.AddOutput(actionCounter)
 	stat.AddOutput(status.NewBuildProgressLog(log, filepath.Join(logsDir, c.logsPrefix+"build_progress.pb")))
 
 	buildCtx.Verbosef("Detected %.3v GB total RAM", float32(config.TotalRAM())/(1024*1024*1024))
@@ -204,9 +262,10 @@ func main() {
 	{
 		// The order of the function calls is important. The last defer function call
 		// is the first one that is executed to save the rbe metrics to a protobuf
-		// file. The soong metrics file is then next. Bazel profiles are written
-		// before the uploadMetrics is invoked. The written files are then uploaded
-		// if the uploading of the metrics is enabled.
+		// file. The critical path is then added to the soong metrics before the soong
+		// metrics file is written. Bazel profiles are written before the uploadMetrics
+		// is invoked. The written files are then uploaded if the uploading of the
+		// metrics is enabled.
 		files := []string{
 			buildErrorFile,           // build error strings
 			rbeMetricsFile,           // high level metrics related to remote build execution.
@@ -215,6 +274,18 @@ func main() {
 		}
 		defer build.UploadMetrics(buildCtx, config, c.simpleOutput, buildStarted, files...)
 		defer met.Dump(soongMetricsFile)
+		if c.recordHistory {
+			defer func() {
+				// Record whether the build is failing with a fatal error, then continue
+				// panicking so that the logger can exit.
+				p := recover()
+				build.RecordBuildHistory(buildCtx, config, buildStarted, actionCounter, p != nil)
+				if p != nil {
+					panic(p)
+				}
+			}()
+		}
+		defer build.WriteCriticalPath(buildCtx, config, criticalPath)
 		defer build.CheckProdCreds(buildCtx, config)
 	}
 
@@ -384,6 +455,158 @@ func dumpVars(ctx build.Context, config build.Config, args []string, _ string) {
 	}
 }
 
+func printHistory(ctx build.Context, config build.Config, args []string, _ string) {
+	flags := flag.NewFlagSet("history", flag.ExitOnError)
+	flags.SetOutput(ctx.Writer)
+
+	flags.Usage = func() {
+		fmt.Fprintf(ctx.Writer, "usage: %s --history [-n N] [-threshold PERCENT]\n\n", os.Args[0])
+		fmt.Fprintln(ctx.Writer, "In history mode, print the most recent builds in the out directory, then")
+		fmt.Fprintln(ctx.Writer, "compare the last build with the last successful build of the same targets")
+		fmt.Fprintln(ctx.Writer, "and print the phases that regressed.")
+		fmt.Fprintln(ctx.Writer, "")
+		flags.PrintDefaults()
+	}
+	n := flags.Int("n", 10, "Number of builds to print")
+	threshold := flags.Float64("threshold", 10, "Minimum percentage increase of a duration to report as a regression")
+	flags.Parse(args)
+
+	if flags.NArg() != 0 {
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	records, err := history.Read(config.BuildHistoryFile())
+	if err != nil {
+		ctx.Fatalf("Failed to read build history: %s", err)
+	}
+	if len(records) == 0 {
+		fmt.Printf("No builds recorded in %s\n", config.BuildHistoryFile())
+		return
+	}
+
+	recent := records
+	if *n > 0 && len(recent) > *n {
+		recent = recent[len(recent)-*n:]
+	}
+	history.Print(os.Stdout, recent)
+
+	last := records[len(records)-1]
+	baseline := history.Baseline(records)
+	if baseline == nil {
+		fmt.Printf("\nNo earlier successful build of %s to compare with\n", last.TargetsString())
+		return
+	}
+
+	fmt.Printf("\nComparing with the build of %s at %s:\n", baseline.TargetsString(),
+		baseline.StartTime().Format("2006-01-02 15:04:05"))
+	regressions := history.Regressions(baseline, last, *threshold/100, time.Second.Milliseconds())
+	if len(regressions) == 0 {
+		fmt.Println("  no regressions")
+	}
+	for _, r := range regressions {
+		fmt.Println("  " + r.String())
+	}
+}
+
+func watchFinder(ctx build.Context, config build.Config, args []string, _ string) {
+	build.WatchSourceFinder(ctx, config)
+}
+
+// whoOwnsConfig builds the module graph, which records the files that each module writes and
+// installs.
+func whoOwnsConfig(ctx build.Context, args ...string) build.Config {
+	return build.NewConfig(ctx, "json-module-graph")
+}
+
+func whoOwns(ctx build.Context, config build.Config, args []string, _ string) {
+	flags := flag.NewFlagSet("whoowns", flag.ExitOnError)
+	flags.SetOutput(ctx.Writer)
+
+	flags.Usage = func() {
+		fmt.Fprintf(ctx.Writer, "usage: %s --whoowns <path> [<path> ...]\n\n", os.Args[0])
+		fmt.Fprintln(ctx.Writer, "In whoowns mode, print the Soong modules and variants, or singletons, that")
+		fmt.Fprintln(ctx.Writer, "write or install each of the given files. Paths may be absolute, relative to")
+		fmt.Fprintln(ctx.Writer, "the top of the source tree or relative to the out directory.")
+		fmt.Fprintln(ctx.Writer, "")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	// Update the module graph first so that it knows about the current Android.bp files.
+	build.Build(ctx, config)
+	build.WhoOwns(ctx, config, flags.Args(), os.Stdout)
+}
+
+// ideExportConfig runs Soong only, and builds the generated sources of the modules in the
+// directories given on the command line.
+func ideExportConfig(ctx build.Context, args ...string) build.Config {
+	var dirs []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			dirs = append(dirs, arg)
+		}
+	}
+	return build.NewConfig(ctx, build.IdeExportArgs(ctx, dirs)...)
+}
+
+func ideExport(ctx build.Context, config build.Config, args []string, _ string) {
+	flags := flag.NewFlagSet("ide-export", flag.ExitOnError)
+	flags.SetOutput(ctx.Writer)
+
+	flags.Usage = func() {
+		fmt.Fprintf(ctx.Writer, "usage: %s --ide-export <dir> [<dir> ...]\n\n", os.Args[0])
+		fmt.Fprintln(ctx.Writer, "In ide-export mode, write compile_commands.json, rust-project.json and")
+		fmt.Fprintln(ctx.Writer, "module_bp_java_deps.json for the modules in the given directories and their")
+		fmt.Fprintln(ctx.Writer, "transitive dependencies, and build the generated sources and headers that")
+		fmt.Fprintln(ctx.Writer, "they use. Directories may be absolute or relative to the top of the source tree.")
+		fmt.Fprintln(ctx.Writer, "")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	build.Build(ctx, config)
+	build.PrintIdeExport(ctx, config, ctx.Writer)
+}
+
+// listEnvConfig runs Soong only, which writes the registry of the environment variables.
+func listEnvConfig(ctx build.Context, args ...string) build.Config {
+	return build.NewConfig(ctx, "--soong-only", "--skip-ninja")
+}
+
+func listEnv(ctx build.Context, config build.Config, args []string, _ string) {
+	flags := flag.NewFlagSet("list-env", flag.ExitOnError)
+	flags.SetOutput(ctx.Writer)
+
+	flags.Usage = func() {
+		fmt.Fprintf(ctx.Writer, "usage: %s --list-env\n\n", os.Args[0])
+		fmt.Fprintln(ctx.Writer, "In list-env mode, print the environment variables that affect the build with")
+		fmt.Fprintln(ctx.Writer, "their type, owner, effective value and description, and warn about unknown")
+		fmt.Fprintln(ctx.Writer, "SOONG_* and RBE_* variables and invalid values.")
+		fmt.Fprintln(ctx.Writer, "")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 0 {
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	build.Build(ctx, config)
+	build.ListEnv(ctx, config, os.Stdout)
+}
+
 func stdio() terminal.StdioInterface {
 	return terminal.StdioImpl{}
 }
@@ -519,6 +742,35 @@ func runMake(ctx build.Context, config build.Config, _ []string, logsDir string)
 	build.Build(ctx, config)
 }
 
+// runExplain runs a build like runMake, then reports why ninja reran each of
+// the actions that it ran, grouped by the Soong module that owns them.
+func runExplain(ctx build.Context, config build.Config, args []string, logsDir string) {
+	snapshot, err := explain.ReadSnapshot(config.NinjaLogFile(), config.NinjaDepsFile())
+	if err != nil {
+		ctx.Fatal(err)
+	}
+
+	recorder := explain.NewRecorder()
+	ctx.Status.AddOutput(recorder)
+
+	// Explain the actions that ran even if the build failed, in which case
+	// failing to explain them must not replace the original failure.
+	defer func() {
+		p := recover()
+		if err := build.ExplainRebuilds(ctx, config, snapshot, recorder, os.Stdout); err != nil {
+			if p == nil {
+				ctx.Fatalf("Failed to explain rebuilds: %s", err)
+			}
+			ctx.Println("Failed to explain rebuilds:", err)
+		}
+		if p != nil {
+			panic(p)
+		}
+	}()
+
+	runMake(ctx, config, args, logsDir)
+}
+
 // getCommand finds the appropriate command based on args[1] flag. args[0]
 // is the soong_ui filename.
 func getCommand(args []string) (*command, []string, error) {
@@ -0,0 +1,153 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// tidy_sarif records the findings of clang-tidy as SARIF. It either runs clang-tidy on a
+// translation unit and writes its findings, or merges the SARIF files of translation units into
+// the report of a module, or of the reports of modules into a single report, optionally comparing
+// the findings to a baseline.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"android/soong/sarif"
+)
+
+var (
+	output         = flag.String("o", "", "SARIF file to write")
+	merge          = flag.Bool("merge", false, "merge the SARIF files given as arguments instead of running a command")
+	module         = flag.String("module", "", "module to attribute the merged findings to")
+	dir            = flag.String("dir", "", "directory of the module to attribute the merged findings to")
+	baseline       = flag.String("baseline", "", "SARIF file with the known findings of the module")
+	checksAsErrors = flag.String("checks_as_errors", "", "comma separated clang-tidy checks whose new findings are errors")
+	summary        = flag.String("summary", "", "file to write the number of findings per directory and module to")
+)
+
+const toolName = "clang-tidy"
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s -o <sarif> -- <clang-tidy command> [args...]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "       %s -merge -o <sarif> [options] <sarif|@rsp>...\n", os.Args[0])
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if *output == "" || flag.NArg() == 0 {
+		usage()
+	}
+
+	if *merge {
+		if err := mergeReports(flag.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	exitCode, err := runTidy(flag.Arg(0), flag.Args()[1:], *output, os.Stdout, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", os.Args[0], err)
+		os.Exit(1)
+	}
+	os.Exit(exitCode)
+}
+
+// runTidy runs the clang-tidy command, forwarding its output, and writes its findings to the SARIF
+// file. The SARIF file is written even if the command fails so that the findings that failed the
+// build are recorded. It returns the exit code of the command.
+func runTidy(command string, args []string, sarifFile string, stdout, stderr io.Writer) (int, error) {
+	var out bytes.Buffer
+	cmd := exec.Command(command, args...)
+	cmd.Stdout = io.MultiWriter(stdout, &out)
+	cmd.Stderr = io.MultiWriter(stderr, &out)
+	err := cmd.Run()
+
+	exitCode := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		return 0, err
+	}
+
+	results := parseTidyOutput(&out)
+	if err := sarif.NewLog(toolName, results).WriteFile(sarifFile); err != nil {
+		return 0, err
+	}
+	return exitCode, nil
+}
+
+// A finding of clang-tidy, e.g.
+// "a/foo.cpp:12:5: warning: message [bugprone-foo]", or
+// "a/foo.cpp:12:5: error: message [bugprone-foo,-warnings-as-errors]".
+var findingRegexp = regexp.MustCompile(`^(.+?):(\d+):(\d+): (warning|error): (.*) \[([^\[\] ]+)\]$`)
+
+// parseTidyOutput returns the findings in the output of clang-tidy, without duplicates.
+func parseTidyOutput(r io.Reader) []*sarif.Result {
+	var results []*sarif.Result
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+
+		match := findingRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(match[2])
+		column, _ := strconv.Atoi(match[3])
+		checks := strings.Split(match[6], ",")
+		level := sarif.LevelWarning
+		if match[4] == "error" {
+			level = sarif.LevelError
+		}
+		results = append(results, sarif.NewResult(checks[0], level, match[5],
+			filepath.Clean(match[1]), lineNumber, column))
+	}
+	return results
+}
+
+// mergeReports merges the SARIF files into the output file. If a baseline is given, the new
+// findings of the checks in checks_as_errors are errors and fail the merge after the output file
+// is written.
+func mergeReports(args []string) error {
+	files, err := sarif.ReadInputs(args)
+	if err != nil {
+		return err
+	}
+	return sarif.MergeReport(toolName, files, *output, *summary, sarif.MergeOptions{
+		Module:        *module,
+		Directory:     *dir,
+		Baseline:      *baseline,
+		RulesAsErrors: *checksAsErrors,
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"android/soong/sarif"
+)
+
+func TestParseTidyOutput(t *testing.T) {
+	output := strings.Join([]string{
+		"2 warnings generated.",
+		"a/foo.cpp:12:5: warning: use nullptr [modernize-use-nullptr]",
+		"  int *p = 0;",
+		"          ^",
+		"a/./foo.h:3:1: error: bad thing [bugprone-bad,-warnings-as-errors]",
+		"a/foo.cpp:12:5: warning: use nullptr [modernize-use-nullptr]",
+		"a/foo.cpp:20:1: note: declared here",
+		"Suppressed 10 warnings (10 in non-user code).",
+	}, "\n")
+
+	var got []string
+	for _, result := range parseTidyOutput(strings.NewReader(output)) {
+		got = append(got, result.String())
+	}
+	want := []string{
+		"a/foo.cpp:12:5: warning: use nullptr [modernize-use-nullptr]",
+		"a/foo.h:3:1: error: bad thing [bugprone-bad]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestRunTidy(t *testing.T) {
+	sarifFile := filepath.Join(t.TempDir(), "foo.tidy.sarif")
+	var stdout, stderr bytes.Buffer
+	exitCode, err := runTidy("sh", []string{"-c",
+		"echo 'foo.cpp:1:2: warning: w [a-b]'; echo 'foo.cpp:3:4: error: e [c-d]' >&2; exit 3"},
+		sarifFile, &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exitCode != 3 {
+		t.Errorf("want exit code 3, got %d", exitCode)
+	}
+	if got, want := stdout.String(), "foo.cpp:1:2: warning: w [a-b]\n"; got != want {
+		t.Errorf("want stdout %q, got %q", want, got)
+	}
+	if got, want := stderr.String(), "foo.cpp:3:4: error: e [c-d]\n"; got != want {
+		t.Errorf("want stderr %q, got %q", want, got)
+	}
+
+	log, err := sarif.ReadFile(sarifFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(log.Results()); got != 2 {
+		t.Errorf("want 2 results, got %d", got)
+	}
+}
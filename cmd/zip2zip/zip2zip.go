@@ -29,6 +29,7 @@ import (
 
 	"android/soong/jar"
 	"android/soong/third_party/zip"
+	soong_zip "android/soong/zip"
 )
 
 var (
@@ -43,17 +44,19 @@ var (
 	excludes   multiFlag
 	includes   multiFlag
 	uncompress multiFlag
+	alignment  soong_zip.AlignmentRules
 )
 
 func init() {
 	flag.Var(&excludes, "x", "exclude a filespec from the output")
 	flag.Var(&includes, "X", "include a filespec in the output that was previously excluded")
 	flag.Var(&uncompress, "0", "convert a filespec to uncompressed in the output")
+	flag.Var(&alignment, "align", "[<glob>:]<alignment> to align the data of uncompressed files, the last matching rule wins")
 }
 
 func main() {
 	flag.Usage = func() {
-		fmt.Fprintln(os.Stderr, "usage: zip2zip -i zipfile -o zipfile [-s|-j] [-t] [filespec]...")
+		fmt.Fprintln(os.Stderr, "usage: zip2zip -i zipfile -o zipfile [-s|-j] [-t] [-align [glob:]alignment] [filespec]...")
 		flag.PrintDefaults()
 		fmt.Fprintln(os.Stderr, "  filespec:")
 		fmt.Fprintln(os.Stderr, "    <name>")
@@ -96,6 +99,7 @@ func main() {
 			log.Fatal(err)
 		}
 	}()
+	alignment.SetAlignment(writer)
 
 	if err := zip2zip(&reader.Reader, writer, *sortGlobs, *sortJava, *setTime,
 		flag.Args(), excludes, includes, uncompress); err != nil {
@@ -0,0 +1,245 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+)
+
+// A resourceTable is the summary of a resources.arsc file that is compared.
+type resourceTable struct {
+	strings int
+
+	// packages are the ids and names of the packages in the table
+	packages map[string]bool
+
+	// resources are the names of the resources in the form <package>:<type>/<name>
+	resources map[string]bool
+
+	// configs is the number of configurations of each type in the form <package>:<type>
+	configs map[string]int
+}
+
+// Chunk types from frameworks/base/libs/androidfw/include/androidfw/ResourceTypes.h.
+const (
+	resStringPoolType    = 0x0001
+	resTableType         = 0x0002
+	resTablePackageType  = 0x0200
+	resTableTypeType     = 0x0201
+	resTableTypeSpecType = 0x0202
+
+	resStringPoolUTF8Flag = 1 << 8
+
+	resTableTypeFlagSparse   = 0x01
+	resTableTypeFlagOffset16 = 0x02
+	resTableEntryFlagCompact = 0x08
+
+	resTableNoEntry = 0xffffffff
+)
+
+// A resChunk is a chunk of a resource table.
+type resChunk struct {
+	typ        uint16
+	headerSize int
+	start, end int
+}
+
+// resChunks returns the chunks between start and end.
+func resChunks(r *binaryReader, start, end int) []resChunk {
+	var chunks []resChunk
+	for pos := start; pos+8 <= end && r.err == nil; {
+		r.seek(pos)
+		c := resChunk{typ: r.u16(), headerSize: int(r.u16()), start: pos}
+		size := int(r.u32())
+		if size < 8 || pos+size > end {
+			r.err = errTruncated
+			break
+		}
+		c.end = pos + size
+		chunks = append(chunks, c)
+		pos = c.end
+	}
+	return chunks
+}
+
+// resStringPool returns the strings of the string pool chunk c.
+func resStringPool(r *binaryReader, c resChunk) []string {
+	r.seek(c.start + 8)
+	count, _, flags, stringsStart := int(r.u32()), r.u32(), r.u32(), int(r.u32())
+	utf8 := flags&resStringPoolUTF8Flag != 0
+
+	var strs []string
+	for i := 0; i < count && r.err == nil; i++ {
+		offset := int(r.seek(c.start + c.headerSize + 4*i).u32())
+		r.seek(c.start + stringsStart + offset)
+		if utf8 {
+			// The length in UTF-16 code units, followed by the length in bytes.
+			for j := 0; j < 2; j++ {
+				length := int(r.u8())
+				if length&0x80 != 0 {
+					length = (length&0x7f)<<8 | int(r.u8())
+				}
+				if j == 1 {
+					strs = append(strs, string(r.next(length)))
+				}
+			}
+		} else {
+			length := int(r.u16())
+			if length&0x8000 != 0 {
+				length = (length&0x7fff)<<16 | int(r.u16())
+			}
+			units := make([]uint16, 0, length)
+			for j := 0; j < length && r.err == nil; j++ {
+				units = append(units, r.u16())
+			}
+			strs = append(strs, string(utf16.Decode(units)))
+		}
+	}
+	return strs
+}
+
+func parseResourceTable(data []byte) (*resourceTable, error) {
+	r := newBinaryReader(data, binary.LittleEndian)
+	table := &resourceTable{
+		packages:  make(map[string]bool),
+		resources: make(map[string]bool),
+		configs:   make(map[string]int),
+	}
+
+	if r.u16() != resTableType {
+		return nil, fmt.Errorf("not a resource table")
+	}
+	headerSize := int(r.u16())
+
+	for _, c := range resChunks(r, headerSize, len(data)) {
+		switch c.typ {
+		case resStringPoolType:
+			table.strings = len(resStringPool(r, c))
+		case resTablePackageType:
+			parseResourcePackage(r, c, table)
+		}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return table, nil
+}
+
+func parseResourcePackage(r *binaryReader, c resChunk, table *resourceTable) {
+	r.seek(c.start + 8)
+	id := r.u32()
+	var nameUnits []uint16
+	for i := 0; i < 128; i++ {
+		if unit := r.u16(); unit != 0 {
+			nameUnits = append(nameUnits, unit)
+		} else {
+			r.next(2 * (127 - i))
+			break
+		}
+	}
+	name := string(utf16.Decode(nameUnits))
+	typeStringsOffset, _, keyStringsOffset := int(r.u32()), r.u32(), int(r.u32())
+	table.packages[fmt.Sprintf("0x%02x %s", id, name)] = true
+
+	var typeNames, keyNames []string
+	for _, chunk := range resChunks(r, c.start+c.headerSize, c.end) {
+		switch {
+		case chunk.typ == resStringPoolType && chunk.start == c.start+typeStringsOffset:
+			typeNames = resStringPool(r, chunk)
+		case chunk.typ == resStringPoolType && chunk.start == c.start+keyStringsOffset:
+			keyNames = resStringPool(r, chunk)
+		case chunk.typ == resTableTypeType:
+			r.seek(chunk.start + 8)
+			typeID, flags := int(r.u8()), r.u8()
+			r.next(2)
+			entryCount, entriesStart := int(r.u32()), int(r.u32())
+			if typeID < 1 || typeID > len(typeNames) {
+				r.err = errTruncated
+				return
+			}
+			typeName := name + ":" + typeNames[typeID-1]
+			table.configs[typeName]++
+
+			entryOffsets := make([]int, 0, entryCount)
+			r.seek(chunk.start + chunk.headerSize)
+			for i := 0; i < entryCount && r.err == nil; i++ {
+				switch {
+				case flags&resTableTypeFlagSparse != 0:
+					r.u16()
+					entryOffsets = append(entryOffsets, 4*int(r.u16()))
+				case flags&resTableTypeFlagOffset16 != 0:
+					if offset := r.u16(); offset != 0xffff {
+						entryOffsets = append(entryOffsets, 4*int(offset))
+					}
+				default:
+					if offset := r.u32(); offset != resTableNoEntry {
+						entryOffsets = append(entryOffsets, int(offset))
+					}
+				}
+			}
+			for _, offset := range entryOffsets {
+				r.seek(chunk.start + entriesStart + offset)
+				size, entryFlags, key := int(r.u16()), r.u16(), int(r.u32())
+				if entryFlags&resTableEntryFlagCompact != 0 {
+					// Compact entries store the key in place of the size.
+					key = size
+				}
+				if key < 0 || key >= len(keyNames) {
+					r.err = errTruncated
+					return
+				}
+				table.resources[typeName+"/"+keyNames[key]] = true
+			}
+		}
+	}
+}
+
+func diffResourceTables(a, b []byte) ([]string, error) {
+	tableA, err := parseResourceTable(a)
+	if err != nil {
+		return nil, err
+	}
+	tableB, err := parseResourceTable(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	diffs = append(diffs, diffValues("strings", tableA.strings, tableB.strings)...)
+	diffs = append(diffs, diffSets("package", tableA.packages, tableB.packages)...)
+	diffs = append(diffs, diffSets("resource", tableA.resources, tableB.resources)...)
+
+	typesA, typesB := make(map[string]bool), make(map[string]bool)
+	for typ := range tableA.configs {
+		typesA[typ] = true
+	}
+	for typ := range tableB.configs {
+		typesB[typ] = true
+	}
+	diffs = append(diffs, diffSets("type", typesA, typesB)...)
+	for _, typ := range sortedKeys(typesA) {
+		if typesB[typ] {
+			diffs = append(diffs, diffValues(typ+" configurations", tableA.configs[typ], tableB.configs[typ])...)
+		}
+	}
+
+	if len(diffs) == 0 {
+		diffs = append(diffs, "only the values of resources changed")
+	}
+	return diffs, nil
+}
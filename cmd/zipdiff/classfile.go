@@ -0,0 +1,241 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A classFile is the summary of a Java class file that is compared.
+type classFile struct {
+	version    string
+	access     uint16
+	name       string
+	super      string
+	interfaces []string
+
+	// fields and methods are indexed by their name and descriptor
+	fields  map[string]*classMember
+	methods map[string]*classMember
+
+	// attributes are the names of the attributes of the class
+	attributes []string
+}
+
+type classMember struct {
+	access uint16
+
+	// code is the contents of the Code attribute of a method
+	code []byte
+
+	// attributes are the names of the other attributes of the member
+	attributes []string
+}
+
+const classFileMagic = 0xcafebabe
+
+// Constant pool tags from the JVM specification.
+const (
+	constantUtf8               = 1
+	constantInteger            = 3
+	constantFloat              = 4
+	constantLong               = 5
+	constantDouble             = 6
+	constantClass              = 7
+	constantString             = 8
+	constantFieldref           = 9
+	constantMethodref          = 10
+	constantInterfaceMethodref = 11
+	constantNameAndType        = 12
+	constantMethodHandle       = 15
+	constantMethodType         = 16
+	constantDynamic            = 17
+	constantInvokeDynamic      = 18
+	constantModule             = 19
+	constantPackage            = 20
+)
+
+func parseClassFile(data []byte) (*classFile, error) {
+	r := newBinaryReader(data, binary.BigEndian)
+	if r.u32() != classFileMagic {
+		return nil, fmt.Errorf("not a class file")
+	}
+	minor, major := r.u16(), r.u16()
+
+	// Only the strings and class names in the constant pool are needed.
+	count := int(r.u16())
+	utf8 := make(map[uint16]string)
+	classes := make(map[uint16]uint16)
+	for i := 1; i < count && r.err == nil; i++ {
+		switch tag := r.u8(); tag {
+		case constantUtf8:
+			utf8[uint16(i)] = string(r.next(int(r.u16())))
+		case constantClass:
+			classes[uint16(i)] = r.u16()
+		case constantString, constantMethodType, constantModule, constantPackage:
+			r.next(2)
+		case constantMethodHandle:
+			r.next(3)
+		case constantInteger, constantFloat, constantFieldref, constantMethodref,
+			constantInterfaceMethodref, constantNameAndType, constantDynamic, constantInvokeDynamic:
+			r.next(4)
+		case constantLong, constantDouble:
+			// 8 byte constants take two entries in the constant pool.
+			r.next(8)
+			i++
+		default:
+			return nil, fmt.Errorf("unknown constant pool tag %d", tag)
+		}
+	}
+	className := func(index uint16) string {
+		return utf8[classes[index]]
+	}
+
+	c := &classFile{
+		version: fmt.Sprintf("%d.%d", major, minor),
+		access:  r.u16(),
+		fields:  make(map[string]*classMember),
+		methods: make(map[string]*classMember),
+	}
+	c.name = className(r.u16())
+	c.super = className(r.u16())
+	for n := int(r.u16()); n > 0 && r.err == nil; n-- {
+		c.interfaces = append(c.interfaces, className(r.u16()))
+	}
+
+	readAttributes := func(member *classMember) []string {
+		var names []string
+		for n := int(r.u16()); n > 0 && r.err == nil; n-- {
+			name := utf8[r.u16()]
+			contents := r.next(int(r.u32()))
+			if member != nil && name == "Code" {
+				member.code = contents
+			} else {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+	readMembers := func(members map[string]*classMember, separator string) {
+		for n := int(r.u16()); n > 0 && r.err == nil; n-- {
+			member := &classMember{access: r.u16()}
+			name, descriptor := utf8[r.u16()], utf8[r.u16()]
+			member.attributes = readAttributes(member)
+			members[name+separator+descriptor] = member
+		}
+	}
+	readMembers(c.fields, ":")
+	readMembers(c.methods, "")
+	c.attributes = readAttributes(nil)
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return c, nil
+}
+
+func diffClassFiles(a, b []byte) ([]string, error) {
+	classA, err := parseClassFile(a)
+	if err != nil {
+		return nil, err
+	}
+	classB, err := parseClassFile(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	diffs = append(diffs, diffValues("class version", classA.version, classB.version)...)
+	diffs = append(diffs, diffValues("class name", classA.name, classB.name)...)
+	diffs = append(diffs, diffValues("access flags", accessFlags(classA.access), accessFlags(classB.access))...)
+	diffs = append(diffs, diffValues("superclass", classA.super, classB.super)...)
+	diffs = append(diffs, diffValues("interfaces", classA.interfaces, classB.interfaces)...)
+	diffs = append(diffs, diffValues("class attributes", classA.attributes, classB.attributes)...)
+	diffs = append(diffs, diffMembers("field", classA.fields, classB.fields)...)
+	diffs = append(diffs, diffMembers("method", classA.methods, classB.methods)...)
+
+	if len(diffs) == 0 {
+		diffs = append(diffs, "only the constant pool or the contents of attributes changed")
+	}
+	return diffs, nil
+}
+
+func diffMembers(kind string, a, b map[string]*classMember) []string {
+	namesA, namesB := make(map[string]bool), make(map[string]bool)
+	for name := range a {
+		namesA[name] = true
+	}
+	for name := range b {
+		namesB[name] = true
+	}
+
+	diffs := diffSets(kind, namesA, namesB)
+	for _, name := range sortedKeys(namesA) {
+		memberA, memberB := a[name], b[name]
+		if memberB == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("%s %s ", kind, name)
+		diffs = append(diffs, diffValues(prefix+"access flags",
+			accessFlags(memberA.access), accessFlags(memberB.access))...)
+		diffs = append(diffs, diffValues(prefix+"attributes", memberA.attributes, memberB.attributes)...)
+		if !bytes.Equal(memberA.code, memberB.code) {
+			diffs = append(diffs, fmt.Sprintf("%scode changed (%d -> %d bytes)",
+				prefix, len(memberA.code), len(memberB.code)))
+		}
+	}
+	return diffs
+}
+
+var accessFlagNames = []struct {
+	flag uint16
+	name string
+}{
+	{0x0001, "public"},
+	{0x0002, "private"},
+	{0x0004, "protected"},
+	{0x0008, "static"},
+	{0x0010, "final"},
+	{0x0020, "synchronized"},
+	{0x0040, "volatile"},
+	{0x0080, "transient"},
+	{0x0100, "native"},
+	{0x0200, "interface"},
+	{0x0400, "abstract"},
+	{0x0800, "strict"},
+	{0x1000, "synthetic"},
+	{0x2000, "annotation"},
+	{0x4000, "enum"},
+}
+
+// accessFlags returns the names of the access flags that are set. Some flags have different
+// meanings for classes, fields and methods, only one of them is used.
+func accessFlags(flags uint16) string {
+	var names []string
+	for _, f := range accessFlagNames {
+		if flags&f.flag != 0 {
+			names = append(names, f.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, " ")
+}
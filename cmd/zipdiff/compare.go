@@ -0,0 +1,331 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"android/soong/cmd/diff_target_files/allowlist"
+	"android/soong/jar"
+	"android/soong/third_party/zip"
+)
+
+// An archive is a zip file whose entries are indexed by name.
+type archive struct {
+	files map[string]*zip.File
+	order []string
+
+	// aligned is true if the archive has uncompressed entries and the data of all of them is
+	// aligned to at least 4 bytes, as if the archive had been zipaligned.
+	aligned bool
+}
+
+func newArchive(r *zip.Reader) *archive {
+	a := &archive{files: make(map[string]*zip.File)}
+	unaligned := false
+	for _, f := range r.File {
+		if _, exists := a.files[f.Name]; exists {
+			// Only the first entry with a name is visible to most readers.
+			continue
+		}
+		a.files[f.Name] = f
+		a.order = append(a.order, f.Name)
+		if f.Method == zip.Store && f.UncompressedSize64 > 0 {
+			a.aligned = true
+			if alignment, err := dataAlignment(f); err != nil || alignment < 4 {
+				unaligned = true
+			}
+		}
+	}
+	a.aligned = a.aligned && !unaligned
+	return a
+}
+
+// An entryDiff describes how an entry that is in both archives differs between them.
+type entryDiff struct {
+	Name string
+
+	// Metadata lists the differences in the zip metadata of the entry, like its CRC or compression
+	// method.
+	Metadata []string
+
+	// Contents summarizes the differences in the contents of entries in known formats.
+	Contents []string
+}
+
+// An archiveDiff describes the differences between two archives.
+type archiveDiff struct {
+	OnlyInA []string
+	OnlyInB []string
+	Changed []entryDiff
+
+	// Order lists the differences in the order of the entries.
+	Order []string
+}
+
+func (d archiveDiff) empty() bool {
+	return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0 && len(d.Changed) == 0 && len(d.Order) == 0
+}
+
+type compareOptions struct {
+	allowLists []allowlist.AllowList
+
+	// semantic enables the summaries of the differences in the contents of known formats.
+	semantic bool
+}
+
+// compareArchives compares the entries of a and b, in the order that they would have in a jar.
+func compareArchives(a, b *archive, opts compareOptions) (archiveDiff, error) {
+	var diff archiveDiff
+
+	names := make(map[string]bool)
+	for name := range a.files {
+		names[name] = true
+	}
+	for name := range b.files {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return jar.EntryNamesLess(sorted[i], sorted[j])
+	})
+
+	for _, name := range sorted {
+		fa, fb := a.files[name], b.files[name]
+
+		allowed, ignoreMatchingLines, err := allowListFor(opts.allowLists, name)
+		if err != nil {
+			return diff, err
+		}
+		if allowed && len(ignoreMatchingLines) == 0 {
+			continue
+		}
+
+		switch {
+		case fb == nil:
+			if !allowed {
+				diff.OnlyInA = append(diff.OnlyInA, name)
+			}
+		case fa == nil:
+			if !allowed {
+				diff.OnlyInB = append(diff.OnlyInB, name)
+			}
+		default:
+			if allowed {
+				if match, err := sameIgnoringMatchingLines(fa, fb, ignoreMatchingLines); err != nil {
+					return diff, err
+				} else if match {
+					continue
+				}
+			}
+			// The alignment of entries in archives that weren't aligned is incidental.
+			checkAlignment := a.aligned || b.aligned
+			entry, err := compareEntries(fa, fb, checkAlignment, opts.semantic)
+			if err != nil {
+				return diff, fmt.Errorf("comparing %s: %w", name, err)
+			}
+			if len(entry.Metadata) > 0 || len(entry.Contents) > 0 {
+				diff.Changed = append(diff.Changed, entry)
+			}
+		}
+	}
+
+	diff.Order = compareOrder(a.order, b.order)
+
+	return diff, nil
+}
+
+// allowListFor returns whether name is allowed to differ by the first allowlist that matches it,
+// and the regular expressions of the lines that are allowed to differ.
+func allowListFor(allowLists []allowlist.AllowList, name string) (bool, []string, error) {
+	for _, w := range allowLists {
+		if match, err := allowlist.Match(w.Path, name); err != nil {
+			return false, nil, err
+		} else if match {
+			return true, w.IgnoreMatchingLines, nil
+		}
+	}
+	return false, nil, nil
+}
+
+func sameIgnoringMatchingLines(a, b *zip.File, ignoreMatchingLines []string) (bool, error) {
+	rA, err := a.Open()
+	if err != nil {
+		return false, err
+	}
+	defer rA.Close()
+	rB, err := b.Open()
+	if err != nil {
+		return false, err
+	}
+	defer rB.Close()
+
+	return allowlist.DiffIgnoringMatchingLines(rA, rB, ignoreMatchingLines)
+}
+
+// compareEntries compares the metadata of a and b, and if their contents differ and are in a
+// known format, summarizes the differences in their contents.
+func compareEntries(a, b *zip.File, checkAlignment, semantic bool) (entryDiff, error) {
+	diff := entryDiff{Name: a.Name}
+	changed := func(format string, args ...interface{}) {
+		diff.Metadata = append(diff.Metadata, fmt.Sprintf(format, args...))
+	}
+
+	sameContents := a.CRC32 == b.CRC32 && a.UncompressedSize64 == b.UncompressedSize64
+	if a.CRC32 != b.CRC32 {
+		changed("crc32: %08x -> %08x", a.CRC32, b.CRC32)
+	}
+	if a.UncompressedSize64 != b.UncompressedSize64 {
+		changed("size: %d -> %d", a.UncompressedSize64, b.UncompressedSize64)
+	}
+	if a.Method != b.Method {
+		changed("compression: %s -> %s", methodName(a.Method), methodName(b.Method))
+	} else if sameContents && a.CompressedSize64 != b.CompressedSize64 {
+		changed("compressed size: %d -> %d", a.CompressedSize64, b.CompressedSize64)
+	}
+	if timeA, timeB := a.ModTime(), b.ModTime(); !timeA.Equal(timeB) {
+		changed("modification time: %s -> %s", timeA.Format(timeFormat), timeB.Format(timeFormat))
+	}
+	if a.Mode() != b.Mode() {
+		changed("mode: %s -> %s", a.Mode(), b.Mode())
+	}
+	if checkAlignment && a.Method == zip.Store && b.Method == zip.Store &&
+		a.UncompressedSize64 > 0 && b.UncompressedSize64 > 0 {
+		alignmentA, err := dataAlignment(a)
+		if err != nil {
+			return diff, err
+		}
+		alignmentB, err := dataAlignment(b)
+		if err != nil {
+			return diff, err
+		}
+		if alignmentA != alignmentB {
+			changed("data alignment: %d -> %d", alignmentA, alignmentB)
+		}
+	}
+
+	if semantic && !sameContents {
+		if differ := formatDifferFor(a.Name); differ != nil {
+			contentsA, err := readEntry(a)
+			if err != nil {
+				return diff, err
+			}
+			contentsB, err := readEntry(b)
+			if err != nil {
+				return diff, err
+			}
+			diff.Contents, err = differ.diff(contentsA, contentsB)
+			if err != nil {
+				// The entry may not be in the format its name suggests, the metadata differences
+				// are still useful.
+				diff.Contents = []string{fmt.Sprintf("failed to compare as %s: %s", differ.name, err)}
+			}
+		}
+	}
+
+	return diff, nil
+}
+
+const timeFormat = "2006-01-02 15:04:05"
+
+func methodName(method uint16) string {
+	switch method {
+	case zip.Store:
+		return "store"
+	case zip.Deflate:
+		return "deflate"
+	default:
+		return fmt.Sprintf("method %d", method)
+	}
+}
+
+// interestingAlignments are the alignments that are requested for uncompressed entries, from the
+// largest to the smallest: pages for native libraries and 4 bytes for everything else.
+var interestingAlignments = []int64{16384, 4096, 4, 1}
+
+// dataAlignment returns the largest interesting alignment of the data of f. Smaller differences
+// in the offsets of entries are incidental.
+func dataAlignment(f *zip.File) (int64, error) {
+	offset, err := f.DataOffset()
+	if err != nil {
+		return 0, err
+	}
+	for _, alignment := range interestingAlignments {
+		if offset%alignment == 0 {
+			return alignment, nil
+		}
+	}
+	return 1, nil
+}
+
+func readEntry(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// compareOrder returns the differences between the order of the entries of two archives, ignoring
+// the entries that are only in one of them, and whether each of them follows the order of a jar.
+func compareOrder(a, b []string) []string {
+	var diffs []string
+
+	inB := make(map[string]bool)
+	for _, name := range b {
+		inB[name] = true
+	}
+	inA := make(map[string]bool)
+	var commonA, commonB []string
+	for _, name := range a {
+		inA[name] = true
+		if inB[name] {
+			commonA = append(commonA, name)
+		}
+	}
+	for _, name := range b {
+		if inA[name] {
+			commonB = append(commonB, name)
+		}
+	}
+	for i := range commonA {
+		if commonA[i] != commonB[i] {
+			diffs = append(diffs, fmt.Sprintf("entries are in a different order from entry %d: %s -> %s",
+				i, commonA[i], commonB[i]))
+			break
+		}
+	}
+
+	jarOrderA, jarOrderB := isJarOrder(a), isJarOrder(b)
+	if jarOrderA && !jarOrderB {
+		diffs = append(diffs, "entries are no longer in jar order")
+	} else if !jarOrderA && jarOrderB {
+		diffs = append(diffs, "entries are now in jar order")
+	}
+
+	return diffs
+}
+
+func isJarOrder(names []string) bool {
+	return sort.SliceIsSorted(names, func(i, j int) bool {
+		return jar.EntryNamesLess(names[i], names[j])
+	})
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"android/soong/cmd/diff_target_files/allowlist"
+	"android/soong/jar"
+	"android/soong/third_party/zip"
+)
+
+type testEntry struct {
+	name     string
+	contents string
+	method   uint16
+	mode     uint32
+	time     time.Time
+}
+
+// testArchive returns an archive containing entries, with the data of the uncompressed entries
+// aligned according to alignment.
+func testArchive(t *testing.T, entries []testEntry, alignment map[string]int) *archive {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	w.SetAlignment(func(fh *zip.FileHeader) int {
+		return alignment[fh.Name]
+	})
+	for _, e := range entries {
+		fh := &zip.FileHeader{Name: e.name, Method: e.method}
+		if e.mode == 0 {
+			fh.SetMode(0644)
+		} else {
+			fh.SetMode(0755)
+		}
+		if e.time.IsZero() {
+			fh.SetModTime(jar.DefaultTime)
+		} else {
+			fh.SetModTime(e.time)
+		}
+		f, err := w.CreateHeaderAndroid(fh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(e.contents))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newArchive(r)
+}
+
+func TestCompareArchives(t *testing.T) {
+	testCases := []struct {
+		name       string
+		a, b       []testEntry
+		alignA     map[string]int
+		allowLists []allowlist.AllowList
+		want       archiveDiff
+	}{
+		{
+			name: "same",
+			a:    []testEntry{{name: "a", contents: "a"}, {name: "b", contents: "b"}},
+			b:    []testEntry{{name: "a", contents: "a"}, {name: "b", contents: "b"}},
+		},
+		{
+			name: "added and removed",
+			a:    []testEntry{{name: "a", contents: "a"}, {name: "b", contents: "b"}},
+			b:    []testEntry{{name: "a", contents: "a"}, {name: "c", contents: "c"}},
+			want: archiveDiff{OnlyInA: []string{"b"}, OnlyInB: []string{"c"}},
+		},
+		{
+			name: "metadata",
+			a: []testEntry{
+				{name: "a", contents: "a"},
+				{name: "b", contents: "b"},
+				{name: "c", contents: "c", method: zip.Deflate},
+			},
+			b: []testEntry{
+				{name: "a", contents: "aa"},
+				{name: "b", contents: "b", mode: 0755, time: time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{name: "c", contents: "c"},
+			},
+			want: archiveDiff{
+				Changed: []entryDiff{
+					{Name: "a", Metadata: []string{"crc32: e8b7be43 -> 078a19d7", "size: 1 -> 2"}},
+					{Name: "b", Metadata: []string{
+						"modification time: 2008-01-01 00:00:00 -> 2009-01-01 00:00:00",
+						"mode: -rw-r--r-- -> -rwxr-xr-x",
+					}},
+					{Name: "c", Metadata: []string{"compression: deflate -> store"}},
+				},
+			},
+		},
+		{
+			name:   "alignment",
+			a:      []testEntry{{name: "a", contents: "a"}, {name: "lib/libfoo.so", contents: "b"}},
+			b:      []testEntry{{name: "a", contents: "a"}, {name: "lib/libfoo.so", contents: "b"}},
+			alignA: map[string]int{"a": 4, "lib/libfoo.so": 4096},
+			want: archiveDiff{
+				Changed: []entryDiff{
+					{Name: "a", Metadata: []string{"data alignment: 4 -> 1"}},
+					{Name: "lib/libfoo.so", Metadata: []string{"data alignment: 4096 -> 1"}},
+				},
+			},
+		},
+		{
+			name: "order",
+			a:    []testEntry{{name: jar.ManifestFile}, {name: "a"}, {name: "b"}},
+			b:    []testEntry{{name: "b"}, {name: "a"}, {name: jar.ManifestFile}},
+			want: archiveDiff{
+				Order: []string{
+					"entries are in a different order from entry 0: META-INF/MANIFEST.MF -> b",
+					"entries are no longer in jar order",
+				},
+			},
+		},
+		{
+			name: "allowed",
+			a: []testEntry{
+				{name: "build.prop", contents: "ro.build.date=1\nro.product=foo\n"},
+				{name: "ignored/a", contents: "a"},
+				{name: "other.prop", contents: "ro.build.date=1\nro.product=foo\n"},
+			},
+			b: []testEntry{
+				{name: "build.prop", contents: "ro.build.date=2\nro.product=foo\n"},
+				{name: "ignored/b", contents: "b"},
+				{name: "other.prop", contents: "ro.build.date=2\nro.product=bar\n"},
+			},
+			allowLists: []allowlist.AllowList{
+				{Path: "ignored/*"},
+				{Path: "*.prop", IgnoreMatchingLines: []string{"ro.build.date=.*"}},
+			},
+			want: archiveDiff{
+				Changed: []entryDiff{
+					{Name: "other.prop", Metadata: []string{"crc32: c9850686 -> e08f8b43"}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, b := testArchive(t, tc.a, tc.alignA), testArchive(t, tc.b, nil)
+			got, err := compareArchives(a, b, compareOptions{allowLists: tc.allowLists, semantic: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want:\n%#v\ngot:\n%#v", tc.want, got)
+			}
+			if got.empty() != reflect.DeepEqual(tc.want, archiveDiff{}) {
+				t.Errorf("unexpected empty() = %v", got.empty())
+			}
+		})
+	}
+}
+
+func TestFormatReport(t *testing.T) {
+	diff := archiveDiff{
+		OnlyInA: []string{"a"},
+		OnlyInB: []string{"b"},
+		Changed: []entryDiff{
+			{
+				Name:     "classes.dex",
+				Metadata: []string{"size: 1 -> 2"},
+				Contents: []string{"added class LFoo;", "added class LBar;", "added class LBaz;"},
+			},
+		},
+		Order: []string{"entries are now in jar order"},
+	}
+
+	want := `Only in a.zip:
+  a
+Only in b.zip:
+  b
+Changed:
+  classes.dex
+    size: 1 -> 2
+    added class LFoo;
+    added class LBar;
+    ... and 1 more
+Order:
+  entries are now in jar order
+`
+	if got := formatReport(diff, "a.zip", "b.zip", 2); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// A dexFile is the summary of a dex file that is compared.
+type dexFile struct {
+	version string
+
+	// the number of items in each section of the dex file, in the order of dexSections
+	counts []int
+
+	classes map[string]*dexClass
+}
+
+type dexClass struct {
+	access     uint32
+	super      string
+	interfaces []string
+
+	// fields and methods that are defined by the class, indexed by their name and type
+	fields  map[string]uint32
+	methods map[string]*dexMethod
+}
+
+type dexMethod struct {
+	access uint32
+	insns  []byte
+}
+
+var dexSections = []string{"strings", "types", "protos", "fields", "methods", "classes"}
+
+const (
+	dexHeaderSize = 0x70
+	dexNoIndex    = 0xffffffff
+)
+
+func parseDexFile(data []byte) (*dexFile, error) {
+	if len(data) < dexHeaderSize || !bytes.HasPrefix(data, []byte("dex\n")) {
+		return nil, fmt.Errorf("not a dex file")
+	}
+	r := newBinaryReader(data, binary.LittleEndian)
+
+	dex := &dexFile{
+		version: strings.TrimRight(string(data[4:8]), "\x00"),
+		classes: make(map[string]*dexClass),
+	}
+
+	// The size and offset of each section follow each other in the header, starting with the
+	// string ids.
+	type section struct{ size, off int }
+	var sections []section
+	r.seek(0x38)
+	for range dexSections {
+		size, off := int(r.u32()), int(r.u32())
+		sections = append(sections, section{size, off})
+		dex.counts = append(dex.counts, size)
+	}
+	strs, types, protos, fields, methods, classDefs :=
+		sections[0], sections[1], sections[2], sections[3], sections[4], sections[5]
+
+	str := func(index uint32) string {
+		if index == dexNoIndex || int(index) >= strs.size {
+			return ""
+		}
+		dataOff := int(r.seek(strs.off + 4*int(index)).u32())
+		r.seek(dataOff).uleb128()
+		if r.err != nil {
+			return ""
+		}
+		end := bytes.IndexByte(data[r.pos:], 0)
+		if end < 0 {
+			r.err = errTruncated
+			return ""
+		}
+		return string(r.next(end))
+	}
+	typeName := func(index uint32) string {
+		if index == dexNoIndex || int(index) >= types.size {
+			return ""
+		}
+		return str(r.seek(types.off + 4*int(index)).u32())
+	}
+	typeList := func(off uint32) []string {
+		if off == 0 {
+			return nil
+		}
+		var list []string
+		size := int(r.seek(int(off)).u32())
+		for i := 0; i < size && r.err == nil; i++ {
+			list = append(list, typeName(uint32(r.seek(int(off)+4+2*i).u16())))
+		}
+		return list
+	}
+	fieldName := func(index uint32) string {
+		if int(index) >= fields.size {
+			r.err = errTruncated
+			return ""
+		}
+		r.seek(fields.off + 8*int(index) + 2)
+		fieldType, name := uint32(r.u16()), r.u32()
+		return str(name) + ":" + typeName(fieldType)
+	}
+	methodName := func(index uint32) string {
+		if int(index) >= methods.size {
+			r.err = errTruncated
+			return ""
+		}
+		r.seek(methods.off + 8*int(index) + 2)
+		proto, name := int(r.u16()), r.u32()
+		if proto >= protos.size {
+			r.err = errTruncated
+			return ""
+		}
+		r.seek(protos.off + 12*proto + 4)
+		returnType, params := r.u32(), r.u32()
+		return str(name) + "(" + strings.Join(typeList(params), "") + ")" + typeName(returnType)
+	}
+
+	for i := 0; i < classDefs.size && r.err == nil; i++ {
+		r.seek(classDefs.off + 32*i)
+		classIdx, access, superIdx, interfacesOff := r.u32(), r.u32(), r.u32(), r.u32()
+		r.next(8)
+		classDataOff := r.u32()
+
+		class := &dexClass{
+			access:  access,
+			fields:  make(map[string]uint32),
+			methods: make(map[string]*dexMethod),
+		}
+		name := typeName(classIdx)
+		class.super = typeName(superIdx)
+		class.interfaces = typeList(interfacesOff)
+		dex.classes[name] = class
+
+		if classDataOff == 0 {
+			continue
+		}
+		pos := int(classDataOff)
+		readULEB := func() uint32 {
+			v := r.seek(pos).uleb128()
+			pos = r.pos
+			return v
+		}
+		staticFields, instanceFields := readULEB(), readULEB()
+		directMethods, virtualMethods := readULEB(), readULEB()
+		for _, n := range []uint32{staticFields, instanceFields} {
+			var index uint32
+			for ; n > 0 && r.err == nil; n-- {
+				index += readULEB()
+				fieldAccess := readULEB()
+				class.fields[fieldName(index)] = fieldAccess
+			}
+		}
+		for _, n := range []uint32{directMethods, virtualMethods} {
+			var index uint32
+			for ; n > 0 && r.err == nil; n-- {
+				index += readULEB()
+				method := &dexMethod{access: readULEB()}
+				if codeOff := readULEB(); codeOff != 0 {
+					insnsSize := int(r.seek(int(codeOff) + 12).u32())
+					method.insns = r.next(2 * insnsSize)
+				}
+				class.methods[methodName(index)] = method
+			}
+		}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return dex, nil
+}
+
+func diffDexFiles(a, b []byte) ([]string, error) {
+	dexA, err := parseDexFile(a)
+	if err != nil {
+		return nil, err
+	}
+	dexB, err := parseDexFile(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	diffs = append(diffs, diffValues("dex version", dexA.version, dexB.version)...)
+	for i, section := range dexSections {
+		diffs = append(diffs, diffValues(section, dexA.counts[i], dexB.counts[i])...)
+	}
+
+	namesA, namesB := make(map[string]bool), make(map[string]bool)
+	for name := range dexA.classes {
+		namesA[name] = true
+	}
+	for name := range dexB.classes {
+		namesB[name] = true
+	}
+	diffs = append(diffs, diffSets("class", namesA, namesB)...)
+
+	// Instructions refer to strings, types and methods by their index, so they change whenever
+	// anything is added to or removed from the dex file. Only report the methods whose code
+	// changed size individually.
+	renumbered := 0
+	for _, name := range sortedKeys(namesA) {
+		classA, classB := dexA.classes[name], dexB.classes[name]
+		if classB == nil {
+			continue
+		}
+		prefix := "class " + name + " "
+		diffs = append(diffs, diffValues(prefix+"access flags", classA.access, classB.access)...)
+		diffs = append(diffs, diffValues(prefix+"superclass", classA.super, classB.super)...)
+		diffs = append(diffs, diffValues(prefix+"interfaces", classA.interfaces, classB.interfaces)...)
+
+		fieldsA, fieldsB := make(map[string]bool), make(map[string]bool)
+		for field := range classA.fields {
+			fieldsA[field] = true
+		}
+		for field := range classB.fields {
+			fieldsB[field] = true
+		}
+		for _, d := range diffSets("field", fieldsA, fieldsB) {
+			diffs = append(diffs, prefix+d)
+		}
+		for _, field := range sortedKeys(fieldsA) {
+			if fieldsB[field] {
+				diffs = append(diffs, diffValues(prefix+"field "+field+" access flags",
+					classA.fields[field], classB.fields[field])...)
+			}
+		}
+
+		methodsA, methodsB := make(map[string]bool), make(map[string]bool)
+		for method := range classA.methods {
+			methodsA[method] = true
+		}
+		for method := range classB.methods {
+			methodsB[method] = true
+		}
+		for _, d := range diffSets("method", methodsA, methodsB) {
+			diffs = append(diffs, prefix+d)
+		}
+		for _, method := range sortedKeys(methodsA) {
+			methodA, methodB := classA.methods[method], classB.methods[method]
+			if methodB == nil {
+				continue
+			}
+			diffs = append(diffs, diffValues(prefix+"method "+method+" access flags",
+				methodA.access, methodB.access)...)
+			if len(methodA.insns) != len(methodB.insns) {
+				diffs = append(diffs, fmt.Sprintf("%smethod %s code changed (%d -> %d code units)",
+					prefix, method, len(methodA.insns)/2, len(methodB.insns)/2))
+			} else if !bytes.Equal(methodA.insns, methodB.insns) {
+				renumbered++
+			}
+		}
+	}
+	if renumbered > 0 {
+		diffs = append(diffs, fmt.Sprintf("%d methods have different instructions of the same size", renumbered))
+	}
+
+	if len(diffs) == 0 {
+		diffs = append(diffs, "only the checksum, debug info or data layout changed")
+	}
+	return diffs, nil
+}
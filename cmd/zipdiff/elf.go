@@ -0,0 +1,153 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// An elfFile is the summary of a shared library that is compared.
+type elfFile struct {
+	machine string
+	soname  string
+	needed  []string
+	buildID string
+
+	// the sizes of the sections that are loaded at runtime, indexed by name
+	sections map[string]uint64
+
+	// the sizes of the dynamic symbols defined by the library, and the names of the symbols it
+	// imports
+	exported map[string]uint64
+	imported map[string]bool
+}
+
+func parseELFFile(data []byte) (*elfFile, error) {
+	f, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	e := &elfFile{
+		machine:  f.Machine.String(),
+		sections: make(map[string]uint64),
+		exported: make(map[string]uint64),
+		imported: make(map[string]bool),
+	}
+
+	if sonames, err := f.DynString(elf.DT_SONAME); err == nil && len(sonames) > 0 {
+		e.soname = sonames[0]
+	}
+	if e.needed, err = f.ImportedLibraries(); err != nil {
+		return nil, err
+	}
+
+	for _, s := range f.Sections {
+		if s.Flags&elf.SHF_ALLOC != 0 {
+			e.sections[s.Name] = s.Size
+		}
+		if s.Type == elf.SHT_NOTE && s.Name == ".note.gnu.build-id" {
+			if note, err := s.Data(); err == nil {
+				e.buildID = buildIDFromNote(note, f.ByteOrder)
+			}
+		}
+	}
+
+	symbols, err := f.DynamicSymbols()
+	if err != nil && err != elf.ErrNoSymbols {
+		return nil, err
+	}
+	for _, sym := range symbols {
+		bind := elf.ST_BIND(sym.Info)
+		if bind != elf.STB_GLOBAL && bind != elf.STB_WEAK {
+			continue
+		}
+		if sym.Section == elf.SHN_UNDEF {
+			e.imported[sym.Name] = true
+		} else {
+			e.exported[sym.Name] = sym.Size
+		}
+	}
+
+	return e, nil
+}
+
+// buildIDFromNote returns the build ID in the contents of a .note.gnu.build-id section.
+func buildIDFromNote(note []byte, order binary.ByteOrder) string {
+	if len(note) < 12 {
+		return ""
+	}
+	nameSize, descSize := int(order.Uint32(note[0:])), int(order.Uint32(note[4:]))
+	descStart := 12 + (nameSize+3)&^3
+	if descStart+descSize > len(note) {
+		return ""
+	}
+	return hex.EncodeToString(note[descStart : descStart+descSize])
+}
+
+func diffELFFiles(a, b []byte) ([]string, error) {
+	elfA, err := parseELFFile(a)
+	if err != nil {
+		return nil, err
+	}
+	elfB, err := parseELFFile(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	diffs = append(diffs, diffValues("machine", elfA.machine, elfB.machine)...)
+	diffs = append(diffs, diffValues("soname", elfA.soname, elfB.soname)...)
+	diffs = append(diffs, diffValues("needed libraries", elfA.needed, elfB.needed)...)
+	diffs = append(diffs, diffValues("build id", elfA.buildID, elfB.buildID)...)
+
+	sectionsA, sectionsB := make(map[string]bool), make(map[string]bool)
+	for name := range elfA.sections {
+		sectionsA[name] = true
+	}
+	for name := range elfB.sections {
+		sectionsB[name] = true
+	}
+	diffs = append(diffs, diffSets("section", sectionsA, sectionsB)...)
+	for _, name := range sortedKeys(sectionsA) {
+		if sectionsB[name] {
+			diffs = append(diffs, diffValues("section "+name+" size", elfA.sections[name], elfB.sections[name])...)
+		}
+	}
+
+	exportedA, exportedB := make(map[string]bool), make(map[string]bool)
+	for name := range elfA.exported {
+		exportedA[name] = true
+	}
+	for name := range elfB.exported {
+		exportedB[name] = true
+	}
+	diffs = append(diffs, diffSets("exported symbol", exportedA, exportedB)...)
+	for _, name := range sortedKeys(exportedA) {
+		if exportedB[name] {
+			diffs = append(diffs, diffValues("symbol "+name+" size", elfA.exported[name], elfB.exported[name])...)
+		}
+	}
+	diffs = append(diffs, diffSets("imported symbol", elfA.imported, elfB.imported)...)
+
+	if len(diffs) == 0 {
+		diffs = append(diffs, "only the contents of sections changed")
+	}
+	return diffs, nil
+}
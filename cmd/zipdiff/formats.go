@@ -0,0 +1,174 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"android/soong/jar"
+)
+
+// A formatDiffer summarizes the differences between the contents of two versions of a file in a
+// known format.
+type formatDiffer struct {
+	name  string
+	match func(name string) bool
+	diff  func(a, b []byte) ([]string, error)
+}
+
+var formatDiffers = []formatDiffer{
+	{
+		name:  "class file",
+		match: func(name string) bool { return strings.HasSuffix(name, ".class") },
+		diff:  diffClassFiles,
+	},
+	{
+		name:  "dex file",
+		match: func(name string) bool { return strings.HasSuffix(name, ".dex") },
+		diff:  diffDexFiles,
+	},
+	{
+		name:  "ELF file",
+		match: func(name string) bool { return strings.HasSuffix(name, ".so") },
+		diff:  diffELFFiles,
+	},
+	{
+		name:  "manifest",
+		match: func(name string) bool { return name == jar.ManifestFile },
+		diff:  diffManifests,
+	},
+	{
+		name:  "resource table",
+		match: func(name string) bool { return path.Base(name) == "resources.arsc" },
+		diff:  diffResourceTables,
+	},
+}
+
+func formatDifferFor(name string) *formatDiffer {
+	for i := range formatDiffers {
+		if formatDiffers[i].match(name) {
+			return &formatDiffers[i]
+		}
+	}
+	return nil
+}
+
+// diffSets returns a line for each string that is only in a or only in b, describing it as a
+// removed or added kind.
+func diffSets(kind string, a, b map[string]bool) []string {
+	var diffs []string
+	for _, s := range sortedKeys(a) {
+		if !b[s] {
+			diffs = append(diffs, fmt.Sprintf("removed %s %s", kind, s))
+		}
+	}
+	for _, s := range sortedKeys(b) {
+		if !a[s] {
+			diffs = append(diffs, fmt.Sprintf("added %s %s", kind, s))
+		}
+	}
+	return diffs
+}
+
+// diffValues returns a line describing the change of a property from a to b, if they differ.
+func diffValues(property string, a, b interface{}) []string {
+	sa, sb := fmt.Sprint(a), fmt.Sprint(b)
+	if sa == sb {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: %s -> %s", property, sa, sb)}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var errTruncated = errors.New("truncated or corrupt file")
+
+// A binaryReader reads values from data, either sequentially or at given offsets. Reads past the
+// end of data return zero values and set err, so that parsers only need to check err once they
+// are done.
+type binaryReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+	err   error
+}
+
+func newBinaryReader(data []byte, order binary.ByteOrder) *binaryReader {
+	return &binaryReader{data: data, order: order}
+}
+
+func (r *binaryReader) seek(pos int) *binaryReader {
+	r.pos = pos
+	return r
+}
+
+func (r *binaryReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.pos < 0 || r.pos > len(r.data) || n > len(r.data)-r.pos {
+		r.err = errTruncated
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *binaryReader) u8() uint8 {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *binaryReader) u16() uint16 {
+	if b := r.next(2); b != nil {
+		return r.order.Uint16(b)
+	}
+	return 0
+}
+
+func (r *binaryReader) u32() uint32 {
+	if b := r.next(4); b != nil {
+		return r.order.Uint32(b)
+	}
+	return 0
+}
+
+// uleb128 reads an unsigned LEB128 value, as used by dex files.
+func (r *binaryReader) uleb128() uint32 {
+	var result uint32
+	for shift := uint(0); shift < 35; shift += 7 {
+		b := r.u8()
+		result |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return result
+}
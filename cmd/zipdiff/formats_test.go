@@ -0,0 +1,208 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+// testClass describes a class file with the constant pool used by classFileBytes.
+type testClass struct {
+	major   uint16
+	fields  bool
+	methods map[string][]byte
+}
+
+// classFileBytes returns a class Foo with an optional field x:I and methods with the given names,
+// descriptor ()V and code.
+func classFileBytes(c testClass) []byte {
+	buf := &bytes.Buffer{}
+	w := func(v interface{}) { binary.Write(buf, binary.BigEndian, v) }
+	utf8 := func(s string) {
+		w(uint8(constantUtf8))
+		w(uint16(len(s)))
+		buf.WriteString(s)
+	}
+
+	w(uint32(classFileMagic))
+	w(uint16(0))
+	w(c.major)
+
+	// The constant pool, with a long to check that it takes two entries.
+	w(uint16(14))
+	utf8("Foo")              // 1
+	w(uint8(constantClass))  // 2
+	w(uint16(1))             //
+	utf8("java/lang/Object") // 3
+	w(uint8(constantClass))  // 4
+	w(uint16(3))             //
+	utf8("()V")              // 5
+	utf8("Code")             // 6
+	w(uint8(constantLong))   // 7 and 8
+	w(uint64(1))             //
+	utf8("x")                // 9
+	utf8("I")                // 10
+	utf8("bar")              // 11
+	utf8("baz")              // 12
+	utf8("SourceFile")       // 13
+	nameIndex := map[string]uint16{"bar": 11, "baz": 12}
+
+	w(uint16(0x21))
+	w(uint16(2))
+	w(uint16(4))
+	w(uint16(0))
+
+	if c.fields {
+		w(uint16(1))
+		w(uint16(0x2))
+		w(uint16(9))
+		w(uint16(10))
+		w(uint16(0))
+	} else {
+		w(uint16(0))
+	}
+
+	w(uint16(len(c.methods)))
+	for _, name := range []string{"bar", "baz"} {
+		code, ok := c.methods[name]
+		if !ok {
+			continue
+		}
+		w(uint16(0x1))
+		w(nameIndex[name])
+		w(uint16(5))
+		w(uint16(1))
+		w(uint16(6))
+		w(uint32(len(code)))
+		buf.Write(code)
+	}
+
+	w(uint16(1))
+	w(uint16(13))
+	w(uint32(2))
+	w(uint16(1))
+
+	return buf.Bytes()
+}
+
+func TestDiffClassFiles(t *testing.T) {
+	a := classFileBytes(testClass{
+		major:   52,
+		fields:  true,
+		methods: map[string][]byte{"bar": {1, 2, 3}, "baz": {4}},
+	})
+	b := classFileBytes(testClass{
+		major:   55,
+		methods: map[string][]byte{"bar": {1, 2}},
+	})
+
+	diffs, err := diffClassFiles(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"class version: 52.0 -> 55.0",
+		"removed field x:I",
+		"removed method baz()V",
+		"method bar()V code changed (3 -> 2 bytes)",
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("want:\n%q\ngot:\n%q", want, diffs)
+	}
+
+	c, err := parseClassFile(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.name != "Foo" || c.super != "java/lang/Object" || !reflect.DeepEqual(c.attributes, []string{"SourceFile"}) {
+		t.Errorf("unexpected class %q extends %q with attributes %q", c.name, c.super, c.attributes)
+	}
+
+	if _, err := diffClassFiles(a[:len(a)-3], b); err != errTruncated {
+		t.Errorf("expected truncated error, got %v", err)
+	}
+}
+
+func TestDiffManifests(t *testing.T) {
+	a := []byte("Manifest-Version: 1.0\r\nCreated-By: soong_zip\r\nClass-Path: a.jar b\r\n .jar\r\n\r\n" +
+		"Name: foo/Bar.class\r\nSHA-256-Digest: abc\r\n\r\n" +
+		"Name: foo/Baz.class\r\nSHA-256-Digest: def\r\n\r\n")
+	b := []byte("Manifest-Version: 1.0\nClass-Path: a.jar b.jar c.jar\nMain-Class: foo.Main\n\n" +
+		"Name: foo/Bar.class\nSHA-256-Digest: abd\n\n" +
+		"Name: foo/Qux.class\nSHA-256-Digest: ghi\n\n")
+
+	diffs, err := diffManifests(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"main attribute Class-Path: a.jar b.jar -> a.jar b.jar c.jar",
+		"removed main attribute Created-By: soong_zip",
+		"added main attribute Main-Class: foo.Main",
+		"entry foo/Bar.class attribute SHA-256-Digest: abc -> abd",
+		"removed entry foo/Baz.class",
+		"added entry foo/Qux.class",
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("want:\n%q\ngot:\n%q", want, diffs)
+	}
+
+	if _, err := manifestSections([]byte(" continuation\n")); err == nil {
+		t.Errorf("expected error for leading continuation line")
+	}
+}
+
+func TestFormatDifferFor(t *testing.T) {
+	testCases := map[string]string{
+		"foo/Bar.class":             "class file",
+		"classes2.dex":              "dex file",
+		"lib/arm64-v8a/libfoo.so":   "ELF file",
+		"META-INF/MANIFEST.MF":      "manifest",
+		"resources.arsc":            "resource table",
+		"assets/foo/resources.arsc": "resource table",
+		"res/raw/foo.txt":           "",
+	}
+	for name, want := range testCases {
+		got := ""
+		if differ := formatDifferFor(name); differ != nil {
+			got = differ.name
+		}
+		if got != want {
+			t.Errorf("formatDifferFor(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestBinaryReader(t *testing.T) {
+	r := newBinaryReader([]byte{0x01, 0x02, 0x83, 0x01, 0xff}, binary.LittleEndian)
+	if got := r.u16(); got != 0x0201 {
+		t.Errorf("u16() = %#x, want 0x0201", got)
+	}
+	if got := r.uleb128(); got != 0x83&0x7f|1<<7 {
+		t.Errorf("uleb128() = %#x", got)
+	}
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if got := r.u32(); got != 0 || r.err != errTruncated {
+		t.Errorf("u32() past the end = %#x, %v, want 0, %v", got, r.err, errTruncated)
+	}
+	if got := r.seek(0).u8(); got != 0 {
+		t.Errorf("reads after an error should return 0, got %#x", got)
+	}
+}
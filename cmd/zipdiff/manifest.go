@@ -0,0 +1,133 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// manifestSections returns the attributes of each section of a jar manifest, indexed by the name
+// of the section. The main section has an empty name.
+func manifestSections(data []byte) (map[string]map[string]string, error) {
+	sections := make(map[string]map[string]string)
+	var attributes map[string]string
+	var lastAttribute string
+
+	endSection := func() error {
+		if attributes == nil {
+			return nil
+		}
+		name := ""
+		if len(sections) > 0 {
+			name = attributes["Name"]
+			if name == "" {
+				return fmt.Errorf("manifest section without a Name attribute")
+			}
+			delete(attributes, "Name")
+		}
+		sections[name] = attributes
+		attributes = nil
+		return nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for _, line := range lines {
+		switch {
+		case line == "":
+			if err := endSection(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, " "):
+			// A continuation of the value of the previous attribute.
+			if attributes == nil || lastAttribute == "" {
+				return nil, fmt.Errorf("unexpected continuation line %q", line)
+			}
+			attributes[lastAttribute] += line[1:]
+		default:
+			i := strings.Index(line, ": ")
+			if i < 0 {
+				return nil, fmt.Errorf("invalid manifest line %q", line)
+			}
+			if attributes == nil {
+				attributes = make(map[string]string)
+			}
+			lastAttribute = line[:i]
+			attributes[lastAttribute] = line[i+2:]
+		}
+	}
+	if err := endSection(); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+func diffManifests(a, b []byte) ([]string, error) {
+	sectionsA, err := manifestSections(a)
+	if err != nil {
+		return nil, err
+	}
+	sectionsB, err := manifestSections(b)
+	if err != nil {
+		return nil, err
+	}
+
+	namesA, namesB := make(map[string]bool), make(map[string]bool)
+	for name := range sectionsA {
+		namesA[name] = true
+	}
+	for name := range sectionsB {
+		namesB[name] = true
+	}
+
+	var diffs []string
+	for _, name := range sortedKeys(namesA) {
+		if !namesB[name] {
+			continue
+		}
+		prefix := "main attribute "
+		if name != "" {
+			prefix = "entry " + name + " attribute "
+		}
+
+		attrsA, attrsB := make(map[string]bool), make(map[string]bool)
+		for attr := range sectionsA[name] {
+			attrsA[attr] = true
+		}
+		for attr := range sectionsB[name] {
+			attrsB[attr] = true
+		}
+		for _, attr := range sortedKeys(attrsA) {
+			if !attrsB[attr] {
+				diffs = append(diffs, fmt.Sprintf("removed %s%s: %s", prefix, attr, sectionsA[name][attr]))
+			} else {
+				diffs = append(diffs, diffValues(prefix+attr, sectionsA[name][attr], sectionsB[name][attr])...)
+			}
+		}
+		for _, attr := range sortedKeys(attrsB) {
+			if !attrsA[attr] {
+				diffs = append(diffs, fmt.Sprintf("added %s%s: %s", prefix, attr, sectionsB[name][attr]))
+			}
+		}
+	}
+	delete(namesA, "")
+	delete(namesB, "")
+	diffs = append(diffs, diffSets("entry", namesA, namesB)...)
+
+	if len(diffs) == 0 {
+		diffs = append(diffs, "only the order or line wrapping of attributes changed")
+	}
+	return diffs, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// zipdiff compares two zip files entry by entry, and summarizes the differences in the contents
+// of the entries in known formats like class files, dex files, shared libraries, jar manifests and
+// resource tables.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"android/soong/cmd/diff_target_files/allowlist"
+	"android/soong/third_party/zip"
+)
+
+var (
+	entriesOnly = flag.Bool("entries_only", false, "only compare the metadata of entries, without summarizing the differences in their contents")
+	maxLines    = flag.Int("max_lines", 20, "maximum number of differences to print for the contents of each entry, 0 for no limit")
+
+	allowLists     = newMultiString("allowlist", "allowlist patterns in the form <pattern>[:<regex of line to ignore>]")
+	allowListFiles = newMultiString("allowlist_file", "files containing allowlist definitions")
+)
+
+func newMultiString(name, usage string) *multiString {
+	var f multiString
+	flag.Var(&f, name, usage)
+	return &f
+}
+
+type multiString []string
+
+func (ms *multiString) String() string     { return strings.Join(*ms, ", ") }
+func (ms *multiString) Set(s string) error { *ms = append(*ms, s); return nil }
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: zipdiff [flags] a.zip b.zip\n\n")
+	fmt.Fprintf(os.Stderr, "Compares two zip files entry by entry. Exits with status 1 if they differ.\n\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	different, err := run(os.Stdout, flag.Arg(0), flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(2)
+	}
+	if different {
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, fileA, fileB string) (bool, error) {
+	allowLists, err := allowlist.Parse(*allowLists, *allowListFiles)
+	if err != nil {
+		return false, fmt.Errorf("parsing allowlists: %w", err)
+	}
+
+	zipA, err := zip.OpenReader(fileA)
+	if err != nil {
+		return false, err
+	}
+	defer zipA.Close()
+	zipB, err := zip.OpenReader(fileB)
+	if err != nil {
+		return false, err
+	}
+	defer zipB.Close()
+
+	diff, err := compareArchives(newArchive(&zipA.Reader), newArchive(&zipB.Reader), compareOptions{
+		allowLists: allowLists,
+		semantic:   !*entriesOnly,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Fprint(w, formatReport(diff, fileA, fileB, *maxLines))
+	return !diff.empty(), nil
+}
+
+// formatReport returns a human readable description of diff, printing at most maxLines
+// differences in the contents of each entry.
+func formatReport(diff archiveDiff, nameA, nameB string, maxLines int) string {
+	sb := &strings.Builder{}
+
+	list := func(title string, names []string) {
+		if len(names) > 0 {
+			fmt.Fprintf(sb, "%s\n", title)
+			for _, name := range names {
+				fmt.Fprintf(sb, "  %s\n", name)
+			}
+		}
+	}
+	list("Only in "+nameA+":", diff.OnlyInA)
+	list("Only in "+nameB+":", diff.OnlyInB)
+
+	if len(diff.Changed) > 0 {
+		fmt.Fprintf(sb, "Changed:\n")
+		for _, entry := range diff.Changed {
+			fmt.Fprintf(sb, "  %s\n", entry.Name)
+			for _, line := range entry.Metadata {
+				fmt.Fprintf(sb, "    %s\n", line)
+			}
+			for i, line := range entry.Contents {
+				if maxLines > 0 && i == maxLines {
+					fmt.Fprintf(sb, "    ... and %d more\n", len(entry.Contents)-maxLines)
+					break
+				}
+				fmt.Fprintf(sb, "    %s\n", line)
+			}
+		}
+	}
+
+	list("Order:", diff.Order)
+
+	return sb.String()
+}
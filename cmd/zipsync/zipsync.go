@@ -18,17 +18,24 @@ import (
 	"archive/zip"
 	"flag"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+
+	"github.com/google/blueprint/pathtools"
+
+	"android/soong/makedeps"
 )
 
 var (
 	outputDir  = flag.String("d", "", "output dir")
 	outputFile = flag.String("l", "", "output list file")
+	depFile    = flag.String("depfile", "", "optional depfile listing the input zips as dependencies of the list file")
 	filter     = flag.String("f", "", "optional filter pattern")
 	zipPrefix  = flag.String("zip-prefix", "", "optional prefix within the zip file to extract, stripping the prefix")
 )
@@ -63,9 +70,209 @@ func writeSymlink(filename string, in io.Reader) error {
 	return err
 }
 
+// An entry is a file in an input zip that is extracted to the output directory.
+type entry struct {
+	file  *zip.File
+	input string
+}
+
+func (e entry) isDir() bool {
+	return e.file.FileInfo().IsDir()
+}
+
+func (e entry) isSymlink() bool {
+	return e.file.Mode()&os.ModeSymlink != 0
+}
+
+// readEntries returns the entries of the input zips to extract, indexed by their path relative to
+// the output directory.
+func readEntries(readers []*zip.ReadCloser, inputs []string, filter, zipPrefix string) (map[string]entry, error) {
+	entries := make(map[string]entry)
+	for i, reader := range readers {
+		input := inputs[i]
+		for _, f := range reader.File {
+			name := f.Name
+			if zipPrefix != "" {
+				if !strings.HasPrefix(name, zipPrefix) {
+					continue
+				}
+				name = strings.TrimPrefix(name, zipPrefix)
+			}
+			if filter != "" {
+				if match, err := filepath.Match(filter, filepath.Base(name)); err != nil {
+					return nil, err
+				} else if !match {
+					continue
+				}
+			}
+			if filepath.IsAbs(name) {
+				return nil, fmt.Errorf("%q in %q is an absolute path", name, input)
+			}
+			name = filepath.Clean(name)
+			if name == "." {
+				continue
+			}
+			if name == ".." || strings.HasPrefix(name, "../") {
+				return nil, fmt.Errorf("%q in %q is outside the output directory", f.Name, input)
+			}
+
+			if prev, exists := entries[name]; exists {
+				return nil, fmt.Errorf("%q found in both %q and %q", name, prev.input, input)
+			}
+			entries[name] = entry{f, input}
+		}
+	}
+	return entries, nil
+}
+
+// removeStale removes the files and directories in outputDir that aren't extracted from any entry,
+// or whose type is different from the entry's, except for the files in keep, which are relative to
+// outputDir.
+func removeStale(outputDir string, entries map[string]entry, keep map[string]bool) error {
+	neededDirs := make(map[string]bool)
+	for name, e := range entries {
+		if e.isDir() {
+			neededDirs[name] = true
+		}
+		for dir := filepath.Dir(name); dir != "."; dir = filepath.Dir(dir) {
+			neededDirs[dir] = true
+		}
+	}
+
+	return filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(outputDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." || keep[rel] {
+			return nil
+		}
+		if info.IsDir() {
+			if neededDirs[rel] {
+				return nil
+			}
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+			return filepath.SkipDir
+		}
+		if e, ok := entries[rel]; !ok || e.isDir() || e.isSymlink() != (info.Mode()&os.ModeSymlink != 0) {
+			return os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// upToDate returns true if filename already has the contents and type of the entry, and the same
+// executable bit.
+func upToDate(filename string, e entry) (bool, error) {
+	info, err := os.Lstat(filename)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if e.isSymlink() {
+		if info.Mode()&os.ModeSymlink == 0 {
+			return false, nil
+		}
+		dest, err := os.Readlink(filename)
+		if err != nil {
+			return false, err
+		}
+		in, err := e.file.Open()
+		if err != nil {
+			return false, err
+		}
+		defer in.Close()
+		want, err := ioutil.ReadAll(in)
+		if err != nil {
+			return false, err
+		}
+		return dest == string(want), nil
+	}
+
+	if !info.Mode().IsRegular() || uint64(info.Size()) != e.file.UncompressedSize64 ||
+		info.Mode()&0100 != e.file.Mode()&0100 {
+		return false, nil
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	crc := crc32.NewIEEE()
+	if _, err := io.Copy(crc, f); err != nil {
+		return false, err
+	}
+	return crc.Sum32() == e.file.CRC32, nil
+}
+
+// extract writes the entry to filename, replacing any existing file.
+func extract(filename string, e entry) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+		return err
+	}
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	in, err := e.file.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if e.isSymlink() {
+		return writeSymlink(filename, in)
+	}
+	return writeFile(filename, in, e.file.Mode())
+}
+
+// syncDir brings outputDir up to date with the entries, only rewriting the files whose contents
+// differ, and returns the sorted list of extracted files. Files in outputDir that aren't extracted
+// from any entry are removed, except for the files in keep, which are relative to outputDir.
+func syncDir(outputDir string, entries map[string]entry, keep map[string]bool) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0777); err != nil {
+		return nil, err
+	}
+	if err := removeStale(outputDir, entries, keep); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var files []string
+	for _, name := range names {
+		e := entries[name]
+		filename := filepath.Join(outputDir, name)
+		if e.isDir() {
+			if err := os.MkdirAll(filename, 0777); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if ok, err := upToDate(filename, e); err != nil {
+			return nil, err
+		} else if !ok {
+			if err := extract(filename, e); err != nil {
+				return nil, fmt.Errorf("extracting %q from %q: %w", e.file.Name, e.input, err)
+			}
+		}
+		files = append(files, filename)
+	}
+	return files, nil
+}
+
 func main() {
 	flag.Usage = func() {
-		fmt.Fprintln(os.Stderr, "usage: zipsync -d <output dir> [-l <output file>] [-f <pattern>] [zip]...")
+		fmt.Fprintln(os.Stderr, "usage: zipsync -d <output dir> [-l <output file>] [-depfile <depfile>] [-f <pattern>] [zip]...")
 		flag.PrintDefaults()
 	}
 
@@ -78,76 +285,48 @@ func main() {
 
 	inputs := flag.Args()
 
-	// For now, just wipe the output directory and replace its contents with the zip files
-	// Eventually this could only modify the directory contents as necessary to bring it up
-	// to date with the zip files.
-	must(os.RemoveAll(*outputDir))
-
-	must(os.MkdirAll(*outputDir, 0777))
-
-	var files []string
-	seen := make(map[string]string)
-
 	if *zipPrefix != "" {
 		*zipPrefix = filepath.Clean(*zipPrefix) + "/"
 	}
 
+	var readers []*zip.ReadCloser
 	for _, input := range inputs {
 		reader, err := zip.OpenReader(input)
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer reader.Close()
+		readers = append(readers, reader)
+	}
 
-		for _, f := range reader.File {
-			name := f.Name
-			if *zipPrefix != "" {
-				if !strings.HasPrefix(name, *zipPrefix) {
-					continue
-				}
-				name = strings.TrimPrefix(name, *zipPrefix)
-			}
-			if *filter != "" {
-				if match, err := filepath.Match(*filter, filepath.Base(name)); err != nil {
-					log.Fatal(err)
-				} else if !match {
-					continue
-				}
-			}
-			if filepath.IsAbs(name) {
-				log.Fatalf("%q in %q is an absolute path", name, input)
-			}
+	entries, err := readEntries(readers, inputs, *filter, *zipPrefix)
+	must(err)
 
-			if prev, exists := seen[name]; exists {
-				log.Fatalf("%q found in both %q and %q", name, prev, input)
-			}
-			seen[name] = input
-
-			filename := filepath.Join(*outputDir, name)
-			if f.FileInfo().IsDir() {
-				must(os.MkdirAll(filename, 0777))
-			} else {
-				must(os.MkdirAll(filepath.Dir(filename), 0777))
-				in, err := f.Open()
-				if err != nil {
-					log.Fatal(err)
-				}
-				if f.FileInfo().Mode()&os.ModeSymlink != 0 {
-					must(writeSymlink(filename, in))
-				} else {
-					must(writeFile(filename, in, f.FileInfo().Mode()))
-				}
-				in.Close()
-				files = append(files, filename)
-			}
+	// Only rewrite the files that changed since the last run, so that large srcjars can be
+	// extracted incrementally. The list file and the depfile may be in the output directory.
+	keep := map[string]bool{}
+	for _, f := range []string{*outputFile, *depFile} {
+		if rel, err := filepath.Rel(*outputDir, f); f != "" && err == nil {
+			keep[rel] = true
 		}
 	}
+	files, err := syncDir(*outputDir, entries, keep)
+	must(err)
 
 	if *outputFile != "" {
 		data := strings.Join(files, "\n")
 		if len(files) > 0 {
 			data += "\n"
 		}
-		must(ioutil.WriteFile(*outputFile, []byte(data), 0666))
+		must(pathtools.WriteFileIfChanged(*outputFile, []byte(data), 0666))
+	}
+
+	if *depFile != "" {
+		target := *outputFile
+		if target == "" {
+			target = *outputDir
+		}
+		deps := makedeps.Deps{Output: target, Inputs: inputs}
+		must(pathtools.WriteFileIfChanged(*depFile, deps.Print(), 0666))
 	}
 }
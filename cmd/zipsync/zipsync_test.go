@@ -0,0 +1,205 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestZip writes a zip file with the given files and returns a reader for it. Names ending in
+// / are directories.
+func writeTestZip(t *testing.T, name string, files map[string]string) *zip.ReadCloser {
+	t.Helper()
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	for _, name := range names {
+		fh := &zip.FileHeader{Name: name}
+		if strings.HasSuffix(name, "/") {
+			fh.SetMode(os.ModeDir | 0755)
+		} else {
+			fh.SetMode(0644)
+		}
+		fw, err := w.CreateHeader(fh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(files[name]))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := zip.OpenReader(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+// readTree returns the contents of the files in dir by their relative paths.
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	tree := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		contents, err := ioutil.ReadFile(path)
+		tree[rel] = string(contents)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestSyncDir(t *testing.T) {
+	tmp := t.TempDir()
+	outputDir := filepath.Join(tmp, "out")
+
+	sync := func(inputs map[string]map[string]string, keep map[string]bool) []string {
+		t.Helper()
+		var readers []*zip.ReadCloser
+		var names []string
+		for name, files := range inputs {
+			name = filepath.Join(tmp, name)
+			readers = append(readers, writeTestZip(t, name, files))
+			names = append(names, name)
+		}
+		entries, err := readEntries(readers, names, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		files, err := syncDir(outputDir, entries, keep)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return files
+	}
+
+	files := sync(map[string]map[string]string{
+		"a.zip": {"a/A.java": "class A {}", "a/B.java": "class B {}", "empty/": ""},
+		"b.zip": {"b/C.java": "class C {}", "stale/D.java": "class D {}"},
+	}, nil)
+	want := []string{
+		filepath.Join(outputDir, "a/A.java"),
+		filepath.Join(outputDir, "a/B.java"),
+		filepath.Join(outputDir, "b/C.java"),
+		filepath.Join(outputDir, "stale/D.java"),
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("want files %q, got %q", want, files)
+	}
+
+	// Make the unchanged files look old to check that they aren't rewritten.
+	old := time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, name := range []string{"a/A.java", "b/C.java"} {
+		if err := os.Chtimes(filepath.Join(outputDir, name), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(outputDir, "list"), []byte("list"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(outputDir, "a/Extra.java"), nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	sync(map[string]map[string]string{
+		"a.zip": {"a/A.java": "class A {}", "a/B.java": "class B { int x; }"},
+		"b.zip": {"b/C.java": "class C {}", "b/E.java": "class E {}"},
+	}, map[string]bool{"list": true})
+
+	wantTree := map[string]string{
+		"a/A.java": "class A {}",
+		"a/B.java": "class B { int x; }",
+		"b/C.java": "class C {}",
+		"b/E.java": "class E {}",
+		"list":     "list",
+	}
+	if tree := readTree(t, outputDir); !reflect.DeepEqual(tree, wantTree) {
+		t.Errorf("want tree:\n%q\ngot:\n%q", wantTree, tree)
+	}
+	for _, dir := range []string{"empty", "stale"} {
+		if _, err := os.Stat(filepath.Join(outputDir, dir)); !os.IsNotExist(err) {
+			t.Errorf("expected stale directory %s to be removed, got %v", dir, err)
+		}
+	}
+	for _, name := range []string{"a/A.java", "b/C.java"} {
+		if info, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Fatal(err)
+		} else if !info.ModTime().Equal(old) {
+			t.Errorf("expected unchanged %s not to be rewritten", name)
+		}
+	}
+}
+
+func TestReadEntriesErrors(t *testing.T) {
+	tmp := t.TempDir()
+	a := writeTestZip(t, filepath.Join(tmp, "a.zip"), map[string]string{"a/A.java": ""})
+	b := writeTestZip(t, filepath.Join(tmp, "b.zip"), map[string]string{"a/A.java": ""})
+	escape := writeTestZip(t, filepath.Join(tmp, "escape.zip"), map[string]string{"a/../../A.java": ""})
+
+	testCases := []struct {
+		name    string
+		readers []*zip.ReadCloser
+		inputs  []string
+		err     string
+	}{
+		{
+			name:    "duplicate",
+			readers: []*zip.ReadCloser{a, b},
+			inputs:  []string{"a.zip", "b.zip"},
+			err:     `"a/A.java" found in both "a.zip" and "b.zip"`,
+		},
+		{
+			name:    "outside output dir",
+			readers: []*zip.ReadCloser{escape},
+			inputs:  []string{"escape.zip"},
+			err:     "outside the output directory",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readEntries(tc.readers, tc.inputs, "", "")
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("want error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -427,7 +427,7 @@ func WriteModuleConfig(ctx android.ModuleContext, config *ModuleConfig, path and
 func dex2oatModuleName(config android.Config) string {
 	// Default to the debug variant of dex2oat to help find bugs.
 	// Set USE_DEX2OAT_DEBUG to false for only building non-debug versions.
-	if config.Getenv("USE_DEX2OAT_DEBUG") == "false" {
+	if !config.EnvBool("USE_DEX2OAT_DEBUG") {
 		return "dex2oat"
 	} else {
 		return "dex2oatd"
@@ -0,0 +1,233 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// This file implements the on-disk format of the cache db.
+//
+// The db starts with two lines of text: the version string and the json encoded cacheConfig.
+// They are followed by a sequence of blocks, each of which can be parsed independently so that
+// the db can be loaded using multiple threads. A block is encoded as:
+//
+//	uvarint  length of the payload
+//	uint32   crc32 (IEEE) of the payload, little endian
+//	payload
+//
+// The sequence of blocks ends with a block of length 0, and nothing may follow it.
+//
+// A payload is a sequence of directories, sorted by path. Each directory is encoded as:
+//
+//	uvarint  length of the prefix that its path shares with the previous path in the block
+//	uvarint  length of the rest of its path, followed by the rest of the path
+//	uvarint  device number
+//	varint   modification time
+//	uvarint  inode number
+//	uvarint  number of files, followed by each file name as a uvarint length and the name
+
+var errCorruptCache = errors.New("corrupt cache block")
+
+// maxCacheBlockSize is a sanity limit on the length of a block, to avoid allocating huge buffers
+// when reading a corrupt db
+const maxCacheBlockSize = 1 << 30
+
+// serializeCacheEntry encodes the payload of a block containing dirInfos, which must be sorted
+// by path.
+func (f *Finder) serializeCacheEntry(dirInfos []dirFullInfo) ([]byte, error) {
+	var buf []byte
+	var scratch [binary.MaxVarintLen64]byte
+	putUvarint := func(v uint64) {
+		buf = append(buf, scratch[:binary.PutUvarint(scratch[:], v)]...)
+	}
+	putString := func(s string) {
+		putUvarint(uint64(len(s)))
+		buf = append(buf, s...)
+	}
+
+	prevPath := ""
+	for _, info := range dirInfos {
+		shared := 0
+		for shared < len(prevPath) && shared < len(info.Path) && prevPath[shared] == info.Path[shared] {
+			shared++
+		}
+		putUvarint(uint64(shared))
+		putString(info.Path[shared:])
+		prevPath = info.Path
+
+		putUvarint(info.Device)
+		buf = append(buf, scratch[:binary.PutVarint(scratch[:], info.ModTime)]...)
+		putUvarint(info.Inode)
+
+		putUvarint(uint64(len(info.FileNames)))
+		for _, name := range info.FileNames {
+			putString(name)
+		}
+	}
+	return buf, nil
+}
+
+// a cacheDecoder reads the values of a block payload, remembering the first error
+type cacheDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *cacheDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = errCorruptCache
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *cacheDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.err = errCorruptCache
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *cacheDecoder) bytes() []byte {
+	length := d.uvarint()
+	if d.err != nil {
+		return nil
+	}
+	if length > uint64(len(d.data)) {
+		d.err = errCorruptCache
+		return nil
+	}
+	b := d.data[:length]
+	d.data = d.data[length:]
+	return b
+}
+
+// parseCacheEntry decodes the payload of a block
+func (f *Finder) parseCacheEntry(bytes []byte) ([]dirFullInfo, error) {
+	d := &cacheDecoder{data: bytes}
+
+	// Most directories contain files with the same few names, like Android.bp, so share the
+	// strings to save memory.
+	names := map[string]string{}
+	intern := func(b []byte) string {
+		if s, ok := names[string(b)]; ok {
+			return s
+		}
+		s := string(b)
+		names[s] = s
+		return s
+	}
+
+	nodes := []dirFullInfo{}
+	prevPath := ""
+	for len(d.data) > 0 && d.err == nil {
+		shared := d.uvarint()
+		rest := d.bytes()
+		if shared > uint64(len(prevPath)) {
+			d.err = errCorruptCache
+		}
+		device := d.uvarint()
+		modTime := d.varint()
+		inode := d.uvarint()
+		numFiles := d.uvarint()
+		if numFiles > uint64(len(d.data)) {
+			// each name takes at least one byte
+			d.err = errCorruptCache
+		}
+		if d.err != nil {
+			break
+		}
+
+		fileNames := make([]string, numFiles)
+		for i := range fileNames {
+			fileNames[i] = intern(d.bytes())
+		}
+
+		path := prevPath[:shared] + string(rest)
+		prevPath = path
+		nodes = append(nodes, dirFullInfo{
+			pathAndStats: pathAndStats{
+				statResponse: statResponse{
+					ModTime: modTime, Inode: inode, Device: device,
+				},
+				Path: path},
+			FileNames: fileNames})
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return nodes, nil
+}
+
+// appendCacheBlock appends the framing of a block and its payload to buf
+func appendCacheBlock(buf []byte, payload []byte) []byte {
+	var scratch [binary.MaxVarintLen64 + 4]byte
+	n := binary.PutUvarint(scratch[:], uint64(len(payload)))
+	binary.LittleEndian.PutUint32(scratch[n:], crc32.ChecksumIEEE(payload))
+	buf = append(buf, scratch[:n+4]...)
+	return append(buf, payload...)
+}
+
+// appendCacheTerminator appends the empty block that marks the end of the db to buf
+func appendCacheTerminator(buf []byte) []byte {
+	return append(buf, 0)
+}
+
+// readBlock reads the payload of the next block of the db, and returns io.EOF after the last one
+func (f *Finder) readBlock(reader *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block length: %v", err)
+	}
+	if length == 0 {
+		if _, err := reader.ReadByte(); err != io.EOF {
+			return nil, errors.New("unexpected data after the last block")
+		}
+		return nil, io.EOF
+	}
+
+	var checksum [4]byte
+	if _, err := io.ReadFull(reader, checksum[:]); err != nil {
+		return nil, fmt.Errorf("failed to read block checksum: %v", err)
+	}
+	if length > maxCacheBlockSize {
+		return nil, fmt.Errorf("invalid block length %v", length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, fmt.Errorf("failed to read block: %v", err)
+	}
+	if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(checksum[:]) {
+		return nil, errors.New("block checksum mismatch")
+	}
+	return data, nil
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -22,9 +23,12 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"os/signal"
+	"regexp"
 	"runtime/pprof"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"android/soong/finder"
@@ -35,13 +39,24 @@ var (
 	// configuration of what to find
 	excludeDirs     string
 	filenamesToFind string
+	suffixesToFind  string
 	pruneFiles      string
 
+	// configuration of the query
+	globs      string
+	regex      string
+	pruneDirs  string
+	firstOnly  bool
+	newerThan  string
+	since      string
+	jsonOutput bool
+
 	// other configuration
 	cpuprofile    string
 	verbose       bool
 	dbPath        string
 	numIterations int
+	watch         bool
 )
 
 func init() {
@@ -54,16 +69,36 @@ func init() {
 		"comma-separated list of directory names to exclude from search")
 	flag.StringVar(&filenamesToFind, "names", "",
 		"comma-separated list of filenames to find")
+	flag.StringVar(&suffixesToFind, "suffixes", "",
+		"comma-separated list of filename suffixes to find")
 	flag.StringVar(&pruneFiles, "prune-files", "",
 		"filenames that if discovered will exclude their entire directory "+
 			"(including sibling files and directories)")
+
+	flag.StringVar(&globs, "glob", "",
+		"comma-separated list of glob patterns, one of which the name of each match must match")
+	flag.StringVar(&regex, "regex", "",
+		"regular expression that the path of each match must match")
+	flag.StringVar(&pruneDirs, "prune", "",
+		"comma-separated list of glob patterns of directory names not to search "+
+			"(without changing the cache db)")
+	flag.BoolVar(&firstOnly, "first", false,
+		"don't search the subdirectories of a directory containing a match")
+	flag.StringVar(&newerThan, "newer", "",
+		"only print files modified more recently than this file, for example the output of the last build")
+	flag.StringVar(&since, "since", "",
+		"only print files modified after this time, given in RFC 3339 format or as a duration before now, like 24h")
+	flag.BoolVar(&jsonOutput, "json", false, "print the matches as a JSON array")
+
+	flag.BoolVar(&watch, "watch", false,
+		"keep the cache db up to date until interrupted instead of printing matches")
 	flag.IntVar(&numIterations, "count", 1,
 		"number of times to run. This is intended for use with --cpuprofile"+
 			" , to increase profile accuracy")
 }
 
 var usage = func() {
-	fmt.Printf("usage: finder -name <fileName> --db <dbPath> <searchDirectory> [<searchDirectory>...]\n")
+	fmt.Printf("usage: finder -names <fileName> --db <dbPath> [<query flags>] <searchDirectory> [<searchDirectory>...]\n")
 	flag.PrintDefaults()
 }
 
@@ -76,9 +111,51 @@ func main() {
 }
 
 func stringToList(input string) []string {
+	if input == "" {
+		return []string{}
+	}
 	return strings.Split(input, ",")
 }
 
+// buildQuery returns the query described by the query flags
+func buildQuery() (finder.Query, error) {
+	query := finder.Query{
+		Names:     stringToList(globs),
+		PruneDirs: stringToList(pruneDirs),
+		FirstOnly: firstOnly,
+	}
+
+	if regex != "" {
+		re, err := regexp.Compile(regex)
+		if err != nil {
+			return query, fmt.Errorf("Invalid -regex: %v", err)
+		}
+		query.Regexp = re
+	}
+
+	if newerThan != "" && since != "" {
+		return query, errors.New("-newer and -since can't be used together")
+	}
+	if newerThan != "" {
+		info, err := os.Stat(newerThan)
+		if err != nil {
+			return query, err
+		}
+		query.ModifiedSince = info.ModTime()
+	}
+	if since != "" {
+		if t, err := time.Parse(time.RFC3339, since); err == nil {
+			query.ModifiedSince = t
+		} else if d, err := time.ParseDuration(since); err == nil {
+			query.ModifiedSince = time.Now().Add(-d)
+		} else {
+			return query, fmt.Errorf("Invalid -since %q: must be a time in RFC 3339 format or a duration", since)
+		}
+	}
+
+	return query, nil
+}
+
 func run() error {
 	startTime := time.Now()
 	flag.Parse()
@@ -122,15 +199,25 @@ func run() error {
 		ExcludeDirs:      stringToList(excludeDirs),
 		PruneFiles:       stringToList(pruneFiles),
 		IncludeFiles:     stringToList(filenamesToFind),
+		IncludeSuffixes:  stringToList(suffixesToFind),
 	}
 	if dbPath == "" {
 		usage()
 		return errors.New("Param 'db' must be nonempty")
 	}
 
+	if watch {
+		return runWatch(params, logger)
+	}
+
+	query, err := buildQuery()
+	if err != nil {
+		return err
+	}
+
 	matches := []string{}
 	for i := 0; i < numIterations; i++ {
-		matches, err = runFind(params, logger)
+		matches, err = runFind(params, query, logger)
 		if err != nil {
 			return err
 		}
@@ -138,19 +225,44 @@ func run() error {
 	findDuration := time.Since(startTime)
 	logger.Printf("Found these %v inodes in %v :\n", len(matches), findDuration)
 	sort.Strings(matches)
-	for _, match := range matches {
-		fmt.Println(match)
+	if jsonOutput {
+		if err := json.NewEncoder(os.Stdout).Encode(matches); err != nil {
+			return err
+		}
+	} else {
+		for _, match := range matches {
+			fmt.Println(match)
+		}
 	}
 	logger.Printf("End of %v inodes\n", len(matches))
 	logger.Printf("Finder completed in %v\n", time.Since(startTime))
 	return nil
 }
 
-func runFind(params finder.CacheParams, logger *log.Logger) (paths []string, err error) {
+func runFind(params finder.CacheParams, query finder.Query, logger *log.Logger) (paths []string, err error) {
 	service, err := finder.New(params, fs.OsFs, logger, dbPath)
 	if err != nil {
 		return []string{}, err
 	}
 	defer service.Shutdown()
-	return service.FindAll(), nil
+	return service.FindQuery("/", query)
+}
+
+func runWatch(params finder.CacheParams, logger *log.Logger) error {
+	service, err := finder.New(params, fs.OsFs, logger, dbPath)
+	if err != nil {
+		return err
+	}
+	defer service.Shutdown()
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	logger.Printf("Watching %v\n", params.RootDirs)
+	return service.Watch(stop)
 }
@@ -16,13 +16,13 @@ package finder
 
 import (
 	"bufio"
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"sort"
 	"strings"
@@ -83,7 +83,7 @@ import (
 // see cmd/finder.go or finder_test.go for usage examples
 
 // Update versionString whenever making a backwards-incompatible change to the cache file format
-const versionString = "Android finder version 1"
+const versionString = "Android finder version 2"
 
 // a CacheParams specifies which files and directories the user wishes be scanned and
 // potentially added to the cache
@@ -164,6 +164,10 @@ type Finder struct {
 	// non-temporary state
 	modifiedFlag int32
 	nodes        pathMap
+
+	// dbIsCurrent is set if a watcher confirmed that the db at DbPath reflects the current state
+	// of the filesystem, in which case the directories in it don't need to be checked again
+	dbIsCurrent bool
 }
 
 var defaultNumThreads = runtime.NumCPU() * 2
@@ -283,6 +287,92 @@ func (f *Finder) FindFirstNamedAt(rootPath string, fileName string) []string {
 	return f.FindMatching(rootPath, filter)
 }
 
+// a Query describes the files to search for with FindQuery. Only files included in the cache by
+// the CacheParams can match.
+type Query struct {
+	// Names are glob patterns, in the syntax of filepath.Match, at least one of which must match
+	// the name of a file. If Names is empty, any name matches.
+	Names []string
+
+	// Regexp, if set, must match the path of a file, as returned by FindQuery.
+	Regexp *regexp.Regexp
+
+	// PruneDirs are glob patterns matched against the names of directories. Directories that
+	// match any of them are not searched.
+	PruneDirs []string
+
+	// FirstOnly stops searching subdirectories of a directory that contains a match, like
+	// FindFirstNamed.
+	FirstOnly bool
+
+	// ModifiedSince, if set, selects only the files that were modified after it. The cache
+	// doesn't store the modification times of files, so every file that matches the other
+	// criteria is checked with Lstat.
+	ModifiedSince time.Time
+}
+
+// FindQuery searches under <rootPath> for every file matching <query>
+func (f *Finder) FindQuery(rootPath string, query Query) ([]string, error) {
+	for _, pattern := range append(append([]string{}, query.Names...), query.PruneDirs...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+
+	// FindMatching returns paths relative to the working directory when rootPath is relative,
+	// match the regexp against the same paths.
+	workingDir := f.cacheMetadata.Config.WorkingDirectory
+	isRel := !filepath.IsAbs(rootPath)
+
+	matchesAny := func(patterns []string, name string) bool {
+		for _, pattern := range patterns {
+			if match, _ := filepath.Match(pattern, name); match {
+				return true
+			}
+		}
+		return false
+	}
+
+	filter := func(entries DirEntries) (dirNames []string, fileNames []string) {
+		fileNames = []string{}
+		for _, name := range entries.FileNames {
+			if len(query.Names) > 0 && !matchesAny(query.Names, name) {
+				continue
+			}
+			path := joinCleanPaths(entries.Path, name)
+			if query.Regexp != nil {
+				queryPath := path
+				if isRel {
+					queryPath = strings.TrimPrefix(path, workingDir+"/")
+				}
+				if !query.Regexp.MatchString(queryPath) {
+					continue
+				}
+			}
+			if !query.ModifiedSince.IsZero() {
+				info, err := f.filesystem.Lstat(path)
+				if err != nil || !info.ModTime().After(query.ModifiedSince) {
+					continue
+				}
+			}
+			fileNames = append(fileNames, name)
+		}
+
+		if query.FirstOnly && len(fileNames) > 0 {
+			return []string{}, fileNames
+		}
+
+		dirNames = make([]string, 0, len(entries.DirNames))
+		for _, dirName := range entries.DirNames {
+			if !matchesAny(query.PruneDirs, dirName) {
+				dirNames = append(dirNames, dirName)
+			}
+		}
+		return dirNames, fileNames
+	}
+	return f.FindMatching(rootPath, filter), nil
+}
+
 // FindMatching is the most general exported function for searching for files in the cache
 // The WalkFunc will be invoked repeatedly and is expected to modify the provided DirEntries
 // in place, removing file paths and directories as desired.
@@ -427,28 +517,6 @@ type dirFullInfo struct {
 	FileNames []string
 }
 
-// a PersistedDirInfo is the information about a dir that we save to our cache on disk
-type PersistedDirInfo struct {
-	// These field names are short because they are repeated many times in the output json file
-	P string   // path
-	T int64    // modification time
-	I uint64   // inode number
-	F []string // relevant filenames contained
-}
-
-// a PersistedDirs is the information that we persist for a group of dirs
-type PersistedDirs struct {
-	// the device on which each directory is stored
-	Device uint64
-	// the common root path to which all contained dirs are relative
-	Root string
-	// the directories themselves
-	Dirs []PersistedDirInfo
-}
-
-// a CacheEntry is the smallest unit that can be read and parsed from the cache (on disk) at a time
-type CacheEntry []PersistedDirs
-
 // a DirEntries lists the files and directories contained directly within a specific directory
 type DirEntries struct {
 	Path string
@@ -652,95 +720,9 @@ func (e fsErr) String() string {
 	return e.path + ": " + e.err.Error()
 }
 
-func (f *Finder) serializeCacheEntry(dirInfos []dirFullInfo) ([]byte, error) {
-	// group each dirFullInfo by its Device, to avoid having to repeat it in the output
-	dirsByDevice := map[uint64][]PersistedDirInfo{}
-	for _, entry := range dirInfos {
-		_, found := dirsByDevice[entry.Device]
-		if !found {
-			dirsByDevice[entry.Device] = []PersistedDirInfo{}
-		}
-		dirsByDevice[entry.Device] = append(dirsByDevice[entry.Device],
-			PersistedDirInfo{P: entry.Path, T: entry.ModTime, I: entry.Inode, F: entry.FileNames})
-	}
-
-	cacheEntry := CacheEntry{}
-
-	for device, infos := range dirsByDevice {
-		// find common prefix
-		prefix := ""
-		if len(infos) > 0 {
-			prefix = infos[0].P
-		}
-		for _, info := range infos {
-			for !strings.HasPrefix(info.P+"/", prefix+"/") {
-				prefix = filepath.Dir(prefix)
-				if prefix == "/" {
-					break
-				}
-			}
-		}
-		// remove common prefix
-		for i := range infos {
-			suffix := strings.Replace(infos[i].P, prefix, "", 1)
-			if len(suffix) > 0 && suffix[0] == '/' {
-				suffix = suffix[1:]
-			}
-			infos[i].P = suffix
-		}
-
-		// turn the map (keyed by device) into a list of structs with labeled fields
-		// this is to improve readability of the output
-		cacheEntry = append(cacheEntry, PersistedDirs{Device: device, Root: prefix, Dirs: infos})
-	}
-
-	// convert to json.
-	// it would save some space to use a different format than json for the db file,
-	// but the space and time savings are small, and json is easy for humans to read
-	bytes, err := json.Marshal(cacheEntry)
-	return bytes, err
-}
-
-func (f *Finder) parseCacheEntry(bytes []byte) ([]dirFullInfo, error) {
-	var cacheEntry CacheEntry
-	err := json.Unmarshal(bytes, &cacheEntry)
-	if err != nil {
-		return nil, err
-	}
-
-	// convert from a CacheEntry to a []dirFullInfo (by copying a few fields)
-	capacity := 0
-	for _, element := range cacheEntry {
-		capacity += len(element.Dirs)
-	}
-	nodes := make([]dirFullInfo, capacity)
-	count := 0
-	for _, element := range cacheEntry {
-		for _, dir := range element.Dirs {
-			path := joinCleanPaths(element.Root, dir.P)
-
-			nodes[count] = dirFullInfo{
-				pathAndStats: pathAndStats{
-					statResponse: statResponse{
-						ModTime: dir.T, Inode: dir.I, Device: element.Device,
-					},
-					Path: path},
-				FileNames: dir.F}
-			count++
-		}
-	}
-	return nodes, nil
-}
-
-// We use the following separator byte to distinguish individually parseable blocks of json
-// because we know this separator won't appear in the json that we're parsing.
-//
-// The newline byte can only appear in a UTF-8 stream if the newline character appears, because:
-//   - The newline character is encoded as "0000 1010" in binary ("0a" in hex)
-//   - UTF-8 dictates that bytes beginning with a "0" bit are never emitted as part of a multibyte
-//     character.
+// We use the following separator byte to terminate the lines of the cache header.
 //
-// We know that the newline character will never appear in our json string, because:
+// We know that the newline character will never appear in the json encoded config, because:
 //   - If a newline character appears as part of a data string, then json encoding will
 //     emit two characters instead: '\' and 'n'.
 //   - The json encoder that we use doesn't emit the optional newlines between any of its
@@ -810,8 +792,13 @@ func (f *Finder) loadBytes(id int, data []byte) (m *pathMap, dirsToWalk []string
 	stats := make([]statResponse, len(cachedNodes))
 
 	for i, node := range cachedNodes {
-		// check the file system for an updated timestamp
-		stats[i] = f.statDirSync(node.Path)
+		if f.dbIsCurrent {
+			// a watcher has confirmed that the db matches the file system
+			stats[i] = node.statResponse
+		} else {
+			// check the file system for an updated timestamp
+			stats[i] = f.statDirSync(node.Path)
+		}
 	}
 
 	dirsToWalk = []string{}
@@ -846,6 +833,9 @@ func (f *Finder) startFromExternalCache() (err error) {
 	startTime := time.Now()
 	dbPath := f.DbPath
 
+	// if a watcher is keeping the db up to date, wait for it to write any pending changes
+	f.dbIsCurrent = f.syncWithWatcher()
+
 	// open cache file and validate its header
 	reader, err := f.filesystem.Open(dbPath)
 	if err != nil {
@@ -872,29 +862,23 @@ func (f *Finder) startFromExternalCache() (err error) {
 	readBlocks := func() {
 		index := 0
 		for {
-			// It takes some time to unmarshal the input from json, so we want
-			// to unmarshal it in parallel. In order to find valid places to
-			// break the input, we scan for the line separators that we inserted
-			// (for this purpose) when we dumped the database.
-			data, err := f.readLine(bufferedReader)
-			var response dataBlock
-			done := false
-			if err != nil && err != io.EOF {
-				response = dataBlock{id: index, err: err, data: nil}
-				done = true
-			} else {
-				done = (err == io.EOF)
-				response = dataBlock{id: index, err: nil, data: data}
-			}
-			blockChannel <- response
-			index++
+			// It takes some time to decode the blocks, so we want to decode
+			// them in parallel. Each block starts with its length, so it can
+			// be read without decoding it.
+			data, err := f.readBlock(bufferedReader)
 			duration := time.Since(startTime)
-			f.verbosef("Read block %v after %v\n", index, duration)
-			if done {
+			if err == io.EOF {
 				f.verbosef("Read %v blocks in %v\n", index, duration)
 				close(blockChannel)
 				return
 			}
+			blockChannel <- dataBlock{id: index, err: err, data: data}
+			if err != nil {
+				close(blockChannel)
+				return
+			}
+			index++
+			f.verbosef("Read block %v after %v\n", index, duration)
 		}
 	}
 	go readBlocks()
@@ -1069,6 +1053,10 @@ func (f *Finder) setModified() {
 	atomic.StoreInt32(&f.modifiedFlag, newVal)
 }
 
+func (f *Finder) clearModified() {
+	atomic.StoreInt32(&f.modifiedFlag, 0)
+}
+
 // sortedDirEntries exports directory entries to facilitate dumping them to the external cache
 func (f *Finder) sortedDirEntries() []dirFullInfo {
 	startTime := time.Now()
@@ -1108,21 +1096,21 @@ func (f *Finder) serializeDb() ([]byte, error) {
 		return nil, err
 	}
 	header = append(header, configDump...)
+	header = append(header, lineSeparator)
 
 	// serialize individual blocks in parallel
 	numBlocks := f.numDbLoadingThreads
 	if numBlocks > len(entryList) {
 		numBlocks = len(entryList)
 	}
-	blocks := make([][]byte, 1+numBlocks)
-	blocks[0] = header
+	blocks := make([][]byte, numBlocks)
 	blockMin := 0
 	wg := sync.WaitGroup{}
 	var errLock sync.Mutex
 
-	for i := 1; i <= numBlocks; i++ {
+	for i := 0; i < numBlocks; i++ {
 		// identify next block
-		blockMax := len(entryList) * i / numBlocks
+		blockMax := len(entryList) * (i + 1) / numBlocks
 		block := entryList[blockMin:blockMax]
 
 		// process block
@@ -1150,7 +1138,11 @@ func (f *Finder) serializeDb() ([]byte, error) {
 		return nil, err
 	}
 
-	content := bytes.Join(blocks, []byte{lineSeparator})
+	content := header
+	for _, block := range blocks {
+		content = appendCacheBlock(content, block)
+	}
+	content = appendCacheTerminator(content)
 
 	return content, nil
 }
@@ -1476,6 +1468,7 @@ func (f *Finder) listDirSync(dir *pathMap) {
 func (f *Finder) listMatches(node *pathMap,
 	filter WalkFunc) (subDirs []*pathMap, filePaths []string) {
 	entries := DirEntries{
+		Path:      node.path,
 		FileNames: node.FileNames,
 	}
 	entries.DirNames = make([]string, 0, len(node.children))
@@ -20,6 +20,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 	"testing"
@@ -499,6 +501,119 @@ func TestFindFirst(t *testing.T) {
 	)
 }
 
+func TestFindQuery(t *testing.T) {
+	filesystem := newFs()
+	fs.Create(t, "/tmp/Android.bp", filesystem)
+	fs.Create(t, "/tmp/a/Android.bp", filesystem)
+	fs.Create(t, "/tmp/a/board.mk", filesystem)
+	fs.Create(t, "/tmp/a/b/Android.bp", filesystem)
+	fs.Create(t, "/tmp/out/Android.bp", filesystem)
+	filesystem.Clock.Tick()
+	lastBuild := filesystem.Clock.Time()
+	filesystem.Clock.Tick()
+	fs.Create(t, "/tmp/a/product.mk", filesystem)
+	fs.Create(t, "/tmp/c/Android.bp", filesystem)
+
+	finder := newFinder(
+		t,
+		filesystem,
+		CacheParams{
+			WorkingDirectory: "/tmp",
+			RootDirs:         []string{"/tmp"},
+			IncludeFiles:     []string{"Android.bp"},
+			IncludeSuffixes:  []string{".mk"},
+		},
+	)
+	defer finder.Shutdown()
+
+	testCases := []struct {
+		name     string
+		root     string
+		query    Query
+		expected []string
+	}{
+		{
+			name:  "glob",
+			root:  "/tmp",
+			query: Query{Names: []string{"*.mk"}},
+			expected: []string{
+				"/tmp/a/board.mk",
+				"/tmp/a/product.mk",
+			},
+		},
+		{
+			name:  "several globs",
+			root:  "/tmp/a",
+			query: Query{Names: []string{"board.*", "Android.[a-z]p"}},
+			expected: []string{
+				"/tmp/a/Android.bp",
+				"/tmp/a/b/Android.bp",
+				"/tmp/a/board.mk",
+			},
+		},
+		{
+			name:  "regexp",
+			root:  "/tmp",
+			query: Query{Regexp: regexp.MustCompile(`^/tmp/[a-z]/Android\.bp$`)},
+			expected: []string{
+				"/tmp/a/Android.bp",
+				"/tmp/c/Android.bp",
+			},
+		},
+		{
+			name:  "regexp on relative paths",
+			root:  ".",
+			query: Query{Regexp: regexp.MustCompile(`^a/[^/]*$`)},
+			expected: []string{
+				"a/Android.bp",
+				"a/board.mk",
+				"a/product.mk",
+			},
+		},
+		{
+			name:  "prune",
+			root:  "/tmp",
+			query: Query{Names: []string{"Android.bp"}, PruneDirs: []string{"out", "b"}},
+			expected: []string{
+				"/tmp/Android.bp",
+				"/tmp/a/Android.bp",
+				"/tmp/c/Android.bp",
+			},
+		},
+		{
+			name:  "first only",
+			root:  "/tmp/a",
+			query: Query{Names: []string{"Android.bp"}, FirstOnly: true},
+			expected: []string{
+				"/tmp/a/Android.bp",
+			},
+		},
+		{
+			name:  "modified since",
+			root:  "/tmp",
+			query: Query{ModifiedSince: lastBuild},
+			expected: []string{
+				"/tmp/a/product.mk",
+				"/tmp/c/Android.bp",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			foundPaths, err := finder.FindQuery(tc.root, tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fs.AssertSameResponse(t, foundPaths, tc.expected)
+		})
+	}
+
+	if _, err := finder.FindQuery("/tmp", Query{Names: []string{"["}}); err == nil {
+		t.Errorf("expected error for invalid pattern")
+	}
+}
+
 func TestConcurrentFindSameDirectory(t *testing.T) {
 
 	testWithNumThreads := func(t *testing.T, numThreads int) {
@@ -772,6 +887,32 @@ func TestCorruptedCacheBody(t *testing.T) {
 	finder2.Shutdown()
 }
 
+func TestCacheEntryRoundTrip(t *testing.T) {
+	finder := &Finder{}
+	entries := []dirFullInfo{
+		{pathAndStats{statResponse{ModTime: 1, Inode: 2, Device: 3}, "/tmp"}, []string{"Android.bp"}},
+		{pathAndStats{statResponse{ModTime: -4, Inode: 5, Device: 3}, "/tmp/a"}, []string{}},
+		{pathAndStats{statResponse{ModTime: 6, Inode: 7, Device: 8}, "/tmp/a/b"}, []string{"Android.bp", "x.mk"}},
+		{pathAndStats{statResponse{ModTime: 9, Inode: 10, Device: 3}, "/tmp/ab"}, []string{"Android.bp"}},
+	}
+
+	data, err := finder.serializeCacheEntry(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := finder.parseCacheEntry(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, entries) {
+		t.Errorf("parseCacheEntry(serializeCacheEntry(%v)) = %v", entries, parsed)
+	}
+
+	if parsed, err := finder.parseCacheEntry(data[:len(data)-1]); err == nil {
+		t.Errorf("expected error parsing truncated data, got %v", parsed)
+	}
+}
+
 func TestStatCalls(t *testing.T) {
 	// setup filesystem
 	filesystem := newFs()
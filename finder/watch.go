@@ -0,0 +1,345 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// This file implements a long-lived watcher that keeps the cache db up to date between uses of
+// the Finder, so that a new Finder doesn't need to stat every directory in the db.
+//
+// The watcher is a Finder whose Watch method is running. It asks the operating system to notify
+// it whenever the contents of a directory in the db change, rescans those directories
+// periodically and rewrites the db when anything changed. It also listens on a unix socket next
+// to the db. When a new Finder starts, it sends "sync\n" to the socket, and the watcher replies
+// "ok\n" once it has processed every change that happened before the request and written the db.
+// The new Finder then loads the db without statting any directories. If there is no watcher, or
+// it doesn't reply, the new Finder checks every directory as usual.
+
+// watchInterval is how often the watcher processes notifications when nobody is waiting for it
+var watchInterval = time.Second
+
+// watcherSyncTimeout is how long a new Finder waits for the watcher to bring the db up to date
+const watcherSyncTimeout = 30 * time.Second
+
+// a dirWatcher reports changes to the contents of a set of directories
+type dirWatcher interface {
+	// add starts watching the directory at path
+	add(path string) error
+
+	// watching returns whether the directory at path is being watched
+	watching(path string) bool
+
+	// changes returns the directories whose contents may have changed since the last call,
+	// without blocking. If notifications were lost, overflowed is true and any directory may
+	// have changed.
+	changes() (dirs []string, overflowed bool, err error)
+
+	close() error
+}
+
+// watcherSocket returns the path of the socket on which a watcher of the db serves requests
+func (f *Finder) watcherSocket() string {
+	return f.DbPath + ".sock"
+}
+
+// syncWithWatcher asks the watcher of the db, if any, to write any pending changes to the db,
+// and returns whether it did
+func (f *Finder) syncWithWatcher() bool {
+	conn, err := net.DialTimeout("unix", f.watcherSocket(), time.Second)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(watcherSyncTimeout))
+
+	startTime := time.Now()
+	if _, err := io.WriteString(conn, "sync\n"); err != nil {
+		f.verbosef("Failed to send request to watcher: %v\n", err)
+		return false
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		f.verbosef("Failed to read reply from watcher: %v\n", err)
+		return false
+	}
+	if reply != "ok\n" {
+		f.verbosef("Watcher failed to update db: %q\n", strings.TrimSpace(reply))
+		return false
+	}
+	f.verbosef("Watcher updated db in %v\n", time.Since(startTime))
+	return true
+}
+
+// Watch keeps the db up to date with the filesystem until stop is closed, so that later Finders
+// using the same db start without checking every directory. Watch returns an error if the
+// operating system doesn't support watching directories, or if another watcher is already
+// watching the db.
+func (f *Finder) Watch(stop <-chan struct{}) error {
+	watcher, err := newDirWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.close()
+
+	listener, err := listenForSyncRequests(f.watcherSocket())
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	return f.watch(watcher, listener, stop)
+}
+
+// listenForSyncRequests listens on the unix socket at path, replacing a socket left behind by a
+// watcher that didn't exit cleanly
+func listenForSyncRequests(path string) (net.Listener, error) {
+	listener, err := net.Listen("unix", path)
+	if err == nil {
+		return listener, nil
+	}
+	if conn, dialErr := net.Dial("unix", path); dialErr == nil {
+		conn.Close()
+		return nil, fmt.Errorf("another watcher is already listening on %v", path)
+	}
+	os.Remove(path)
+	return net.Listen("unix", path)
+}
+
+// watch implements Watch using the given watcher and listener
+func (f *Finder) watch(watcher dirWatcher, listener net.Listener, stop <-chan struct{}) error {
+	startTime := time.Now()
+
+	// Don't race with writing the db after loading it.
+	f.WaitForDbDump()
+
+	// Start watching every known directory before checking them, so that no change made after
+	// the check is missed.
+	f.lock()
+	dirs, err := f.watchTree(watcher, &f.nodes)
+	if err == nil {
+		err = f.refreshDirs(watcher, dirs, false)
+	}
+	f.unlock()
+	if err != nil {
+		return err
+	}
+	f.verbosef("Watching %v directories after %v\n", len(dirs), time.Since(startTime))
+
+	done := make(chan struct{})
+	defer close(done)
+	requests := make(chan chan error)
+	go f.serveSyncRequests(listener, requests, done)
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			if err := f.processWatchEvents(watcher); err != nil {
+				return err
+			}
+		case reply := <-requests:
+			err := f.processWatchEvents(watcher)
+			reply <- err
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// serveSyncRequests accepts connections until listener is closed, and sends a request to
+// requests for each sync request received until done is closed
+func (f *Finder) serveSyncRequests(listener net.Listener, requests chan<- chan error, done <-chan struct{}) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			conn.SetDeadline(time.Now().Add(watcherSyncTimeout))
+
+			request, err := bufio.NewReader(conn).ReadString('\n')
+			if err != nil {
+				return
+			}
+			if request != "sync\n" {
+				fmt.Fprintf(conn, "error: unknown request %q\n", strings.TrimSpace(request))
+				return
+			}
+
+			reply := make(chan error, 1)
+			select {
+			case requests <- reply:
+			case <-done:
+				return
+			}
+			if err := <-reply; err != nil {
+				fmt.Fprintf(conn, "error: %v\n", err)
+			} else {
+				io.WriteString(conn, "ok\n")
+			}
+		}()
+	}
+}
+
+// processWatchEvents updates the directories that the watcher reported as changed, and writes
+// the db if anything changed
+func (f *Finder) processWatchEvents(watcher dirWatcher) error {
+	changed, overflowed, err := watcher.changes()
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 && !overflowed {
+		return nil
+	}
+
+	startTime := time.Now()
+	f.lock()
+	defer f.unlock()
+
+	var dirs []*pathMap
+	force := true
+	if overflowed {
+		// Some notifications were lost, so check every directory as if starting from the db.
+		f.verbosef("Watcher lost notifications, checking all directories\n")
+		f.nodes.walk(func(node *pathMap) {
+			if node.ModTime != 0 {
+				dirs = append(dirs, node)
+			}
+		})
+		force = false
+	} else {
+		for _, path := range changed {
+			if node := f.nodes.GetNode(path, false); node != nil {
+				dirs = append(dirs, node)
+			}
+		}
+	}
+
+	if err := f.refreshDirs(watcher, dirs, force); err != nil {
+		return err
+	}
+
+	if err := f.getErr(); err != nil {
+		f.verbosef("%v\n", err)
+	}
+	f.fsErrs = nil
+
+	if !f.wasModified() {
+		return nil
+	}
+	f.clearModified()
+	f.verbosef("Updated %v changed directories in %v\n", len(dirs), time.Since(startTime))
+	return f.dumpDb()
+}
+
+// refreshDirs brings dirs and any directories discovered in them up to date with the filesystem,
+// and watches the new directories. If force is set, dirs are listed again even if their stats
+// haven't changed, as their modification times may not have the resolution to show every
+// change. f must be locked.
+func (f *Finder) refreshDirs(watcher dirWatcher, dirs []*pathMap, force bool) error {
+	for len(dirs) > 0 {
+		f.threadPool = newThreadPool(f.numDbLoadingThreads)
+		for _, dir := range dirs {
+			if force {
+				dir.statResponse = statResponse{}
+			}
+			f.statDirAsync(dir)
+		}
+		f.threadPool.Wait()
+		f.threadPool = nil
+
+		// Directories that were found while listing dirs are only watched after they were listed,
+		// so list them again in case they changed in between.
+		var added []*pathMap
+		for _, dir := range outermostDirs(dirs) {
+			node := f.nodes.GetNode(dir.path, false)
+			if node == nil {
+				continue
+			}
+			newDirs, err := f.watchTree(watcher, node)
+			if err != nil {
+				return err
+			}
+			added = append(added, newDirs...)
+		}
+		dirs = added
+		force = true
+	}
+	return nil
+}
+
+// watchTree watches every directory under root that exists and isn't watched yet, and returns
+// them
+func (f *Finder) watchTree(watcher dirWatcher, root *pathMap) ([]*pathMap, error) {
+	var added []*pathMap
+	var err error
+	root.walk(func(node *pathMap) {
+		if err != nil || node.ModTime == 0 || watcher.watching(node.path) {
+			return
+		}
+		if addErr := watcher.add(node.path); addErr != nil {
+			// The directory may have been removed since it was listed, it will be updated
+			// when its parent is.
+			if !os.IsNotExist(addErr) && !os.IsPermission(addErr) {
+				err = addErr
+			}
+			return
+		}
+		added = append(added, node)
+	})
+	return added, err
+}
+
+// outermostDirs returns the dirs that are not under any other dir in dirs
+func outermostDirs(dirs []*pathMap) []*pathMap {
+	sorted := append([]*pathMap(nil), dirs...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].path < sorted[j].path
+	})
+
+	var result []*pathMap
+	for _, dir := range sorted {
+		if len(result) > 0 {
+			last := result[len(result)-1].path
+			if dir.path == last || last == "/" || strings.HasPrefix(dir.path, last+"/") {
+				continue
+			}
+		}
+		result = append(result, dir)
+	}
+	return result
+}
+
+// walk calls visit for m and each of its descendants, parents before children
+func (m *pathMap) walk(visit func(node *pathMap)) {
+	visit(m)
+	for _, child := range m.children {
+		child.walk(visit)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// The events that may change the stats or the list of entries of a watched directory. Changes to
+// the contents of the files in it don't matter.
+const inotifyMask = syscall.IN_CREATE |
+	syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM |
+	syscall.IN_MOVED_TO |
+	syscall.IN_ATTRIB |
+	syscall.IN_DELETE_SELF |
+	syscall.IN_MOVE_SELF |
+	syscall.IN_ONLYDIR |
+	syscall.IN_DONT_FOLLOW
+
+// an inotifyWatcher is a dirWatcher that uses inotify
+type inotifyWatcher struct {
+	fd int
+
+	// the path of each watch descriptor, and the watch descriptor of each path
+	paths map[int32]string
+	wds   map[string]int32
+
+	buf []byte
+}
+
+func newDirWatcher() (dirWatcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_NONBLOCK | syscall.IN_CLOEXEC)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	return &inotifyWatcher{
+		fd:    fd,
+		paths: make(map[int32]string),
+		wds:   make(map[string]int32),
+		buf:   make([]byte, 64*1024),
+	}, nil
+}
+
+func (w *inotifyWatcher) add(path string) error {
+	wd, err := syscall.InotifyAddWatch(w.fd, path, inotifyMask)
+	if err == syscall.ENOSPC {
+		return fmt.Errorf("failed to watch %v: the limit on the number of inotify watches was reached, "+
+			"increase it with `sysctl fs.inotify.max_user_watches=<limit>`", path)
+	} else if err != nil {
+		return &os.PathError{Op: "inotify_add_watch", Path: path, Err: err}
+	}
+
+	// A directory that moved keeps its watch descriptor, forget its old path.
+	if oldPath, ok := w.paths[int32(wd)]; ok {
+		delete(w.wds, oldPath)
+	}
+	w.paths[int32(wd)] = path
+	w.wds[path] = int32(wd)
+	return nil
+}
+
+func (w *inotifyWatcher) watching(path string) bool {
+	_, ok := w.wds[path]
+	return ok
+}
+
+func (w *inotifyWatcher) forget(wd int32) {
+	if path, ok := w.paths[wd]; ok {
+		delete(w.wds, path)
+		delete(w.paths, wd)
+	}
+}
+
+func (w *inotifyWatcher) changes() (dirs []string, overflowed bool, err error) {
+	seen := make(map[string]bool)
+	for {
+		n, err := syscall.Read(w.fd, w.buf)
+		if err == syscall.EINTR {
+			continue
+		} else if err == syscall.EAGAIN {
+			break
+		} else if err != nil {
+			return nil, false, os.NewSyscallError("read", err)
+		}
+		if n < syscall.SizeofInotifyEvent {
+			break
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&w.buf[offset]))
+			offset += syscall.SizeofInotifyEvent + int(event.Len)
+
+			switch {
+			case event.Mask&syscall.IN_Q_OVERFLOW != 0:
+				overflowed = true
+				continue
+			case event.Mask&syscall.IN_IGNORED != 0:
+				// The watch was removed because the directory was deleted.
+				w.forget(event.Wd)
+				continue
+			case event.Mask&syscall.IN_MOVE_SELF != 0:
+				// The directory moved, so its path is no longer valid. Its old and new parents
+				// receive events for the move, and it will be watched again at its new path.
+				syscall.InotifyRmWatch(w.fd, uint32(event.Wd))
+				w.forget(event.Wd)
+				continue
+			case event.Mask&syscall.IN_ATTRIB != 0 && event.Len > 0:
+				// The attributes of an entry in the directory changed, which doesn't change the
+				// directory itself.
+				continue
+			}
+
+			path, ok := w.paths[event.Wd]
+			if ok && !seen[path] {
+				seen[path] = true
+				dirs = append(dirs, path)
+			}
+		}
+	}
+	return dirs, overflowed, nil
+}
+
+func (w *inotifyWatcher) close() error {
+	return syscall.Close(w.fd)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInotifyWatcher(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := newDirWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.close()
+
+	assertChanges := func(want ...string) {
+		t.Helper()
+		got, overflowed, err := w.changes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if overflowed {
+			t.Errorf("unexpected overflow")
+		}
+		if len(got) != 0 || len(want) != 0 {
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("changes() = %q, want %q", got, want)
+			}
+		}
+	}
+
+	if err := w.add(dir); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges()
+
+	sub := filepath.Join(dir, "sub")
+	if err := os.Mkdir(sub, 0777); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(dir)
+
+	if err := w.add(sub); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(sub, "Android.bp")
+	if err := ioutil.WriteFile(file, nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(sub)
+
+	// changing the contents or attributes of a file doesn't change its directory
+	if err := ioutil.WriteFile(file, []byte("changed"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(file, 0600); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges()
+
+	// a moved directory is no longer watched at its old path
+	moved := filepath.Join(dir, "moved")
+	if err := os.Rename(sub, moved); err != nil {
+		t.Fatal(err)
+	}
+	assertChanges(dir)
+	if w.watching(sub) {
+		t.Errorf("expected %v to no longer be watched", sub)
+	}
+
+	if err := w.add(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("expected not exist error watching missing directory, got %v", err)
+	}
+}
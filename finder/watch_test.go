@@ -0,0 +1,221 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"android/soong/finder/fs"
+)
+
+// a fakeDirWatcher is a dirWatcher whose changes are reported by the test
+type fakeDirWatcher struct {
+	lock       sync.Mutex
+	watched    map[string]bool
+	changed    []string
+	overflowed bool
+}
+
+func newFakeDirWatcher() *fakeDirWatcher {
+	return &fakeDirWatcher{watched: make(map[string]bool)}
+}
+
+func (w *fakeDirWatcher) add(path string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.watched[path] = true
+	return nil
+}
+
+func (w *fakeDirWatcher) watching(path string) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.watched[path]
+}
+
+func (w *fakeDirWatcher) changes() ([]string, bool, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	changed, overflowed := w.changed, w.overflowed
+	w.changed, w.overflowed = nil, false
+	return changed, overflowed, nil
+}
+
+func (w *fakeDirWatcher) close() error {
+	return nil
+}
+
+func (w *fakeDirWatcher) notify(path string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.changed = append(w.changed, path)
+}
+
+func (w *fakeDirWatcher) overflow() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.overflowed = true
+}
+
+// startWatcher creates a Finder that stores its db in a real temporary directory, so that it can
+// listen on a socket next to it, and starts watching with a fakeDirWatcher
+func startWatcher(t *testing.T, filesystem *fs.MockFs, cacheParams CacheParams) (*Finder, *fakeDirWatcher) {
+	dbPath := filepath.Join(t.TempDir(), "finder-db")
+	filesystem.MkDirs(filepath.Dir(dbPath))
+	logger := log.New(ioutil.Discard, "", 0)
+	f, err := newImpl(cacheParams, filesystem, logger, dbPath, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := listenForSyncRequests(f.watcherSocket())
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher := newFakeDirWatcher()
+	stop := make(chan struct{})
+	errs := make(chan error)
+	go func() {
+		errs <- f.watch(watcher, listener, stop)
+	}()
+
+	t.Cleanup(func() {
+		close(stop)
+		if err := <-errs; err != nil {
+			t.Errorf("watch failed: %v", err)
+		}
+		listener.Close()
+	})
+
+	// wait for the watcher to finish checking the directories in the db
+	if !f.syncWithWatcher() {
+		t.Fatal("failed to sync with watcher")
+	}
+	return f, watcher
+}
+
+func finderWithSameParamsAsWatcher(t *testing.T, watcher *Finder) *Finder {
+	f, err := newImpl(
+		watcher.cacheMetadata.Config.CacheParams,
+		watcher.filesystem,
+		watcher.logger,
+		watcher.DbPath,
+		2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestWatch(t *testing.T) {
+	filesystem := newFs()
+	fs.Create(t, "/src/a/findme.txt", filesystem)
+	fs.Create(t, "/src/b/ignore.txt", filesystem)
+
+	watcher, dirWatcher := startWatcher(t, filesystem, CacheParams{
+		RootDirs:     []string{"/src"},
+		IncludeFiles: []string{"findme.txt"},
+	})
+
+	// a Finder that syncs with the watcher doesn't check any directories
+	filesystem.ClearMetrics()
+	finder := finderWithSameParamsAsWatcher(t, watcher)
+	if !finder.dbIsCurrent {
+		t.Errorf("expected Finder to sync with watcher")
+	}
+	fs.AssertSameResponse(t, finder.FindNamedAt("/src", "findme.txt"), []string{"/src/a/findme.txt"})
+	fs.AssertSameStatCalls(t, filesystem.StatCalls, []string{})
+	fs.AssertSameReadDirCalls(t, filesystem.ReadDirCalls, []string{})
+	finder.Shutdown()
+
+	for _, dir := range []string{"/src", "/src/a", "/src/b"} {
+		if !dirWatcher.watching(dir) {
+			t.Errorf("expected %v to be watched", dir)
+		}
+	}
+
+	// add a directory
+	filesystem.Clock.Tick()
+	fs.Create(t, "/src/c/findme.txt", filesystem)
+	filesystem.Clock.Tick()
+	dirWatcher.notify("/src")
+	filesystem.ClearMetrics()
+
+	// only the watcher checks the directories that changed, and the new directory is listed
+	// again after it is watched
+	finder = finderWithSameParamsAsWatcher(t, watcher)
+	fs.AssertSameResponse(t, finder.FindNamedAt("/src", "findme.txt"),
+		[]string{"/src/a/findme.txt", "/src/c/findme.txt"})
+	fs.AssertSameStatCalls(t, filesystem.StatCalls, []string{"/src", "/src/c", "/src/c"})
+	fs.AssertSameReadDirCalls(t, filesystem.ReadDirCalls, []string{"/src", "/src/c", "/src/c"})
+	finder.Shutdown()
+
+	if !dirWatcher.watching("/src/c") {
+		t.Errorf("expected /src/c to be watched")
+	}
+
+	// remove a file
+	filesystem.Clock.Tick()
+	fs.Delete(t, "/src/a/findme.txt", filesystem)
+	filesystem.Clock.Tick()
+	dirWatcher.notify("/src/a")
+
+	finder = finderWithSameParamsAsWatcher(t, watcher)
+	fs.AssertSameResponse(t, finder.FindNamedAt("/src", "findme.txt"), []string{"/src/c/findme.txt"})
+	finder.Shutdown()
+}
+
+func TestWatchOverflow(t *testing.T) {
+	filesystem := newFs()
+	fs.Create(t, "/src/a/findme.txt", filesystem)
+	fs.Create(t, "/src/b/findme.txt", filesystem)
+
+	watcher, dirWatcher := startWatcher(t, filesystem, CacheParams{
+		RootDirs:     []string{"/src"},
+		IncludeFiles: []string{"findme.txt"},
+	})
+
+	// when notifications are lost, every directory is checked
+	filesystem.Clock.Tick()
+	fs.Delete(t, "/src/b/findme.txt", filesystem)
+	filesystem.Clock.Tick()
+	dirWatcher.overflow()
+	filesystem.ClearMetrics()
+
+	finder := finderWithSameParamsAsWatcher(t, watcher)
+	fs.AssertSameResponse(t, finder.FindNamedAt("/src", "findme.txt"), []string{"/src/a/findme.txt"})
+	fs.AssertSameStatCalls(t, filesystem.StatCalls, []string{"/src", "/src/a", "/src/b"})
+	fs.AssertSameReadDirCalls(t, filesystem.ReadDirCalls, []string{"/src/b"})
+	finder.Shutdown()
+}
+
+func TestWatcherAlreadyRunning(t *testing.T) {
+	filesystem := newFs()
+	fs.Create(t, "/src/findme.txt", filesystem)
+
+	watcher, _ := startWatcher(t, filesystem, CacheParams{
+		RootDirs:     []string{"/src"},
+		IncludeFiles: []string{"findme.txt"},
+	})
+
+	if _, err := listenForSyncRequests(watcher.watcherSocket()); err == nil {
+		t.Errorf("expected error when another watcher is running")
+	}
+}
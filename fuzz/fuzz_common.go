@@ -187,7 +187,7 @@ type FuzzPackagedModule struct {
 }
 
 func GetFramework(ctx android.LoadHookContext, lang Lang) Framework {
-	framework := ctx.Config().Getenv("FUZZ_FRAMEWORK")
+	framework := ctx.Config().EnvString("FUZZ_FRAMEWORK")
 
 	if lang == Cc {
 		switch strings.ToLower(framework) {
@@ -593,7 +593,7 @@ func (a *AndroidApp) generateAndroidBuildActions(ctx android.ModuleContext) {
 	a.classLoaderContexts = a.usesLibrary.classLoaderContextForUsesLibDeps(ctx)
 
 	var noticeAssetPath android.WritablePath
-	if Bool(a.appProperties.Embed_notices) || ctx.Config().IsEnvTrue("ALWAYS_EMBED_NOTICES") {
+	if Bool(a.appProperties.Embed_notices) || ctx.Config().EnvBool("ALWAYS_EMBED_NOTICES") {
 		// The rule to create the notice file can't be generated yet, as the final output path
 		// for the apk isn't known yet.  Add the path where the notice file will be generated to the
 		// aapt rules now before calling aaptBuildActions, the rule to create the notice file will
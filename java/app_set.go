@@ -45,6 +45,19 @@ type AndroidAppSetProperties struct {
 	// APKs in this set use prerelease SDK version
 	Prerelease *bool
 
+	// Texture compression formats supported by the device, most preferred first (e.g. ASTC, ETC2).
+	// Selects the splits for the first supported format, or the fallback splits if not set.
+	// Overrides PRODUCT_TEXTURE_COMPRESSION_FORMATS.
+	Texture_compression_formats []string
+
+	// Device tier of the device, which selects the splits for the highest tier that doesn't exceed
+	// it. Overrides PRODUCT_DEVICE_TIER, defaults to 0.
+	Device_tier *int64
+
+	// Name of the device's country set, which selects its splits, or the fallback splits if not set.
+	// Overrides PRODUCT_COUNTRY_SET.
+	Country_set *string
+
 	// Names of modules to be overridden. Listed modules can only be other apps
 	//	(in Make or Soong).
 	Overrides []string
@@ -123,7 +136,31 @@ func (as *AndroidAppSet) GenerateAndroidBuildActions(ctx android.ModuleContext)
 	if dpis := ctx.Config().ProductAAPTPrebuiltDPI(); len(dpis) > 0 {
 		screenDensities = strings.ToUpper(strings.Join(dpis, ","))
 	}
-	// TODO(asmundak): handle locales.
+	locales := "all"
+	if productLocales := ctx.Config().ProductLocales(); len(productLocales) > 0 {
+		locales = strings.Join(productLocales, ",")
+	}
+	textureCompressionFormats := "none"
+	formats := as.properties.Texture_compression_formats
+	if len(formats) == 0 {
+		formats = ctx.Config().ProductTextureCompressionFormats()
+	}
+	if len(formats) > 0 {
+		textureCompressionFormats = strings.ToUpper(strings.Join(formats, ","))
+	}
+	deviceTier := 0
+	if as.properties.Device_tier != nil {
+		deviceTier = proptools.Int(as.properties.Device_tier)
+		if deviceTier < 0 {
+			ctx.PropertyErrorf("device_tier", "must not be negative, got %d", deviceTier)
+		}
+	} else if productDeviceTier := ctx.Config().ProductDeviceTier(); productDeviceTier != nil {
+		deviceTier = *productDeviceTier
+		if deviceTier < 0 {
+			ctx.ModuleErrorf("PRODUCT_DEVICE_TIER must not be negative, got %d", deviceTier)
+		}
+	}
+	countrySet := proptools.StringDefault(as.properties.Country_set, ctx.Config().ProductCountrySet())
 	// TODO(asmundak): do we support device features
 	ctx.Build(pctx,
 		android.BuildParams{
@@ -133,14 +170,18 @@ func (as *AndroidAppSet) GenerateAndroidBuildActions(ctx android.ModuleContext)
 			ImplicitOutputs: android.WritablePaths{as.packedOutput, as.apkcertsFile},
 			Inputs:          android.Paths{as.prebuilt.SingleSourcePath(ctx)},
 			Args: map[string]string{
-				"abis":              strings.Join(SupportedAbis(ctx), ","),
-				"allow-prereleased": strconv.FormatBool(proptools.Bool(as.properties.Prerelease)),
-				"screen-densities":  screenDensities,
-				"sdk-version":       ctx.Config().PlatformSdkVersion().String(),
-				"stem":              as.BaseModuleName(),
-				"apkcerts":          as.apkcertsFile.String(),
-				"partition":         as.PartitionTag(ctx.DeviceConfig()),
-				"zip":               as.packedOutput.String(),
+				"abis":                        strings.Join(SupportedAbis(ctx), ","),
+				"allow-prereleased":           strconv.FormatBool(proptools.Bool(as.properties.Prerelease)),
+				"screen-densities":            screenDensities,
+				"locales":                     locales,
+				"texture-compression-formats": textureCompressionFormats,
+				"device-tier":                 strconv.Itoa(deviceTier),
+				"country-set":                 countrySet,
+				"sdk-version":                 ctx.Config().PlatformSdkVersion().String(),
+				"stem":                        as.BaseModuleName(),
+				"apkcerts":                    as.apkcertsFile.String(),
+				"partition":                   as.PartitionTag(ctx.DeviceConfig()),
+				"zip":                         as.packedOutput.String(),
 			},
 		})
 
@@ -163,7 +204,13 @@ func (as *AndroidAppSet) InstallBypassMake() bool { return true }
 // PRODUCT_AAPT_PREBUILT_DPI variable. If present (its value should
 // be a list density names: LDPI, MDPI, HDPI, etc.), only listed
 // splits will be extracted. Otherwise all density-specific splits
-// will be extracted.
+// will be extracted. Similarly, if PRODUCT_LOCALES is set, only the
+// language splits for its languages, and the split for the other
+// languages unless each of them has its own split, will be extracted.
+// The splits of bundles that target texture compression formats, device
+// tiers or country sets are selected by PRODUCT_TEXTURE_COMPRESSION_FORMATS,
+// PRODUCT_DEVICE_TIER and PRODUCT_COUNTRY_SET, which can be overridden with
+// the texture_compression_formats, device_tier and country_set properties.
 func AndroidAppSetFactory() android.Module {
 	module := &AndroidAppSet{}
 	module.AddProperties(&module.properties)
@@ -29,6 +29,9 @@ func TestAndroidAppSet(t *testing.T) {
 			name: "foo",
 			set: "prebuilts/apks/app.apks",
 			prerelease: true,
+			texture_compression_formats: ["astc", "etc2"],
+			device_tier: 1,
+			country_set: "latam",
 		}`)
 	module := result.ModuleForTests("foo", "android_common")
 	const packedSplitApks = "foo.zip"
@@ -42,6 +45,9 @@ func TestAndroidAppSet(t *testing.T) {
 	if s := params.Args["partition"]; s != "system" {
 		t.Errorf("wrong partition value: '%s', expected 'system'", s)
 	}
+	android.AssertStringEquals(t, "texture-compression-formats", "ASTC,ETC2", params.Args["texture-compression-formats"])
+	android.AssertStringEquals(t, "device-tier", "1", params.Args["device-tier"])
+	android.AssertStringEquals(t, "country-set", "latam", params.Args["country-set"])
 
 	android.AssertPathRelativeToTopEquals(t, "incorrect output path",
 		"out/soong/.intermediates/foo/android_common/foo.apk", params.Output)
@@ -74,6 +80,10 @@ func TestAndroidAppSet_Variants(t *testing.T) {
 		name            string
 		targets         []android.Target
 		aaptPrebuiltDPI []string
+		locales         []string
+		textureFormats  []string
+		deviceTier      int
+		countrySet      string
 		sdkVersion      int
 		expected        map[string]string
 	}{
@@ -83,13 +93,21 @@ func TestAndroidAppSet_Variants(t *testing.T) {
 				{Os: android.Android, Arch: android.Arch{ArchType: android.X86}},
 			},
 			aaptPrebuiltDPI: []string{"ldpi", "xxhdpi"},
+			locales:         []string{"en_US", "fr_FR"},
+			textureFormats:  []string{"etc2"},
+			deviceTier:      2,
+			countrySet:      "sea",
 			sdkVersion:      29,
 			expected: map[string]string{
-				"abis":              "X86",
-				"allow-prereleased": "false",
-				"screen-densities":  "LDPI,XXHDPI",
-				"sdk-version":       "29",
-				"stem":              "foo",
+				"abis":                        "X86",
+				"allow-prereleased":           "false",
+				"screen-densities":            "LDPI,XXHDPI",
+				"locales":                     "en_US,fr_FR",
+				"texture-compression-formats": "ETC2",
+				"device-tier":                 "2",
+				"country-set":                 "sea",
+				"sdk-version":                 "29",
+				"stem":                        "foo",
 			},
 		},
 		{
@@ -101,11 +119,15 @@ func TestAndroidAppSet_Variants(t *testing.T) {
 			aaptPrebuiltDPI: nil,
 			sdkVersion:      30,
 			expected: map[string]string{
-				"abis":              "X86_64,X86",
-				"allow-prereleased": "false",
-				"screen-densities":  "all",
-				"sdk-version":       "30",
-				"stem":              "foo",
+				"abis":                        "X86_64,X86",
+				"allow-prereleased":           "false",
+				"screen-densities":            "all",
+				"locales":                     "all",
+				"texture-compression-formats": "none",
+				"device-tier":                 "0",
+				"country-set":                 "",
+				"sdk-version":                 "30",
+				"stem":                        "foo",
 			},
 		},
 	}
@@ -115,6 +137,10 @@ func TestAndroidAppSet_Variants(t *testing.T) {
 			PrepareForTestWithJavaDefaultModules,
 			android.FixtureModifyProductVariables(func(variables android.FixtureProductVariables) {
 				variables.AAPTPrebuiltDPI = test.aaptPrebuiltDPI
+				variables.ProductLocales = test.locales
+				variables.ProductTextureCompressionFormats = test.textureFormats
+				variables.ProductDeviceTier = &test.deviceTier
+				variables.ProductCountrySet = &test.countrySet
 				variables.Platform_sdk_version = &test.sdkVersion
 			}),
 			android.FixtureModifyConfig(func(config android.Config) {
@@ -132,3 +158,15 @@ func TestAndroidAppSet_Variants(t *testing.T) {
 		}
 	}
 }
+
+func TestAndroidAppSetNegativeDeviceTier(t *testing.T) {
+	PrepareForTestWithJavaDefaultModules.
+		ExtendWithErrorHandler(android.FixtureExpectsAtLeastOneErrorMatchingPattern(
+			`device_tier: must not be negative, got -1`)).
+		RunTestWithBp(t, `
+			android_app_set {
+				name: "foo",
+				set: "prebuilts/apks/app.apks",
+				device_tier: -1,
+			}`)
+}
@@ -708,6 +708,15 @@ func TestAppJavaResources(t *testing.T) {
 		t.Errorf("expected aligned dex jar %q in foo apk inputs %q", w, g)
 	}
 
+	// signapk aligns the uncompressed entries of the signed apk, including native libraries.
+	fooSignedApk := foo.Output("foo.apk")
+	if g, w := fooSignedApk.Rule.String(), "signapk"; !strings.Contains(g, w) {
+		t.Errorf("expected foo apk to be signed by %q, got %q", w, g)
+	}
+	if g, w := fooSignedApk.Input.String(), fooApk.Output.String(); g != w {
+		t.Errorf("expected unsigned apk %q in foo signed apk inputs %q", w, g)
+	}
+
 	bar := ctx.ModuleForTests("bar", "android_common")
 	barResources := bar.Output("res/bar.jar")
 	barApk := bar.Rule("combineApk")
@@ -623,14 +623,14 @@ func initJavaModule(module android.DefaultableModule, hod android.HostOrDeviceSu
 
 func (j *Module) shouldInstrument(ctx android.BaseModuleContext) bool {
 	return j.properties.Instrument &&
-		ctx.Config().IsEnvTrue("EMMA_INSTRUMENT") &&
+		ctx.Config().EnvBool("EMMA_INSTRUMENT") &&
 		ctx.DeviceConfig().JavaCoverageEnabledForPath(ctx.ModuleDir())
 }
 
 func (j *Module) shouldInstrumentStatic(ctx android.BaseModuleContext) bool {
 	return j.properties.Supports_static_instrumentation &&
 		j.shouldInstrument(ctx) &&
-		(ctx.Config().IsEnvTrue("EMMA_INSTRUMENT_STATIC") ||
+		(ctx.Config().EnvBool("EMMA_INSTRUMENT_STATIC") ||
 			ctx.Config().UnbundledBuild())
 }
 
@@ -643,7 +643,7 @@ func (j *Module) shouldInstrumentInApex(ctx android.BaseModuleContext) bool {
 	if j.DirectlyInAnyApex() && !isJacocoAgent && !apexInfo.IsForPlatform() {
 		if !inList(ctx.ModuleName(), config.InstrumentFrameworkModules) {
 			return true
-		} else if ctx.Config().IsEnvTrue("EMMA_INSTRUMENT_FRAMEWORK") {
+		} else if ctx.Config().EnvBool("EMMA_INSTRUMENT_FRAMEWORK") {
 			return true
 		}
 	}
@@ -787,7 +787,7 @@ func (j *Module) deps(ctx android.BottomUpMutatorContext) {
 	// static dependency on jacoco, otherwise there would be multiple conflicting definitions of
 	// the same jacoco classes coming from different bootclasspath jars.
 	if inList(ctx.ModuleName(), config.InstrumentFrameworkModules) {
-		if ctx.Config().IsEnvTrue("EMMA_INSTRUMENT_FRAMEWORK") {
+		if ctx.Config().EnvBool("EMMA_INSTRUMENT_FRAMEWORK") {
 			j.properties.Instrument = true
 		}
 	} else if j.shouldInstrumentStatic(ctx) {
@@ -932,7 +932,7 @@ func (j *Module) collectBuilderFlags(ctx android.ModuleContext, deps deps) javaB
 		// b) references to existing APIs are not reinterpreted in an
 		//    OpenJDK 9-specific way, eg. calls to subclasses of
 		//    java.nio.Buffer as in http://b/70862583
-		java8Home := ctx.Config().Getenv("ANDROID_JAVA8_HOME")
+		java8Home := ctx.Config().EnvString("ANDROID_JAVA8_HOME")
 		flags.bootClasspath = append(flags.bootClasspath,
 			android.PathForSource(ctx, java8Home, "jre/lib/jce.jar"),
 			android.PathForSource(ctx, java8Home, "jre/lib/rt.jar"))
@@ -1189,7 +1189,7 @@ func (j *Module) compile(ctx android.ModuleContext, aaptSrcJar android.Path) {
 
 	enableSharding := false
 	var headerJarFileWithoutDepsOrJarjar android.Path
-	if ctx.Device() && !ctx.Config().IsEnvFalse("TURBINE_ENABLED") && !disableTurbine {
+	if ctx.Device() && ctx.Config().EnvBool("TURBINE_ENABLED") && !disableTurbine {
 		if j.properties.Javac_shard_size != nil && *(j.properties.Javac_shard_size) > 0 {
 			enableSharding = true
 			// Formerly, there was a check here that prevented annotation processors
@@ -1336,7 +1336,7 @@ func (j *Module) compile(ctx android.ModuleContext, aaptSrcJar android.Path) {
 		args := map[string]string{
 			"jarArgs": "-P META-INF/services/ " + strings.Join(proptools.NinjaAndShellEscapeList(zipargs), " "),
 		}
-		if ctx.Config().UseRBE() && ctx.Config().IsEnvTrue("RBE_ZIP") {
+		if ctx.Config().UseRBE() && ctx.Config().EnvBool("RBE_ZIP") {
 			rule = zipRE
 			args["implicits"] = strings.Join(services.Strings(), ",")
 		}
@@ -1762,6 +1762,14 @@ func (j *Module) IDEInfo(dpInfo *android.IdeInfo) {
 	dpInfo.Libs = append(dpInfo.Libs, j.properties.Libs...)
 }
 
+var _ android.IdeGeneratedSources = (*Module)(nil)
+
+// IdeGeneratedSources returns the srcjars generated from aidl, proto and other sources, which must
+// be built before an IDE can index the module.
+func (j *Module) IdeGeneratedSources() android.Paths {
+	return j.compiledSrcJars
+}
+
 func (j *Module) CompilerDeps() []string {
 	jdeps := []string{}
 	jdeps = append(jdeps, j.properties.Libs...)
@@ -286,7 +286,7 @@ func bootclasspathFragmentFactory() android.Module {
 	android.AddLoadHook(m, func(ctx android.LoadHookContext) {
 		// If code coverage has been enabled for the framework then append the properties with
 		// coverage specific properties.
-		if ctx.Config().IsEnvTrue("EMMA_INSTRUMENT_FRAMEWORK") {
+		if ctx.Config().EnvBool("EMMA_INSTRUMENT_FRAMEWORK") {
 			err := proptools.AppendProperties(&m.properties.BootclasspathFragmentCoverageAffectedProperties, &m.properties.Coverage, nil)
 			if err != nil {
 				ctx.PropertyErrorf("coverage", "error trying to append coverage specific properties: %s", err)
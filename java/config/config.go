@@ -122,17 +122,17 @@ func init() {
 
 	pctx.VariableFunc("JavaHome", func(ctx android.PackageVarContext) string {
 		// This is set up and guaranteed by soong_ui
-		return ctx.Config().Getenv("ANDROID_JAVA_HOME")
+		return ctx.Config().EnvString("ANDROID_JAVA_HOME")
 	})
 	pctx.VariableFunc("Java11Home", func(ctx android.PackageVarContext) string {
 		// This is set up and guaranteed by soong_ui
-		return ctx.Config().Getenv("ANDROID_JAVA11_HOME")
+		return ctx.Config().EnvString("ANDROID_JAVA11_HOME")
 	})
 	pctx.VariableFunc("JlinkVersion", func(ctx android.PackageVarContext) string {
-		if override := ctx.Config().Getenv("OVERRIDE_JLINK_VERSION_NUMBER"); override != "" {
+		if override := ctx.Config().EnvString("OVERRIDE_JLINK_VERSION_NUMBER"); override != "" {
 			return override
 		}
-		switch ctx.Config().Getenv("EXPERIMENTAL_USE_OPENJDK17_TOOLCHAIN") {
+		switch ctx.Config().EnvString("EXPERIMENTAL_USE_OPENJDK17_TOOLCHAIN") {
 		case "true":
 			return "17"
 		default:
@@ -188,14 +188,23 @@ func init() {
 	pctx.HostBinToolVariable("SoongJavacWrapper", "soong_javac_wrapper")
 	pctx.HostBinToolVariable("DexpreoptGen", "dexpreopt_gen")
 
-	pctx.StaticVariableWithEnvOverride("REJavaPool", "RBE_JAVA_POOL", "java16")
-	pctx.StaticVariableWithEnvOverride("REJavacExecStrategy", "RBE_JAVAC_EXEC_STRATEGY", remoteexec.RemoteLocalFallbackExecStrategy)
-	pctx.StaticVariableWithEnvOverride("RED8ExecStrategy", "RBE_D8_EXEC_STRATEGY", remoteexec.RemoteLocalFallbackExecStrategy)
-	pctx.StaticVariableWithEnvOverride("RER8ExecStrategy", "RBE_R8_EXEC_STRATEGY", remoteexec.RemoteLocalFallbackExecStrategy)
-	pctx.StaticVariableWithEnvOverride("RETurbineExecStrategy", "RBE_TURBINE_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
-	pctx.StaticVariableWithEnvOverride("RESignApkExecStrategy", "RBE_SIGNAPK_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
-	pctx.StaticVariableWithEnvOverride("REJarExecStrategy", "RBE_JAR_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
-	pctx.StaticVariableWithEnvOverride("REZipExecStrategy", "RBE_ZIP_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
+	android.RegisterEnvVar(android.StringEnvVar("RBE_JAVA_POOL", "java16", "java", "RBE worker pool of Java tools."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_JAVAC_EXEC_STRATEGY", remoteexec.RemoteLocalFallbackExecStrategy, "java", "RBE execution strategy of javac."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_D8_EXEC_STRATEGY", remoteexec.RemoteLocalFallbackExecStrategy, "java", "RBE execution strategy of d8."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_R8_EXEC_STRATEGY", remoteexec.RemoteLocalFallbackExecStrategy, "java", "RBE execution strategy of r8."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_TURBINE_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "java", "RBE execution strategy of turbine."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_SIGNAPK_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "java", "RBE execution strategy of signapk."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_JAR_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "java", "RBE execution strategy of jar."))
+	android.RegisterEnvVar(android.StringEnvVar("RBE_ZIP_EXEC_STRATEGY", remoteexec.LocalExecStrategy, "java", "RBE execution strategy of soong_zip."))
+
+	pctx.StaticVariableWithEnvOverride("REJavaPool", "RBE_JAVA_POOL")
+	pctx.StaticVariableWithEnvOverride("REJavacExecStrategy", "RBE_JAVAC_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("RED8ExecStrategy", "RBE_D8_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("RER8ExecStrategy", "RBE_R8_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("RETurbineExecStrategy", "RBE_TURBINE_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("RESignApkExecStrategy", "RBE_SIGNAPK_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("REJarExecStrategy", "RBE_JAR_EXEC_STRATEGY")
+	pctx.StaticVariableWithEnvOverride("REZipExecStrategy", "RBE_ZIP_EXEC_STRATEGY")
 
 	pctx.HostJavaToolVariable("JacocoCLIJar", "jacoco-cli.jar")
 
@@ -318,7 +327,7 @@ var javaHomeKey = android.NewOnceKey("javaHome")
 func javaHome(ctx android.PathContext) android.SourcePath {
 	return ctx.Config().OnceSourcePath(javaHomeKey, func() android.SourcePath {
 		// This is set up and guaranteed by soong_ui
-		return android.PathForSource(ctx, ctx.Config().Getenv("ANDROID_JAVA_HOME"))
+		return android.PathForSource(ctx, ctx.Config().EnvString("ANDROID_JAVA_HOME"))
 	})
 }
 
@@ -327,6 +336,6 @@ var java11HomeKey = android.NewOnceKey("java11Home")
 func java11Home(ctx android.PathContext) android.SourcePath {
 	return ctx.Config().OnceSourcePath(java11HomeKey, func() android.SourcePath {
 		// This is set up and guaranteed by soong_ui
-		return android.PathForSource(ctx, ctx.Config().Getenv("ANDROID_JAVA11_HOME"))
+		return android.PathForSource(ctx, ctx.Config().EnvString("ANDROID_JAVA11_HOME"))
 	})
 }
@@ -634,7 +634,7 @@ func buildBootImageVariant(ctx android.ModuleContext, image *bootImageVariant, p
 
 	cmd := rule.Command()
 
-	extraFlags := ctx.Config().Getenv("ART_BOOT_IMAGE_EXTRA_ARGS")
+	extraFlags := ctx.Config().EnvString("ART_BOOT_IMAGE_EXTRA_ARGS")
 	if extraFlags == "" {
 		// Use ANDROID_LOG_TAGS to suppress most logging by default...
 		cmd.Text(`ANDROID_LOG_TAGS="*:e"`)
@@ -474,7 +474,7 @@ func (d *Droidstubs) apiLevelsGenerationFlags(ctx android.ModuleContext, cmd *an
 }
 
 func metalavaUseRbe(ctx android.ModuleContext) bool {
-	return ctx.Config().UseRBE() && ctx.Config().IsEnvTrue("RBE_METALAVA")
+	return ctx.Config().UseRBE() && ctx.Config().EnvBool("RBE_METALAVA")
 }
 
 func metalavaCmd(ctx android.ModuleContext, rule *android.RuleBuilder, javaVersion javaVersion, srcs android.Paths,
@@ -487,10 +487,10 @@ func metalavaCmd(ctx android.ModuleContext, rule *android.RuleBuilder, javaVersi
 
 	if metalavaUseRbe(ctx) {
 		rule.Remoteable(android.RemoteRuleSupports{RBE: true})
-		execStrategy := ctx.Config().GetenvWithDefault("RBE_METALAVA_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
+		execStrategy := ctx.Config().EnvString("RBE_METALAVA_EXEC_STRATEGY")
 		labels := map[string]string{"type": "tool", "name": "metalava"}
 		// TODO: metalava pool rejects these jobs
-		pool := ctx.Config().GetenvWithDefault("RBE_METALAVA_POOL", "java16")
+		pool := ctx.Config().EnvString("RBE_METALAVA_POOL")
 		rule.Rewrapper(&remoteexec.REParams{
 			Labels:          labels,
 			ExecStrategy:    execStrategy,
@@ -247,7 +247,7 @@ func hiddenAPIComputeMonolithicStubLibModules(config android.Config) map[*Hidden
 	publicStubModules = append(publicStubModules, config.ProductHiddenAPIStubs()...)
 	systemStubModules = append(systemStubModules, config.ProductHiddenAPIStubsSystem()...)
 	testStubModules = append(testStubModules, config.ProductHiddenAPIStubsTest()...)
-	if config.IsEnvTrue("EMMA_INSTRUMENT") {
+	if config.EnvBool("EMMA_INSTRUMENT") {
 		// Add jacoco-stubs to public, system and test. It doesn't make any real difference as public
 		// allows everyone access but it is needed to ensure consistent flags between the
 		// bootclasspath fragment generated flags and the platform_bootclasspath generated flags.
@@ -122,7 +122,7 @@ type hiddenAPISingleton struct {
 // hiddenAPI singleton rules
 func (h *hiddenAPISingleton) GenerateBuildActions(ctx android.SingletonContext) {
 	// Don't run any hiddenapi rules if UNSAFE_DISABLE_HIDDENAPI_FLAGS=true
-	if ctx.Config().IsEnvTrue("UNSAFE_DISABLE_HIDDENAPI_FLAGS") {
+	if ctx.Config().EnvBool("UNSAFE_DISABLE_HIDDENAPI_FLAGS") {
 		return
 	}
 
@@ -19,11 +19,14 @@ import (
 	"fmt"
 
 	"android/soong/android"
+	"android/soong/shared"
 )
 
 // This singleton generates android java dependency into to a json file. It does so for each
 // blueprint Android.bp resulting in a java.Module when either make, mm, mma, mmm or mmma is
-// called. Dependency info file is generated in $OUT/module_bp_java_depend.json.
+// called. Dependency info file is generated in $OUT/module_bp_java_depend.json. The IDE project
+// export (see android/ide_export.go) also writes a module_bp_java_deps.json file restricted to the
+// exported modules.
 
 func init() {
 	android.RegisterSingletonType("jdeps_generator", jDepsGeneratorSingleton)
@@ -47,6 +50,14 @@ func (j *jdepsGeneratorSingleton) GenerateBuildActions(ctx android.SingletonCont
 	// (b/204397180) Generate module_bp_java_deps.json by default.
 	moduleInfos := make(map[string]android.IdeInfo)
 
+	// The IDE project export only includes the modules in the requested directories and their
+	// dependencies.
+	var exportModules map[android.Module]bool
+	exportNames := make(map[string]bool)
+	if android.IdeExportEnabled(ctx.Config()) {
+		exportModules = android.IdeExportModules(ctx)
+	}
+
 	ctx.VisitAllModules(func(module android.Module) {
 		if !module.Enabled() {
 			return
@@ -62,6 +73,10 @@ func (j *jdepsGeneratorSingleton) GenerateBuildActions(ctx android.SingletonCont
 			name = ideModuleNameProvider.IDECustomizedModuleName()
 		}
 
+		if exportModules[module] {
+			exportNames[name] = true
+		}
+
 		dpInfo := moduleInfos[name]
 		ideInfoProvider.IDEInfo(&dpInfo)
 		dpInfo.Deps = android.FirstUniqueStrings(dpInfo.Deps)
@@ -105,6 +120,17 @@ func (j *jdepsGeneratorSingleton) GenerateBuildActions(ctx android.SingletonCont
 		Rule:   android.Touch,
 		Output: jfpath,
 	})
+
+	if exportModules != nil {
+		exportInfos := make(map[string]android.IdeInfo)
+		for name := range exportNames {
+			exportInfos[name] = moduleInfos[name]
+		}
+		err := createJsonFile(exportInfos, android.PathForIdeExport(ctx, shared.IdeExportJavaDepsFile))
+		if err != nil {
+			ctx.Errorf(err.Error())
+		}
+	}
 }
 
 func (j *jdepsGeneratorSingleton) MakeVars(ctx android.MakeVarsContext) {
@@ -190,8 +190,8 @@ func (l *linter) deps(ctx android.BottomUpMutatorContext) {
 
 	extraCheckModules := l.properties.Lint.Extra_check_modules
 
-	if checkOnly := ctx.Config().Getenv("ANDROID_LINT_CHECK"); checkOnly != "" {
-		if checkOnlyModules := ctx.Config().Getenv("ANDROID_LINT_CHECK_EXTRA_MODULES"); checkOnlyModules != "" {
+	if checkOnly := ctx.Config().EnvString("ANDROID_LINT_CHECK"); checkOnly != "" {
+		if checkOnlyModules := ctx.Config().EnvString("ANDROID_LINT_CHECK_EXTRA_MODULES"); checkOnlyModules != "" {
 			extraCheckModules = strings.Split(checkOnlyModules, ",")
 		}
 	}
@@ -211,7 +211,7 @@ type lintPaths struct {
 }
 
 func lintRBEExecStrategy(ctx android.ModuleContext) string {
-	return ctx.Config().GetenvWithDefault("RBE_LINT_EXEC_STRATEGY", remoteexec.LocalExecStrategy)
+	return ctx.Config().EnvString("RBE_LINT_EXEC_STRATEGY")
 }
 
 func (l *linter) writeLintProjectXML(ctx android.ModuleContext, rule *android.RuleBuilder, srcsList android.Path) lintPaths {
@@ -373,8 +373,8 @@ func (l *linter) lint(ctx android.ModuleContext) {
 			android.PathForModuleOut(ctx, "lint.sbox.textproto")).
 		SandboxInputs()
 
-	if ctx.Config().UseRBE() && ctx.Config().IsEnvTrue("RBE_LINT") {
-		pool := ctx.Config().GetenvWithDefault("RBE_LINT_POOL", "java16")
+	if ctx.Config().UseRBE() && ctx.Config().EnvBool("RBE_LINT") {
+		pool := ctx.Config().EnvString("RBE_LINT_POOL")
 		rule.Remoteable(android.RemoteRuleSupports{RBE: true})
 		rule.Rewrapper(&remoteexec.REParams{
 			Labels:          map[string]string{"type": "tool", "name": "lint"},
@@ -462,7 +462,7 @@ func (l *linter) lint(ctx android.ModuleContext) {
 	rule.Temporary(lintPaths.projectXML)
 	rule.Temporary(lintPaths.configXML)
 
-	if checkOnly := ctx.Config().Getenv("ANDROID_LINT_CHECK"); checkOnly != "" {
+	if checkOnly := ctx.Config().EnvString("ANDROID_LINT_CHECK"); checkOnly != "" {
 		cmd.FlagWithArg("--check ", checkOnly)
 	}
 
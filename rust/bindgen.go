@@ -33,7 +33,7 @@ var (
 	bindgenClangVersion = "clang-r450784d"
 
 	_ = pctx.VariableFunc("bindgenClangVersion", func(ctx android.PackageVarContext) string {
-		if override := ctx.Config().Getenv("LLVM_BINDGEN_PREBUILTS_VERSION"); override != "" {
+		if override := ctx.Config().EnvString("LLVM_BINDGEN_PREBUILTS_VERSION"); override != "" {
 			return override
 		}
 		return bindgenClangVersion
@@ -240,7 +240,7 @@ func (b *bindgenDecorator) GenerateSource(ctx ModuleContext, deps PathDeps) andr
 	}
 
 	// LLVM_NEXT may contain flags that bindgen doesn't recognise. Turn off unknown flags warning.
-	if ctx.Config().IsEnvTrue("LLVM_NEXT") {
+	if ctx.Config().EnvBool("LLVM_NEXT") {
 		cflags = append(cflags, "-Wno-unknown-warning-option")
 	}
 
@@ -28,12 +28,16 @@ var (
 	_     = pctx.SourcePathVariable("rustcCmd", "${config.RustBin}/rustc")
 	rustc = pctx.AndroidStaticRule("rustc",
 		blueprint.RuleParams{
-			Command: "$envVars $rustcCmd " +
+			// rustc_sarif prints the diagnostics of rustc and records them in $sarifFile. The printed
+			// diagnostics keep the colors of --color always, which ninja strips if it is not running
+			// in a terminal.
+			Command: "$envVars $rustcSarifCmd -o $sarifFile -- $rustcCmd " +
+				"--error-format=json --json=diagnostic-rendered-ansi " +
 				"-C linker=${config.RustLinker} " +
 				"-C link-args=\"${crtBegin} ${config.RustLinkerArgs} ${linkFlags} ${crtEnd}\" " +
 				"--emit link -o $out --emit dep-info=$out.d.raw $in ${libFlags} $rustcFlags" +
 				" && grep \"^$out:\" $out.d.raw > $out.d",
-			CommandDeps: []string{"$rustcCmd"},
+			CommandDeps: []string{"$rustcCmd", "$rustcSarifCmd"},
 			// Rustc deps-info writes out make compatible dep files: https://github.com/rust-lang/rust/issues/7633
 			// Rustc emits unneeded dependency lines for the .d and input .rs files.
 			// Those extra lines cause ninja warning:
@@ -42,7 +46,7 @@ var (
 			Deps:    blueprint.DepsGCC,
 			Depfile: "$out.d",
 		},
-		"rustcFlags", "linkFlags", "libFlags", "crtBegin", "crtEnd", "envVars")
+		"rustcFlags", "linkFlags", "libFlags", "crtBegin", "crtEnd", "envVars", "sarifFile")
 
 	_       = pctx.SourcePathVariable("rustdocCmd", "${config.RustBin}/rustdoc")
 	rustdoc = pctx.AndroidStaticRule("rustdoc",
@@ -56,18 +60,28 @@ var (
 	_            = pctx.SourcePathVariable("clippyCmd", "${config.RustBin}/clippy-driver")
 	clippyDriver = pctx.AndroidStaticRule("clippy",
 		blueprint.RuleParams{
-			Command: "$envVars $clippyCmd " +
+			Command: "$envVars $rustcSarifCmd -tool clippy -o ${out}.sarif -- $clippyCmd " +
+				"--error-format=json --json=diagnostic-rendered-ansi " +
 				// Because clippy-driver uses rustc as backend, we need to have some output even during the linting.
 				// Use the metadata output as it has the smallest footprint.
 				"--emit metadata -o $out --emit dep-info=$out.d.raw $in ${libFlags} " +
 				"$rustcFlags $clippyFlags" +
 				" && grep \"^$out:\" $out.d.raw > $out.d",
-			CommandDeps: []string{"$clippyCmd"},
+			CommandDeps: []string{"$clippyCmd", "$rustcSarifCmd"},
 			Deps:        blueprint.DepsGCC,
 			Depfile:     "$out.d",
 		},
 		"rustcFlags", "libFlags", "clippyFlags", "envVars")
 
+	// Merges the diagnostics recorded by rustc_sarif into a report, see cmd/rustc_sarif.
+	diagnosticsMerge = pctx.AndroidStaticRule("diagnosticsMerge",
+		blueprint.RuleParams{
+			Command:        "$rustcSarifCmd -merge -o $out $flags @$out.rsp",
+			CommandDeps:    []string{"$rustcSarifCmd"},
+			Rspfile:        "$out.rsp",
+			RspfileContent: "$in",
+		}, "flags")
+
 	zip = pctx.AndroidStaticRule("zip",
 		blueprint.RuleParams{
 			Command:        "cat $out.rsp | tr ' ' '\\n' | tr -d \\' | sort -u > ${out}.tmp && ${SoongZipCmd} -o ${out} -C $$OUT_DIR -l ${out}.tmp",
@@ -112,12 +126,14 @@ var (
 )
 
 type buildOutput struct {
-	outputFile android.Path
-	kytheFile  android.Path
+	outputFile        android.Path
+	kytheFile         android.Path
+	diagnosticsReport android.Path
 }
 
 func init() {
 	pctx.HostBinToolVariable("SoongZipCmd", "soong_zip")
+	pctx.HostBinToolVariable("rustcSarifCmd", "rustc_sarif")
 }
 
 func TransformSrcToBinary(ctx ModuleContext, mainSrc android.Path, deps PathDeps, flags Flags,
@@ -244,7 +260,7 @@ func transformSrctoCrate(ctx ModuleContext, main android.Path, deps PathDeps, fl
 	rustcFlags = append(rustcFlags, "--sysroot=/dev/null")
 
 	// Enable incremental compilation if requested by user
-	if ctx.Config().IsEnvTrue("SOONG_RUSTC_INCREMENTAL") {
+	if ctx.Config().EnvBool("SOONG_RUSTC_INCREMENTAL") {
 		incrementalPath := android.PathForOutput(ctx, "rustc").String()
 
 		rustcFlags = append(rustcFlags, "-C incremental="+incrementalPath)
@@ -314,13 +330,16 @@ func transformSrctoCrate(ctx ModuleContext, main android.Path, deps PathDeps, fl
 		}
 	}
 
+	var diagnosticsFiles android.Paths
 	if flags.Clippy {
 		clippyFile := android.PathForModuleOut(ctx, outputFile.Base()+".clippy")
+		clippySarifFile := android.PathForModuleOut(ctx, outputFile.Base()+".clippy.sarif")
+		diagnosticsFiles = append(diagnosticsFiles, clippySarifFile)
 		ctx.Build(pctx, android.BuildParams{
 			Rule:            clippyDriver,
 			Description:     "clippy " + main.Rel(),
 			Output:          clippyFile,
-			ImplicitOutputs: nil,
+			ImplicitOutputs: android.WritablePaths{clippySarifFile},
 			Inputs:          inputs,
 			Implicits:       implicits,
 			Args: map[string]string{
@@ -334,6 +353,17 @@ func transformSrctoCrate(ctx ModuleContext, main android.Path, deps PathDeps, fl
 		implicits = append(implicits, clippyFile)
 	}
 
+	sarifFile := android.PathForModuleOut(ctx, outputFile.Base()+".sarif")
+	implicitOutputs = append(implicitOutputs, sarifFile)
+	diagnosticsFiles = append(diagnosticsFiles, sarifFile)
+
+	output.diagnosticsReport = transformDiagnosticsToReport(ctx, diagnosticsFiles, flags)
+	// With a lints_baseline, the new diagnostics of lints_as_errors fail the build of the crate.
+	var validations android.Paths
+	if flags.LintsBaseline != nil {
+		validations = append(validations, output.diagnosticsReport)
+	}
+
 	ctx.Build(pctx, android.BuildParams{
 		Rule:            rustc,
 		Description:     "rustc " + main.Rel(),
@@ -341,6 +371,7 @@ func transformSrctoCrate(ctx ModuleContext, main android.Path, deps PathDeps, fl
 		ImplicitOutputs: implicitOutputs,
 		Inputs:          inputs,
 		Implicits:       implicits,
+		Validations:     validations,
 		Args: map[string]string{
 			"rustcFlags": strings.Join(rustcFlags, " "),
 			"linkFlags":  strings.Join(linkFlags, " "),
@@ -348,6 +379,7 @@ func transformSrctoCrate(ctx ModuleContext, main android.Path, deps PathDeps, fl
 			"crtBegin":   strings.Join(deps.CrtBegin.Strings(), " "),
 			"crtEnd":     strings.Join(deps.CrtEnd.Strings(), " "),
 			"envVars":    strings.Join(envVars, " "),
+			"sarifFile":  sarifFile.String(),
 		},
 	})
 
@@ -373,6 +405,32 @@ func transformSrctoCrate(ctx ModuleContext, main android.Path, deps PathDeps, fl
 	return output
 }
 
+// transformDiagnosticsToReport merges the diagnostics of rustc and clippy-driver for the crate into
+// the diagnostics.sarif report of the module, compared to the lints_baseline if the module has one.
+func transformDiagnosticsToReport(ctx ModuleContext, diagnosticsFiles android.Paths, flags Flags) android.Path {
+	reportFile := android.PathForModuleOut(ctx, "diagnostics.sarif")
+	reportFlags := []string{"-module " + ctx.ModuleName(), "-dir " + ctx.ModuleDir()}
+	var implicits android.Paths
+	if flags.LintsBaseline != nil {
+		implicits = append(implicits, flags.LintsBaseline)
+		reportFlags = append(reportFlags, "-baseline "+flags.LintsBaseline.String())
+		if len(flags.LintsAsErrors) > 0 {
+			reportFlags = append(reportFlags, "-lints_as_errors "+strings.Join(flags.LintsAsErrors, ","))
+		}
+	}
+	ctx.Build(pctx, android.BuildParams{
+		Rule:        diagnosticsMerge,
+		Description: "rust diagnostics report",
+		Output:      reportFile,
+		Inputs:      diagnosticsFiles,
+		Implicits:   implicits,
+		Args: map[string]string{
+			"flags": strings.Join(reportFlags, " "),
+		},
+	})
+	return reportFile
+}
+
 func Rustdoc(ctx ModuleContext, main android.Path, deps PathDeps,
 	flags Flags) android.ModuleOutPath {
 
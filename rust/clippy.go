@@ -15,9 +15,16 @@
 package rust
 
 import (
+	"strings"
+
+	"android/soong/android"
 	"android/soong/rust/config"
 )
 
+func init() {
+	android.RegisterSingletonType("rust_diagnostics_report", rustDiagnosticsReportFactory)
+}
+
 type ClippyProperties struct {
 	// name of the lint set that should be used to validate this module.
 	//
@@ -27,6 +34,16 @@ type ClippyProperties struct {
 	// relaxed set) and "none" (to disable the execution of clippy).  The
 	// default value is "default". See also the `lints` property.
 	Clippy_lints *string
+
+	// lints whose diagnostics should be treated as errors, e.g. "unused_variables" or
+	// "clippy::needless_return". They are passed to rustc and clippy-driver with -D, or with -W if
+	// the module has a lints_baseline.
+	Lints_as_errors []string
+
+	// SARIF file with the known rustc and clippy diagnostics of the module, usually a copy of the
+	// diagnostics.sarif report of the module. If set, only the diagnostics of lints_as_errors that
+	// are not in the baseline are errors.
+	Lints_baseline *string `android:"path"`
 }
 
 type clippy struct {
@@ -44,5 +61,63 @@ func (c *clippy) flags(ctx ModuleContext, flags Flags, deps PathDeps) (Flags, Pa
 	}
 	flags.Clippy = enabled
 	flags.ClippyFlags = append(flags.ClippyFlags, lints)
+
+	// With a baseline, the lints are only enabled so that the known diagnostics don't fail the
+	// build, and the merge of the diagnostics report fails on the new ones instead.
+	level := "-D "
+	if c.Properties.Lints_baseline != nil {
+		flags.LintsBaseline = android.PathForModuleSrc(ctx, *c.Properties.Lints_baseline)
+		flags.LintsAsErrors = c.Properties.Lints_as_errors
+		level = "-W "
+	}
+	// Clippy lints are passed after the lint set of clippy-driver, which would override them.
+	// Globs like "clippy::*" only apply to the diagnostics report, rustc doesn't accept them.
+	for _, lint := range c.Properties.Lints_as_errors {
+		if strings.Contains(lint, "*") {
+			continue
+		} else if strings.HasPrefix(lint, "clippy::") {
+			flags.ClippyFlags = append(flags.ClippyFlags, level+lint)
+		} else {
+			flags.RustFlags = append(flags.RustFlags, level+lint)
+		}
+	}
 	return flags, deps
 }
+
+func rustDiagnosticsReportFactory() android.Singleton {
+	return &rustDiagnosticsReportSingleton{}
+}
+
+// rustDiagnosticsReportSingleton merges the diagnostics reports of all rust modules into
+// rust/diagnostics.sarif, with the number of diagnostics per directory and module in
+// rust/diagnostics_summary.txt, built by the rust-diagnostics-report phony target.
+type rustDiagnosticsReportSingleton struct{}
+
+func (r *rustDiagnosticsReportSingleton) GenerateBuildActions(ctx android.SingletonContext) {
+	var reports android.Paths
+	ctx.VisitAllModules(func(module android.Module) {
+		if ctx.Config().KatiEnabled() && android.ShouldSkipAndroidMkProcessing(module) {
+			return
+		}
+		if m, ok := module.(*Module); ok && m.diagnosticsReport != nil {
+			reports = append(reports, m.diagnosticsReport)
+		}
+	})
+	if len(reports) == 0 {
+		return
+	}
+
+	reportFile := android.PathForOutput(ctx, "rust", "diagnostics.sarif")
+	summaryFile := android.PathForOutput(ctx, "rust", "diagnostics_summary.txt")
+	ctx.Build(pctx, android.BuildParams{
+		Rule:           diagnosticsMerge,
+		Description:    "rust diagnostics report",
+		Output:         reportFile,
+		ImplicitOutput: summaryFile,
+		Inputs:         reports,
+		Args: map[string]string{
+			"flags": "-summary " + summaryFile.String(),
+		},
+	})
+	ctx.Phony("rust-diagnostics-report", reportFile, summaryFile)
+}
@@ -75,3 +75,66 @@ func TestClippy(t *testing.T) {
 		})
 	}
 }
+
+func TestLintsAsErrors(t *testing.T) {
+	ctx := testRust(t, `
+		rust_library {
+			name: "libfoo",
+			srcs: ["foo.rs"],
+			crate_name: "foo",
+			lints_as_errors: ["unused_variables", "clippy::needless_return"],
+		}`)
+
+	foo := ctx.ModuleForTests("libfoo", "android_arm64_armv8-a_dylib")
+	android.AssertStringDoesContain(t, "rustc flags", foo.Rule("rustc").Args["rustcFlags"], "-D unused_variables")
+	android.AssertStringDoesNotContain(t, "rustc flags", foo.Rule("rustc").Args["rustcFlags"], "clippy::needless_return")
+	android.AssertStringEquals(t, "clippy flags", "${config.ClippyDefaultLints} -D clippy::needless_return",
+		foo.Rule("clippy").Args["clippyFlags"])
+	android.AssertStringEquals(t, "report flags", "-module libfoo -dir .",
+		foo.Rule("diagnosticsMerge").Args["flags"])
+	if validations := foo.Rule("rustc").Validations; len(validations) > 0 {
+		t.Errorf("libfoo without a baseline should not validate its report, got %v", validations)
+	}
+}
+
+func TestLintsBaseline(t *testing.T) {
+	result := android.GroupFixturePreparers(
+		prepareForRustTest,
+		android.FixtureAddTextFile("lints_baseline.sarif", ""),
+		android.FixtureAddTextFile("Android.bp", `
+			rust_library {
+				name: "libfoo",
+				srcs: ["foo.rs"],
+				crate_name: "foo",
+				lints_as_errors: ["unused_variables", "clippy::needless_return", "clippy::*"],
+				lints_baseline: "lints_baseline.sarif",
+			}
+			rust_library {
+				name: "libbar",
+				srcs: ["foo.rs"],
+				crate_name: "bar",
+				clippy_lints: "none",
+			}`),
+	).RunTest(t)
+	variant := "android_arm64_armv8-a_dylib"
+
+	foo := result.ModuleForTests("libfoo", variant)
+	android.AssertStringDoesContain(t, "rustc flags with a baseline", foo.Rule("rustc").Args["rustcFlags"], "-W unused_variables")
+	android.AssertStringEquals(t, "clippy flags with a baseline", "${config.ClippyDefaultLints} -W clippy::needless_return",
+		foo.Rule("clippy").Args["clippyFlags"])
+	report := foo.Rule("diagnosticsMerge")
+	android.AssertStringEquals(t, "report flags",
+		"-module libfoo -dir . -baseline lints_baseline.sarif -lints_as_errors unused_variables,clippy::needless_return,clippy::*",
+		report.Args["flags"])
+	android.AssertStringListContains(t, "libfoo depends on its report",
+		foo.Rule("rustc").Validations.Strings(), report.Output.String())
+
+	// Without clippy, the report only has the diagnostics of rustc.
+	barReport := result.ModuleForTests("libbar", variant).Rule("diagnosticsMerge")
+	android.AssertPathsRelativeToTopEquals(t, "libbar report inputs", []string{
+		"out/soong/.intermediates/libbar/" + variant + "/libbar.dylib.so.sarif",
+	}, barReport.Inputs)
+
+	treeReport := result.SingletonForTests("rust_diagnostics_report").Output("rust/diagnostics.sarif")
+	android.AssertStringListContains(t, "tree report inputs", treeReport.Inputs.Strings(), report.Output.String())
+}
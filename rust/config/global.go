@@ -87,7 +87,7 @@ func init() {
 	})
 
 	pctx.VariableFunc("RustBase", func(ctx android.PackageVarContext) string {
-		if override := ctx.Config().Getenv("RUST_PREBUILTS_BASE"); override != "" {
+		if override := ctx.Config().EnvString("RUST_PREBUILTS_BASE"); override != "" {
 			return override
 		}
 		return "${RustDefaultBase}"
@@ -111,7 +111,7 @@ func getRustVersionPctx(ctx android.PackageVarContext) string {
 }
 
 func GetRustVersion(ctx android.PathContext) string {
-	if override := ctx.Config().Getenv("RUST_PREBUILTS_VERSION"); override != "" {
+	if override := ctx.Config().EnvString("RUST_PREBUILTS_VERSION"); override != "" {
 		return override
 	}
 	return RustDefaultVersion
@@ -81,13 +81,13 @@ var (
 func init() {
 	// Default Rust lints. These apply to all Google-authored modules.
 	pctx.VariableFunc("RustDefaultLints", func(ctx android.PackageVarContext) string {
-		if override := ctx.Config().Getenv("RUST_DEFAULT_LINTS"); override != "" {
+		if override := ctx.Config().EnvString("RUST_DEFAULT_LINTS"); override != "" {
 			return override
 		}
 		return strings.Join(defaultRustcLints, " ")
 	})
 	pctx.VariableFunc("ClippyDefaultLints", func(ctx android.PackageVarContext) string {
-		if override := ctx.Config().Getenv("CLIPPY_DEFAULT_LINTS"); override != "" {
+		if override := ctx.Config().EnvString("CLIPPY_DEFAULT_LINTS"); override != "" {
 			return override
 		}
 		return strings.Join(defaultClippyLints, " ")
@@ -95,13 +95,13 @@ func init() {
 
 	// Rust lints that only applies to external code.
 	pctx.VariableFunc("RustVendorLints", func(ctx android.PackageVarContext) string {
-		if override := ctx.Config().Getenv("RUST_VENDOR_LINTS"); override != "" {
+		if override := ctx.Config().EnvString("RUST_VENDOR_LINTS"); override != "" {
 			return override
 		}
 		return strings.Join(defaultRustcVendorLints, " ")
 	})
 	pctx.VariableFunc("ClippyVendorLints", func(ctx android.PackageVarContext) string {
-		if override := ctx.Config().Getenv("CLIPPY_VENDOR_LINTS"); override != "" {
+		if override := ctx.Config().EnvString("CLIPPY_VENDOR_LINTS"); override != "" {
 			return override
 		}
 		return strings.Join(defaultClippyVendorLints, " ")
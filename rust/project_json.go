@@ -20,6 +20,7 @@ import (
 	"path"
 
 	"android/soong/android"
+	"android/soong/shared"
 )
 
 // This singleton collects Rust crate definitions and generates a JSON file
@@ -29,6 +30,9 @@ import (
 // For example,
 //
 //   $ SOONG_GEN_RUST_PROJECT=1 m nothing
+//
+// The IDE project export (see android/ide_export.go) also writes a rust-project.json file
+// restricted to the exported crates.
 
 const (
 	// Environment variables used to control the behavior of this singleton.
@@ -277,16 +281,33 @@ func (singleton *projectGeneratorSingleton) appendCrateAndDependencies(ctx andro
 }
 
 func (singleton *projectGeneratorSingleton) GenerateBuildActions(ctx android.SingletonContext) {
-	if !ctx.Config().IsEnvTrue(envVariableCollectRustDeps) {
-		return
+	if ctx.Config().EnvBool(envVariableCollectRustDeps) {
+		singleton.generateProject(ctx, android.PathForOutput(ctx, rustProjectJsonFileName),
+			func(android.Module) bool { return true })
 	}
 
+	// The IDE project export only includes the crates in the requested directories and their
+	// dependencies.
+	if android.IdeExportEnabled(ctx.Config()) {
+		modules := android.IdeExportModules(ctx)
+		export := &projectGeneratorSingleton{}
+		export.generateProject(ctx, android.PathForIdeExport(ctx, shared.IdeExportRustProjectFile),
+			func(module android.Module) bool { return modules[module] })
+	}
+}
+
+// generateProject writes a rust-project.json file for the crates that match filter and their
+// dependencies.
+func (singleton *projectGeneratorSingleton) generateProject(ctx android.SingletonContext,
+	path android.WritablePath, filter func(android.Module) bool) {
+
 	singleton.knownCrates = make(map[string]crateInfo)
 	ctx.VisitAllModules(func(module android.Module) {
-		singleton.appendCrateAndDependencies(ctx, module)
+		if filter(module) {
+			singleton.appendCrateAndDependencies(ctx, module)
+		}
 	})
 
-	path := android.PathForOutput(ctx, rustProjectJsonFileName)
 	err := createJsonFile(singleton.project, path)
 	if err != nil {
 		ctx.Errorf(err.Error())
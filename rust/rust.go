@@ -58,7 +58,9 @@ type Flags struct {
 	Toolchain       config.Toolchain
 	Coverage        bool
 	Clippy          bool
-	EmitXrefs       bool // If true, emit rules to aid cross-referencing
+	EmitXrefs       bool         // If true, emit rules to aid cross-referencing
+	LintsBaseline   android.Path // Known rustc and clippy diagnostics, see clippy.go
+	LintsAsErrors   []string     // Lints whose diagnostics are errors unless they are in LintsBaseline
 }
 
 type BaseProperties struct {
@@ -159,6 +161,9 @@ type Module struct {
 	// Cross-reference input file
 	kytheFiles android.Paths
 
+	// Merged rustc and clippy diagnostics of the crate
+	diagnosticsReport android.Path
+
 	docTimestampFile android.OptionalPath
 
 	hideApexVariantFromMake bool
@@ -213,6 +218,17 @@ func (mod *Module) OutputFiles(tag string) (android.Paths, error) {
 	}
 }
 
+var _ android.IdeGeneratedSources = (*Module)(nil)
+
+// IdeGeneratedSources returns the sources generated by a source provider module, which must be
+// built before rust-analyzer can index the crate.
+func (mod *Module) IdeGeneratedSources() android.Paths {
+	if mod.sourceProvider != nil {
+		return mod.sourceProvider.Srcs()
+	}
+	return nil
+}
+
 func (mod *Module) SelectedStl() string {
 	return ""
 }
@@ -931,6 +947,7 @@ func (mod *Module) GenerateAndroidBuildActions(actx android.ModuleContext) {
 		if buildOutput.kytheFile != nil {
 			mod.kytheFiles = append(mod.kytheFiles, buildOutput.kytheFile)
 		}
+		mod.diagnosticsReport = buildOutput.diagnosticsReport
 		bloaty.MeasureSizeForPaths(ctx, mod.compiler.strippedOutputFilePath(), android.OptionalPathForPath(mod.compiler.unstrippedOutputFilePath()))
 
 		mod.docTimestampFile = mod.compiler.rustdoc(ctx, flags, deps)
@@ -0,0 +1,182 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"android/soong/response"
+)
+
+// RuleMatcher matches rules against a comma separated list of globs like the -checks and
+// -warnings-as-errors flags of clang-tidy: the last glob that matches a rule decides whether it
+// matches, and globs prefixed with '-' exclude it.
+type RuleMatcher []ruleGlob
+
+type ruleGlob struct {
+	re       *regexp.Regexp
+	negative bool
+}
+
+func NewRuleMatcher(globs string) RuleMatcher {
+	var m RuleMatcher
+	for _, glob := range strings.Split(globs, ",") {
+		glob = strings.TrimSpace(glob)
+		negative := strings.HasPrefix(glob, "-")
+		glob = strings.TrimPrefix(glob, "-")
+		if glob == "" {
+			continue
+		}
+		pattern := strings.Replace(regexp.QuoteMeta(glob), `\*`, ".*", -1)
+		m = append(m, ruleGlob{regexp.MustCompile("^" + pattern + "$"), negative})
+	}
+	return m
+}
+
+func (m RuleMatcher) Match(rule string) bool {
+	matched := false
+	for _, g := range m {
+		if g.re.MatchString(rule) {
+			matched = !g.negative
+		}
+	}
+	return matched
+}
+
+// ApplyRulesAsErrors turns the results of the rules that match rulesAsErrors into errors, and
+// returns the errors that are not in the baseline.
+func ApplyRulesAsErrors(results []*Result, rulesAsErrors RuleMatcher) []*Result {
+	var newErrors []*Result
+	for _, result := range results {
+		if rulesAsErrors.Match(result.RuleID) {
+			result.Level = LevelError
+		}
+		if result.Level == LevelError && result.IsNew() {
+			newErrors = append(newErrors, result)
+		}
+	}
+	return newErrors
+}
+
+// MergeOptions configures MergeFiles.
+type MergeOptions struct {
+	// The module and its directory to attribute the results to, if not empty.
+	Module, Directory string
+
+	// The file with the known results, if not empty.
+	Baseline string
+
+	// The rules whose results are errors if they are not in the baseline, see RuleMatcher.
+	RulesAsErrors string
+}
+
+// MergeFiles merges the results of the logs in the given files. If a baseline is given, it also
+// returns the errors that are not in the baseline.
+func MergeFiles(files []string, opts MergeOptions) (results, newErrors []*Result, err error) {
+	var logs []*Log
+	for _, file := range files {
+		log, err := ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		logs = append(logs, log)
+	}
+	results = Merge(logs)
+
+	if opts.Module != "" {
+		for _, result := range results {
+			result.Properties = &Properties{Module: opts.Module, Directory: opts.Directory}
+		}
+	}
+
+	if opts.Baseline != "" {
+		baseline, err := ReadFile(opts.Baseline)
+		if err != nil {
+			return nil, nil, err
+		}
+		ApplyBaseline(results, baseline.Results())
+		newErrors = ApplyRulesAsErrors(results, NewRuleMatcher(opts.RulesAsErrors))
+	}
+	return results, newErrors, nil
+}
+
+// WriteSummaryFile writes the summary of the results to the given file, see WriteSummary.
+func WriteSummaryFile(path string, results []*Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteSummary(f, results); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadInputs returns the SARIF files given as arguments, expanding the response files prefixed
+// with '@'.
+func ReadInputs(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "@") {
+			files = append(files, arg)
+			continue
+		}
+		f, err := os.Open(strings.TrimPrefix(arg, "@"))
+		if err != nil {
+			return nil, err
+		}
+		rspFiles, err := response.ReadRspFile(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rspFiles...)
+	}
+	return files, nil
+}
+
+// MergeReport merges the SARIF files into a log of the given tool written to output, and writes
+// the summary of the results to summary if it is not empty. If a baseline is given, the new errors
+// fail the merge after the output is written.
+func MergeReport(tool string, files []string, output, summary string, opts MergeOptions) error {
+	results, newErrors, err := MergeFiles(files, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := NewLog(tool, results).WriteFile(output); err != nil {
+		return err
+	}
+	if summary != "" {
+		if err := WriteSummaryFile(summary, results); err != nil {
+			return err
+		}
+	}
+
+	if len(newErrors) > 0 {
+		var lines []string
+		for _, result := range newErrors {
+			lines = append(lines, result.String())
+		}
+		return fmt.Errorf("%d new %s findings are not in the baseline %s:\n%s\n"+
+			"Fix them, or update the baseline with %s",
+			len(newErrors), tool, opts.Baseline, strings.Join(lines, "\n"), output)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRuleMatcher(t *testing.T) {
+	m := NewRuleMatcher("bugprone-*,-bugprone-macro-*,cert-err34-c,bugprone-macro-parentheses")
+	tests := map[string]bool{
+		"bugprone-use-after-move":    true,
+		"bugprone-macro-repeated":    false,
+		"bugprone-macro-parentheses": true,
+		"cert-err34-c":               true,
+		"cert-err34-cpp":             false,
+		"modernize-use-nullptr":      false,
+	}
+	for rule, want := range tests {
+		if got := m.Match(rule); got != want {
+			t.Errorf("Match(%q): want %v, got %v", rule, want, got)
+		}
+	}
+	if NewRuleMatcher("").Match("bugprone-foo") {
+		t.Errorf("empty matcher matched")
+	}
+}
+
+func TestApplyRulesAsErrors(t *testing.T) {
+	known := NewResult("bugprone-foo", LevelWarning, "known", "a.cpp", 1, 1)
+	newFinding := NewResult("bugprone-foo", LevelWarning, "new", "a.cpp", 2, 1)
+	newWarning := NewResult("modernize-foo", LevelWarning, "new", "a.cpp", 3, 1)
+	results := []*Result{known, newFinding, newWarning}
+	ApplyBaseline(results, []*Result{
+		NewResult("bugprone-foo", LevelWarning, "known", "a.cpp", 10, 1),
+	})
+
+	newErrors := ApplyRulesAsErrors(results, NewRuleMatcher("bugprone-*"))
+	if !reflect.DeepEqual(newErrors, []*Result{newFinding}) {
+		t.Errorf("want only the new bugprone finding, got %v", newErrors)
+	}
+	if known.Level != LevelError {
+		t.Errorf("want the known bugprone finding to be an error, got %s", known.Level)
+	}
+	if newWarning.Level != LevelWarning {
+		t.Errorf("want the modernize finding to stay a warning, got %s", newWarning.Level)
+	}
+}
+
+func TestMergeFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeLog := func(name string, results ...*Result) string {
+		path := filepath.Join(dir, name)
+		if err := NewLog("clang-tidy", results).WriteFile(path); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	foo := writeLog("foo.tidy.sarif",
+		NewResult("bugprone-foo", LevelWarning, "known", "a/foo.cpp", 3, 9),
+		NewResult("cert-err34-c", LevelWarning, "new", "a/foo.h", 7, 5))
+	bar := writeLog("bar.tidy.sarif",
+		NewResult("cert-err34-c", LevelWarning, "new", "a/foo.h", 7, 5))
+	baseline := writeLog("baseline.sarif",
+		NewResult("bugprone-foo", LevelWarning, "known", "a/foo.cpp", 1, 9))
+
+	results, newErrors, err := MergeFiles([]string{foo, bar}, MergeOptions{
+		Module:        "libfoo",
+		Directory:     "a",
+		Baseline:      baseline,
+		RulesAsErrors: "bugprone-*,cert-*",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"a/foo.cpp:3:9: error: known [bugprone-foo] unchanged",
+		"a/foo.h:7:5: error: new [cert-err34-c] new",
+	}
+	if got := resultStrings(results); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if len(newErrors) != 1 || newErrors[0].RuleID != "cert-err34-c" {
+		t.Errorf("want the cert-err34-c finding as the only new error, got %v", newErrors)
+	}
+	for _, result := range results {
+		if *result.Properties != (Properties{Module: "libfoo", Directory: "a"}) {
+			t.Errorf("want the result attributed to libfoo, got %v", *result.Properties)
+		}
+	}
+
+	if _, _, err := MergeFiles([]string{filepath.Join(dir, "missing.sarif")}, MergeOptions{}); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestMergeReport(t *testing.T) {
+	dir := t.TempDir()
+	tu := filepath.Join(dir, "foo.cpp.tidy.sarif")
+	if err := NewLog("clang-tidy", []*Result{
+		NewResult("bugprone-foo", LevelWarning, "new", "a/foo.cpp", 2, 1),
+	}).WriteFile(tu); err != nil {
+		t.Fatal(err)
+	}
+	baseline := filepath.Join(dir, "baseline.sarif")
+	if err := NewLog("clang-tidy", nil).WriteFile(baseline); err != nil {
+		t.Fatal(err)
+	}
+	rsp := filepath.Join(dir, "inputs.rsp")
+	if err := os.WriteFile(rsp, []byte(tu), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ReadInputs([]string{"@" + rsp})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "libfoo.tidy.sarif")
+	summary := filepath.Join(dir, "libfoo.tidy.summary")
+	err = MergeReport("clang-tidy", files, output, summary, MergeOptions{
+		Baseline:      baseline,
+		RulesAsErrors: "bugprone-*",
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 new clang-tidy findings are not in the baseline") {
+		t.Errorf("want an error for the new finding, got %v", err)
+	}
+	for _, file := range []string{output, summary} {
+		if _, err := os.Stat(file); err != nil {
+			t.Errorf("want %s written before failing: %s", file, err)
+		}
+	}
+}
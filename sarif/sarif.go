@@ -0,0 +1,329 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sarif reads and writes the subset of the SARIF 2.1.0 format
+// (https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html) used by the reports of static
+// analysis tools run during the build, and compares reports against baselines.
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+
+	// BaselineNew is the baseline state of a result that is not in the baseline.
+	BaselineNew = "new"
+	// BaselineUnchanged is the baseline state of a result that is in the baseline.
+	BaselineUnchanged = "unchanged"
+)
+
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []*Run `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool      `json:"tool"`
+	Results []*Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name string `json:"name"`
+}
+
+type Result struct {
+	RuleID        string      `json:"ruleId"`
+	Level         string      `json:"level"`
+	Message       Message     `json:"message"`
+	Locations     []Location  `json:"locations,omitempty"`
+	BaselineState string      `json:"baselineState,omitempty"`
+	Properties    *Properties `json:"properties,omitempty"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type Region struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// Properties attributes a result to the module that reported it.
+type Properties struct {
+	Module    string `json:"module,omitempty"`
+	Directory string `json:"directory,omitempty"`
+}
+
+// NewResult returns a result for a finding of the given rule at a line and column of a file.
+func NewResult(ruleID, level, message, file string, line, column int) *Result {
+	return &Result{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: Message{Text: message},
+		Locations: []Location{{
+			PhysicalLocation: PhysicalLocation{
+				ArtifactLocation: ArtifactLocation{URI: file},
+				Region:           &Region{StartLine: line, StartColumn: column},
+			},
+		}},
+	}
+}
+
+// File returns the file of the first location of the result, or an empty string if it has none.
+func (r *Result) File() string {
+	if len(r.Locations) == 0 {
+		return ""
+	}
+	return r.Locations[0].PhysicalLocation.ArtifactLocation.URI
+}
+
+// Line returns the line of the first location of the result, or 0 if it has none.
+func (r *Result) Line() int {
+	if len(r.Locations) == 0 || r.Locations[0].PhysicalLocation.Region == nil {
+		return 0
+	}
+	return r.Locations[0].PhysicalLocation.Region.StartLine
+}
+
+// Column returns the column of the first location of the result, or 0 if it has none.
+func (r *Result) Column() int {
+	if len(r.Locations) == 0 || r.Locations[0].PhysicalLocation.Region == nil {
+		return 0
+	}
+	return r.Locations[0].PhysicalLocation.Region.StartColumn
+}
+
+// String returns the result in the format used by compilers, e.g.
+// "foo.cpp:1:2: warning: message [rule]".
+func (r *Result) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", r.File(), r.Line(), r.Column(), r.Level,
+		r.Message.Text, r.RuleID)
+}
+
+// baselineKey identifies a result in a baseline. It doesn't include the line and column, which
+// change when unrelated lines of the file are edited.
+func (r *Result) baselineKey() string {
+	return r.RuleID + "\x00" + r.File() + "\x00" + r.Message.Text
+}
+
+func (r *Result) sortKey() string {
+	return fmt.Sprintf("%s\x00%08d\x00%08d\x00%s\x00%s", r.File(), r.Line(), r.Column(), r.RuleID,
+		r.Message.Text)
+}
+
+// NewLog returns a log with a single run of the given tool.
+func NewLog(tool string, results []*Result) *Log {
+	if results == nil {
+		results = []*Result{}
+	}
+	return &Log{
+		Schema:  Schema,
+		Version: Version,
+		Runs: []*Run{{
+			Tool:    Tool{Driver: Driver{Name: tool}},
+			Results: results,
+		}},
+	}
+}
+
+// Results returns the results of all the runs of the log.
+func (l *Log) Results() []*Result {
+	var results []*Result
+	for _, run := range l.Runs {
+		results = append(results, run.Results...)
+	}
+	return results
+}
+
+// Read parses a log.
+func Read(r io.Reader) (*Log, error) {
+	var log Log
+	if err := json.NewDecoder(r).Decode(&log); err != nil {
+		return nil, err
+	}
+	if log.Version != Version {
+		return nil, fmt.Errorf("unsupported SARIF version %q", log.Version)
+	}
+	return &log, nil
+}
+
+// ReadFile parses the log in the given file.
+func ReadFile(path string) (*Log, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	log, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	return log, nil
+}
+
+// Write writes the log as indented JSON.
+func (l *Log) Write(w io.Writer) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// WriteFile writes the log to the given file.
+func (l *Log) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := l.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Merge returns the results of all the logs, sorted by location, without duplicates. The same
+// finding in a header is reported for every translation unit of a module that includes it, only
+// findings of different modules are kept.
+func Merge(logs []*Log) []*Result {
+	seen := make(map[string]bool)
+	var results []*Result
+	for _, log := range logs {
+		for _, result := range log.Results() {
+			key := result.sortKey()
+			if result.Properties != nil {
+				key += "\x00" + result.Properties.Directory + "\x00" + result.Properties.Module
+			}
+			if !seen[key] {
+				seen[key] = true
+				results = append(results, result)
+			}
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].sortKey() < results[j].sortKey()
+	})
+	return results
+}
+
+// ApplyBaseline sets the baseline state of each result to BaselineUnchanged if it matches a result
+// of the baseline, and to BaselineNew otherwise. Results match if they have the same rule, file
+// and message, each result of the baseline matches at most one result.
+func ApplyBaseline(results []*Result, baseline []*Result) {
+	counts := make(map[string]int)
+	for _, result := range baseline {
+		counts[result.baselineKey()]++
+	}
+	for _, result := range results {
+		key := result.baselineKey()
+		if counts[key] > 0 {
+			counts[key]--
+			result.BaselineState = BaselineUnchanged
+		} else {
+			result.BaselineState = BaselineNew
+		}
+	}
+}
+
+// IsNew returns true if the result is not in the baseline, or if no baseline was applied.
+func (r *Result) IsNew() bool {
+	return r.BaselineState != BaselineUnchanged
+}
+
+type summaryCounts struct {
+	errors, warnings, new int
+}
+
+func (c *summaryCounts) add(result *Result) {
+	if result.Level == LevelError {
+		c.errors++
+	} else {
+		c.warnings++
+	}
+	if result.IsNew() {
+		c.new++
+	}
+}
+
+// WriteSummary writes the number of errors, warnings and new results, i.e. results that are not
+// in the baseline, per directory and per module.
+func WriteSummary(w io.Writer, results []*Result) error {
+	directories := make(map[string]*summaryCounts)
+	modules := make(map[string]*summaryCounts)
+	count := func(m map[string]*summaryCounts, key string, result *Result) {
+		if m[key] == nil {
+			m[key] = &summaryCounts{}
+		}
+		m[key].add(result)
+	}
+	for _, result := range results {
+		var module, directory string
+		if result.Properties != nil {
+			module, directory = result.Properties.Module, result.Properties.Directory
+		}
+		count(directories, directory, result)
+		count(modules, directory+":"+module, result)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	writeCounts := func(title string, m map[string]*summaryCounts) {
+		fmt.Fprintf(tw, "ERRORS\tWARNINGS\tNEW\t%s\n", title)
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			c := m[key]
+			fmt.Fprintf(tw, "%d\t%d\t%d\t%s\n", c.errors, c.warnings, c.new, key)
+		}
+	}
+	writeCounts("DIRECTORY", directories)
+	fmt.Fprintln(tw)
+	writeCounts("MODULE", modules)
+	return tw.Flush()
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func resultStrings(results []*Result) []string {
+	var ret []string
+	for _, result := range results {
+		ret = append(ret, result.String()+" "+result.BaselineState)
+	}
+	return ret
+}
+
+func TestReadWrite(t *testing.T) {
+	log := NewLog("clang-tidy", []*Result{
+		NewResult("bugprone-foo", LevelWarning, "foo", "a/foo.cpp", 1, 2),
+	})
+	buf := &bytes.Buffer{}
+	if err := log.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(log, got) {
+		t.Errorf("want %#v, got %#v", log, got)
+	}
+
+	if _, err := Read(bytes.NewBufferString(`{"version": "1.0.0"}`)); err == nil {
+		t.Errorf("expected an error for an unsupported version")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	header := NewResult("bugprone-foo", LevelWarning, "foo", "a/foo.h", 10, 1)
+	log1 := NewLog("clang-tidy", []*Result{
+		NewResult("bugprone-foo", LevelWarning, "foo", "a/foo.cpp", 3, 1),
+		header,
+	})
+	log2 := NewLog("clang-tidy", []*Result{
+		NewResult("bugprone-bar", LevelError, "bar", "a/bar.cpp", 1, 1),
+		header,
+	})
+
+	want := []string{
+		"a/bar.cpp:1:1: error: bar [bugprone-bar] ",
+		"a/foo.cpp:3:1: warning: foo [bugprone-foo] ",
+		"a/foo.h:10:1: warning: foo [bugprone-foo] ",
+	}
+	if got := resultStrings(Merge([]*Log{log1, log2})); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestApplyBaseline(t *testing.T) {
+	baseline := []*Result{
+		NewResult("bugprone-foo", LevelWarning, "foo", "a/foo.cpp", 3, 1),
+		NewResult("bugprone-bar", LevelWarning, "bar", "a/foo.cpp", 5, 1),
+	}
+	results := []*Result{
+		// Moved by an edit above it.
+		NewResult("bugprone-foo", LevelWarning, "foo", "a/foo.cpp", 13, 1),
+		// The second identical finding is new.
+		NewResult("bugprone-foo", LevelWarning, "foo", "a/foo.cpp", 20, 1),
+		NewResult("bugprone-bar", LevelError, "bar", "a/bar.cpp", 5, 1),
+	}
+	ApplyBaseline(results, baseline)
+
+	want := []string{
+		"a/foo.cpp:13:1: warning: foo [bugprone-foo] unchanged",
+		"a/foo.cpp:20:1: warning: foo [bugprone-foo] new",
+		"a/bar.cpp:5:1: error: bar [bugprone-bar] new",
+	}
+	if got := resultStrings(results); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	result := func(level, module, dir, state string) *Result {
+		r := NewResult("bugprone-foo", level, "foo", dir+"/foo.cpp", 1, 1)
+		r.Properties = &Properties{Module: module, Directory: dir}
+		r.BaselineState = state
+		return r
+	}
+	results := []*Result{
+		result(LevelWarning, "libfoo", "a", BaselineUnchanged),
+		result(LevelError, "libfoo", "a", BaselineNew),
+		result(LevelWarning, "libbar", "a", ""),
+		result(LevelWarning, "libbaz", "b", BaselineUnchanged),
+	}
+
+	want := "" +
+		"ERRORS  WARNINGS  NEW  DIRECTORY\n" +
+		"1       2         2    a\n" +
+		"0       1         0    b\n" +
+		"\n" +
+		"ERRORS  WARNINGS  NEW  MODULE\n" +
+		"0       1         1    a:libbar\n" +
+		"1       1         1    a:libfoo\n" +
+		"0       1         0    b:libbaz\n"
+
+	buf := &bytes.Buffer{}
+	if err := WriteSummary(buf, results); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -1989,7 +1989,7 @@ func (s *sdk) createMemberSnapshot(ctx *memberContext, member *sdkMember, bpModu
 		// snapshot to be created that sets prefer: true.
 		// TODO(b/174997203): Remove once the ability to select the modules to prefer can be done
 		//  dynamically at build time not at snapshot generation time.
-		prefer := config.IsEnvTrue("SOONG_SDK_SNAPSHOT_PREFER")
+		prefer := config.EnvBool("SOONG_SDK_SNAPSHOT_PREFER")
 
 		// Set prefer. Setting this to false is not strictly required as that is the default but it does
 		// provide a convenient hook to post-process the generated Android.bp file, e.g. in tests to
@@ -1997,7 +1997,7 @@ func (s *sdk) createMemberSnapshot(ctx *memberContext, member *sdkMember, bpModu
 		// behavior is for the module.
 		bpModule.insertAfter("name", "prefer", prefer)
 
-		configVar := config.Getenv("SOONG_SDK_SNAPSHOT_USE_SOURCE_CONFIG_VAR")
+		configVar := config.EnvString("SOONG_SDK_SNAPSHOT_USE_SOURCE_CONFIG_VAR")
 		if configVar != "" {
 			parts := strings.Split(configVar, ":")
 			cfp := android.ConfigVarProperties{
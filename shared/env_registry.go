@@ -0,0 +1,190 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The registry of environment variables that affect the build is declared in android/config.go.
+// soong_build writes it to EnvRegistryFile so that soong_ui, which doesn't link the android
+// package, can list the variables and validate the environment.
+
+// EnvRegistryFile is the name of the file in the Soong output directory that contains the registry.
+const EnvRegistryFile = "soong.environment.registry"
+
+// EnvVarType is the type of the value of an environment variable.
+type EnvVarType string
+
+const (
+	EnvString EnvVarType = "string"
+	EnvBool   EnvVarType = "bool"
+	EnvInt    EnvVarType = "int"
+)
+
+// EnvVar describes an environment variable that affects the build.
+type EnvVar struct {
+	Name string
+	Type EnvVarType
+	// The value used when the variable isn't set, empty if there is none.
+	Default string
+	// The package or tool that reads the variable, e.g. "cc" or "soong_ui".
+	Owner       string
+	Description string
+}
+
+// ParseEnvBool returns the value of a boolean environment variable, and false for ok if it is
+// neither true nor false.
+func ParseEnvBool(value string) (b bool, ok bool) {
+	switch value {
+	case "1", "y", "yes", "on", "true":
+		return true, true
+	case "0", "n", "no", "off", "false":
+		return false, true
+	}
+	return false, false
+}
+
+// Validate returns an error if value isn't a valid value for the variable.
+func (v EnvVar) Validate(value string) error {
+	switch v.Type {
+	case EnvBool:
+		if _, ok := ParseEnvBool(value); !ok && value != "" {
+			return fmt.Errorf("%s=%q is not a boolean, use true or false", v.Name, value)
+		}
+	case EnvInt:
+		if _, err := strconv.Atoi(value); err != nil && value != "" {
+			return fmt.Errorf("%s=%q is not an integer", v.Name, value)
+		}
+	}
+	return nil
+}
+
+// EnvRegistryContents serializes the registry to JSON.
+func EnvRegistryContents(vars []EnvVar) ([]byte, error) {
+	data, err := json.MarshalIndent(vars, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// EnvRegistryFromFile reads a registry written by soong_build.
+func EnvRegistryFromFile(filename string) ([]EnvVar, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var vars []EnvVar
+	if err := json.Unmarshal(data, &vars); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", filename, err)
+	}
+	return vars, nil
+}
+
+// isValidatedEnvVar returns true for the variables that must be in the registry. Only SOONG_* and
+// RBE_* variables are validated, as the build reads many other variables that are set by the
+// user's shell or by lunch. Lowercase RBE_* variables are flags for reproxy, which are passed
+// through without being read by the build.
+func isValidatedEnvVar(name string) bool {
+	if strings.HasPrefix(name, "SOONG_") {
+		return true
+	}
+	return strings.HasPrefix(name, "RBE_") && strings.ToUpper(name) == name
+}
+
+// CheckEnvironment returns a warning for each SOONG_* or RBE_* variable in env that is not in the
+// registry, with the registered variable it may be a misspelling of, and for each registered
+// variable whose value doesn't match its type.
+func CheckEnvironment(vars []EnvVar, env map[string]string) []string {
+	registry := make(map[string]EnvVar)
+	for _, v := range vars {
+		registry[v.Name] = v
+	}
+
+	var names []string
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var warnings []string
+	for _, name := range names {
+		if v, ok := registry[name]; ok {
+			if err := v.Validate(env[name]); err != nil {
+				warnings = append(warnings, err.Error())
+			}
+			continue
+		}
+		if !isValidatedEnvVar(name) {
+			continue
+		}
+		warning := fmt.Sprintf("%s is not a known build environment variable", name)
+		if suggestion := closestEnvVar(name, vars); suggestion != "" {
+			warning += fmt.Sprintf(", did you mean %s?", suggestion)
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// closestEnvVar returns the registered variable with the closest name to name, if it is close
+// enough to be a misspelling.
+func closestEnvVar(name string, vars []EnvVar) string {
+	closest := ""
+	closestDistance := 3
+	for _, v := range vars {
+		if d := editDistance(name, v.Name); d < closestDistance {
+			closest, closestDistance = v.Name, d
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	ret := values[0]
+	for _, v := range values[1:] {
+		if v < ret {
+			ret = v
+		}
+	}
+	return ret
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckEnvironment(t *testing.T) {
+	vars := []EnvVar{
+		{Name: "SOONG_GEN_COMPDB", Type: EnvBool, Owner: "cc"},
+		{Name: "RBE_JAVAC", Type: EnvBool, Owner: "java"},
+		{Name: "TIDY_TIMEOUT", Type: EnvInt, Owner: "cc"},
+		{Name: "WITH_TIDY", Type: EnvBool, Owner: "cc"},
+	}
+	env := map[string]string{
+		"SOONG_GEN_COMPBD":   "1",
+		"SOONG_UNRELATED":    "1",
+		"RBE_JAVA":           "true",
+		"RBE_server_address": "unix:///tmp/reproxy.sock",
+		"RBE_JAVAC":          "maybe",
+		"TIDY_TIMEOUT":       "10s",
+		"WITH_TIDY":          "",
+		"PATH":               "/bin",
+	}
+
+	want := []string{
+		`RBE_JAVA is not a known build environment variable, did you mean RBE_JAVAC?`,
+		`RBE_JAVAC="maybe" is not a boolean, use true or false`,
+		`SOONG_GEN_COMPBD is not a known build environment variable, did you mean SOONG_GEN_COMPDB?`,
+		`SOONG_UNRELATED is not a known build environment variable`,
+		`TIDY_TIMEOUT="10s" is not an integer`,
+	}
+	if got := CheckEnvironment(vars, env); !reflect.DeepEqual(got, want) {
+		t.Errorf("want warnings:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"WITH_TIDY", "WITH_TIDY", 0},
+		{"WITH_TIDY", "WITH_TDY", 1},
+		{"SOONG_GEN_COMPBD", "SOONG_GEN_COMPDB", 2},
+		{"", "abc", 3},
+	}
+	for _, tc := range testCases {
+		if got := editDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("editDistance(%q, %q): want %d, got %d", tc.a, tc.b, tc.want, got)
+		}
+	}
+}
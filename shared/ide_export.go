@@ -0,0 +1,42 @@
+// Copyright 2017 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+// This file shares the names used by the IDE project export between soong_ui, which requests it
+// with `soong_ui --ide-export <dirs>`, and soong_build, which writes the project files.
+
+const (
+	// IdeExportDirsEnv is the environment variable that lists the directories to export.
+	IdeExportDirsEnv = "SOONG_IDE_EXPORT_DIRS"
+
+	// IdeExportPhony is the phony target that builds the generated sources of the exported modules.
+	IdeExportPhony = "ide-export"
+
+	// IdeExportDir is the directory, relative to the Soong out directory, that the project files
+	// are written to.
+	IdeExportDir = "ide-export"
+
+	// The project files written to the IDE export directory.
+	IdeExportCompdbFile      = "compile_commands.json"
+	IdeExportRustProjectFile = "rust-project.json"
+	IdeExportJavaDepsFile    = "module_bp_java_deps.json"
+)
+
+// IdeExportFiles lists the project files written to the IDE export directory.
+var IdeExportFiles = []string{
+	IdeExportCompdbFile,
+	IdeExportRustProjectFile,
+	IdeExportJavaDepsFile,
+}
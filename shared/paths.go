@@ -50,6 +50,13 @@ func TempDirForOutDir(outDir string) (tempPath string) {
 	return filepath.Join(outDir, ".temp")
 }
 
+// SboxCacheHitsFile returns the file in the Soong output directory that sbox appends a byte to for
+// every action restored from the action cache, which soong_ui clears at the start of each build to
+// count the cache hits of the build.
+func SboxCacheHitsFile(soongOutDir string) string {
+	return filepath.Join(soongOutDir, ".sbox_cache_hits")
+}
+
 // BazelMetricsFilename returns the bazel profile filename based
 // on the action name. This is to help to store a set of bazel
 // profiles since bazel may execute multiple times during a single
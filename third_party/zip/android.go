@@ -16,12 +16,21 @@ package zip
 
 import (
 	"errors"
+	"fmt"
 	"io"
 )
 
 const DataDescriptorFlag = 0x8
 const ExtendedTimeStampTag = 0x5455
 
+// AlignmentExtraTag is the tag of the extra field that zipalign and apksigner use to pad the local
+// file header of an uncompressed file so that its data is aligned. Its data is the alignment as a
+// uint16 followed by zeros.
+const AlignmentExtraTag = 0xd935
+
+// MaxAlignment is the largest alignment that fits in an alignment extra field.
+const MaxAlignment = 32768
+
 func (w *Writer) CopyFrom(orig *File, newName string) error {
 	if w.last != nil && !w.last.closed {
 		if err := w.last.close(); err != nil {
@@ -53,7 +62,11 @@ func (w *Writer) CopyFrom(orig *File, newName string) error {
 		fh.UncompressedSize = uint32(fh.UncompressedSize64)
 	}
 
-	if err := writeHeader(w.cw, fh); err != nil {
+	padding, err := w.alignmentPadding(fh)
+	if err != nil {
+		return err
+	}
+	if err := writeHeader(w.cw, fh, padding); err != nil {
 		return err
 	}
 	dataOffset, err := orig.DataOffset()
@@ -85,6 +98,48 @@ func (w *Writer) CopyFrom(orig *File, newName string) error {
 	return err
 }
 
+// SetAlignment sets a function that returns the alignment of the data of each uncompressed file
+// added afterwards, for example 4096 for native libraries that are mapped directly from the zip
+// file. The local file header of the file is padded with an alignment extra field to align its
+// data. An alignment of 0 or 1 leaves the data unaligned. Alignments must be powers of 2 no
+// larger than MaxAlignment.
+func (w *Writer) SetAlignment(alignment func(fh *FileHeader) int) {
+	w.alignment = alignment
+}
+
+// alignmentPadding returns the alignment extra field to append to the local file header of fh,
+// which is written at the current offset, or nil if its data doesn't need to be aligned. It
+// returns an error if the alignment of fh isn't a power of 2 no larger than MaxAlignment.
+func (w *Writer) alignmentPadding(fh *FileHeader) ([]byte, error) {
+	if w.alignment == nil || fh.Method != Store {
+		return nil, nil
+	}
+	alignment := w.alignment(fh)
+	if alignment <= 1 {
+		return nil, nil
+	}
+	if alignment > MaxAlignment || alignment&(alignment-1) != 0 {
+		return nil, fmt.Errorf("zip: invalid alignment %d for %q, must be a power of 2 no larger than %d",
+			alignment, fh.Name, MaxAlignment)
+	}
+
+	extraLen := len(fh.Extra)
+	if fh.Flags&DataDescriptorFlag == 0 && (fh.CompressedSize64 > uint32max || fh.UncompressedSize64 > uint32max) {
+		// writeHeader will append a zip64 extra.
+		extraLen += 20
+	}
+	const alignmentExtraLen = 6 // tag, size and alignment
+	dataOffset := w.cw.count + fileHeaderLen + int64(len(fh.Name)) + int64(extraLen) + alignmentExtraLen
+	padding := (int64(alignment) - dataOffset%int64(alignment)) % int64(alignment)
+
+	buf := make([]byte, alignmentExtraLen+padding)
+	b := writeBuf(buf)
+	b.uint16(AlignmentExtraTag)
+	b.uint16(uint16(2 + padding))
+	b.uint16(uint16(alignment))
+	return buf, nil
+}
+
 // The zip64 extras change between the Central Directory and Local File Header, while we use
 // the same structure for both. The Local File Haeder is taken care of by us writing a data
 // descriptor with the zip64 values. The Central Directory Entry is written by Close(), where
@@ -94,6 +149,9 @@ func (w *Writer) CopyFrom(orig *File, newName string) error {
 // File Header.
 // Extended-Timestamp extra(LFH): <tag-size-flag-modtime-actime-changetime>
 // Extended-Timestamp extra(CDH): <tag-size-flag-modtime>
+//
+// The alignment extra is only valid at the original offset of the file, it is replaced when
+// the file is aligned again.
 func stripExtras(input []byte) []byte {
 	ret := []byte{}
 
@@ -104,7 +162,7 @@ func stripExtras(input []byte) []byte {
 		if int(size) > len(r) {
 			break
 		}
-		if tag != zip64ExtraId && tag != ExtendedTimeStampTag {
+		if tag != zip64ExtraId && tag != ExtendedTimeStampTag && tag != AlignmentExtraTag {
 			ret = append(ret, input[:4+size]...)
 		}
 		input = input[4+size:]
@@ -158,7 +216,11 @@ func (w *Writer) CreateCompressedHeader(fh *FileHeader) (io.WriteCloser, error)
 	w.dir = append(w.dir, h)
 	fw.header = h
 
-	if err := writeHeader(w.cw, fh); err != nil {
+	padding, err := w.alignmentPadding(fh)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeHeader(w.cw, fh, padding); err != nil {
 		return nil, err
 	}
 
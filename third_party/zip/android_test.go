@@ -16,6 +16,9 @@ package zip
 
 import (
 	"bytes"
+	"hash/crc32"
+	"io/ioutil"
+	"strings"
 	"testing"
 )
 
@@ -64,6 +67,11 @@ var stripZip64Testcases = []struct {
 		in:   []byte{1, 0, 8, 0, 1, 2, 3, 4, 5, 6, 7, 8, 85, 84, 5, 0, 1, 1, 2, 3, 4, 2, 0, 0, 0},
 		out:  []byte{2, 0, 0, 0},
 	},
+	{
+		name: "alignment extra and valid non-alignment extra",
+		in:   []byte{0x35, 0xd9, 4, 0, 0, 16, 0, 0, 2, 0, 0, 0},
+		out:  []byte{2, 0, 0, 0},
+	},
 }
 
 func TestStripZip64Extras(t *testing.T) {
@@ -220,3 +228,127 @@ func TestZip64P7ZipRecords(t *testing.T) {
 		t.Errorf("wanted directoryOffset > %d, got %d", w, g)
 	}
 }
+
+func TestAlignment(t *testing.T) {
+	alignment := func(fh *FileHeader) int {
+		if strings.HasSuffix(fh.Name, ".so") {
+			return 4096
+		}
+		return 4
+	}
+
+	contents := []byte("contents")
+	storedHeader := func(name string) *FileHeader {
+		return &FileHeader{
+			Name:               name,
+			Method:             Store,
+			CRC32:              crc32.ChecksumIEEE(contents),
+			UncompressedSize64: uint64(len(contents)),
+			CompressedSize64:   uint64(len(contents)),
+		}
+	}
+
+	fromZipBytes := &bytes.Buffer{}
+	fromZip := NewWriter(fromZipBytes)
+	fromZip.SetAlignment(alignment)
+	for _, fh := range []*FileHeader{
+		storedHeader("a"),
+		storedHeader("lib/arm64-v8a/libfoo.so"),
+		{Name: "compressed", Method: Deflate},
+		storedHeader("bb"),
+	} {
+		w, err := fromZip.CreateHeaderAndroid(fh)
+		if err != nil {
+			t.Fatalf("CreateHeaderAndroid: %v", err)
+		}
+		if _, err := w.Write(contents); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	// A file written with a data descriptor.
+	w, err := fromZip.CreateHeader(&FileHeader{Name: "ccc.so", Method: Store})
+	if err != nil {
+		t.Fatalf("CreateHeader: %v", err)
+	}
+	if _, err := w.Write(contents); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := fromZip.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	checkAlignment := func(t *testing.T, zipBytes []byte) *Reader {
+		t.Helper()
+		r, err := NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+		if err != nil {
+			t.Fatalf("NewReader: %v", err)
+		}
+		for _, f := range r.File {
+			offset, err := f.DataOffset()
+			if err != nil {
+				t.Fatalf("DataOffset: %v", err)
+			}
+			if f.Method == Store && offset%int64(alignment(&f.FileHeader)) != 0 {
+				t.Errorf("%s: data offset %d is not aligned to %d", f.Name, offset, alignment(&f.FileHeader))
+			}
+			if bytes.Contains(f.Extra, []byte{0x35, 0xd9}) {
+				t.Errorf("%s: unexpected alignment extra in central directory", f.Name)
+			}
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			got, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				t.Fatalf("%s: ReadAll: %v", f.Name, err)
+			}
+			if !bytes.Equal(got, contents) {
+				t.Errorf("%s: expected %q, got %q", f.Name, contents, got)
+			}
+		}
+		return r
+	}
+
+	fromZipReader := checkAlignment(t, fromZipBytes.Bytes())
+
+	// Copying the files to different offsets aligns them again.
+	toZipBytes := &bytes.Buffer{}
+	toZip := NewWriter(toZipBytes)
+	toZip.SetAlignment(alignment)
+	if _, err := toZip.Create("x"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for _, f := range fromZipReader.File {
+		if err := toZip.CopyFrom(f, f.Name); err != nil {
+			t.Fatalf("CopyFrom: %v", err)
+		}
+	}
+	if err := toZip.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	toZipReader, err := NewReader(bytes.NewReader(toZipBytes.Bytes()), int64(toZipBytes.Len()))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	for _, f := range toZipReader.File[1:] {
+		offset, err := f.DataOffset()
+		if err != nil {
+			t.Fatalf("DataOffset: %v", err)
+		}
+		if f.Method == Store && offset%int64(alignment(&f.FileHeader)) != 0 {
+			t.Errorf("%s: data offset %d is not aligned to %d after copying", f.Name, offset, alignment(&f.FileHeader))
+		}
+	}
+}
+
+func TestInvalidAlignment(t *testing.T) {
+	for _, alignment := range []int{3, 4095, 2 * MaxAlignment} {
+		w := NewWriter(&bytes.Buffer{})
+		w.SetAlignment(func(fh *FileHeader) int { return alignment })
+		if _, err := w.CreateHeader(&FileHeader{Name: "a", Method: Store}); err == nil {
+			t.Errorf("alignment %d: expected error", alignment)
+		}
+	}
+}
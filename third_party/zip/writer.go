@@ -22,6 +22,10 @@ type Writer struct {
 	last        *fileWriter
 	closed      bool
 	compressors map[uint16]Compressor
+
+	// BEGIN ANDROID CHANGE add alignment of uncompressed files
+	alignment func(fh *FileHeader) int
+	// END ANDROID CHANGE
 }
 
 type header struct {
@@ -257,7 +261,13 @@ func (w *Writer) createHeaderImpl(fh *FileHeader) (io.Writer, error) {
 	w.dir = append(w.dir, h)
 	fw.header = h
 
-	if err := writeHeader(w.cw, fh); err != nil {
+	// BEGIN ANDROID CHANGE add alignment of uncompressed files
+	padding, err := w.alignmentPadding(fh)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeHeader(w.cw, fh, padding); err != nil {
+		// END ANDROID CHANGE
 		return nil, err
 	}
 
@@ -265,7 +275,11 @@ func (w *Writer) createHeaderImpl(fh *FileHeader) (io.Writer, error) {
 	return fw, nil
 }
 
-func writeHeader(w io.Writer, h *FileHeader) error {
+// BEGIN ANDROID CHANGE add alignment of uncompressed files
+// writeHeader writes the local file header of h, followed by padding, which is only added to the
+// extra fields of the local file header and not to those of the central directory.
+func writeHeader(w io.Writer, h *FileHeader, padding []byte) error {
+	// END ANDROID CHANGE
 	var buf [fileHeaderLen]byte
 	b := writeBuf(buf[:])
 	b.uint32(uint32(fileHeaderSignature))
@@ -313,15 +327,22 @@ func writeHeader(w io.Writer, h *FileHeader) error {
 	}
 	// END ANDROID CHANGE
 	b.uint16(uint16(len(h.Name)))
-	b.uint16(uint16(len(h.Extra)))
+	// BEGIN ANDROID CHANGE add alignment of uncompressed files
+	b.uint16(uint16(len(h.Extra) + len(padding)))
+	// END ANDROID CHANGE
 	if _, err := w.Write(buf[:]); err != nil {
 		return err
 	}
 	if _, err := io.WriteString(w, h.Name); err != nil {
 		return err
 	}
-	_, err := w.Write(h.Extra)
+	// BEGIN ANDROID CHANGE add alignment of uncompressed files
+	if _, err := w.Write(h.Extra); err != nil {
+		return err
+	}
+	_, err := w.Write(padding)
 	return err
+	// END ANDROID CHANGE
 }
 
 // RegisterCompressor registers or overrides a custom compressor for a specific
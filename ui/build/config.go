@@ -920,10 +920,37 @@ func (c *configImpl) ModuleGraphFile() string {
 	return shared.JoinPath(c.SoongOutDir(), "module-graph.json")
 }
 
+func (c *configImpl) ModuleGraphProtoFile() string {
+	return shared.JoinPath(c.SoongOutDir(), "module-graph.pb")
+}
+
+// IdeExportDir returns the directory where soong_build writes the IDE project files.
+func (c *configImpl) IdeExportDir() string {
+	return shared.JoinPath(c.SoongOutDir(), shared.IdeExportDir)
+}
+
+// EnvRegistryFile returns the registry of the environment variables that affect the build, written
+// by soong_build.
+func (c *configImpl) EnvRegistryFile() string {
+	return shared.JoinPath(c.SoongOutDir(), shared.EnvRegistryFile)
+}
+
 func (c *configImpl) ModuleActionsFile() string {
 	return shared.JoinPath(c.SoongOutDir(), "module-actions.json")
 }
 
+func (c *configImpl) BuildHistoryFile() string {
+	return shared.JoinPath(c.OutDir(), "build_history.jsonl")
+}
+
+func (c *configImpl) NinjaLogFile() string {
+	return shared.JoinPath(c.OutDir(), ".ninja_log")
+}
+
+func (c *configImpl) NinjaDepsFile() string {
+	return shared.JoinPath(c.OutDir(), ".ninja_deps")
+}
+
 func (c *configImpl) TempDir() string {
 	return shared.TempDirForOutDir(c.SoongOutDir())
 }
@@ -0,0 +1,95 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+
+	"android/soong/ui/build/ninjafile"
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
+	"android/soong/ui/status"
+)
+
+// WriteCriticalPath attributes the actions on the critical path of the build, and the long
+// running actions, to the Soong modules that produced them, then stores the result in the build
+// metrics and overlays it on the build trace.
+func WriteCriticalPath(ctx Context, config Config, criticalPath status.CriticalPath) {
+	info := criticalPath.CriticalPathInfo()
+	if len(info.GetCriticalPath()) == 0 {
+		return
+	}
+
+	jobs := append(append([]*soong_metrics_proto.JobInfo(nil), info.CriticalPath...), info.LongRunningJobs...)
+	outputs := make(map[string]bool)
+	for _, job := range jobs {
+		for _, output := range job.Outputs {
+			outputs[output] = true
+		}
+	}
+
+	if f, err := os.Open(config.SoongNinjaFile()); err != nil {
+		ctx.Verbosef("Not attributing critical path to modules: %s", err)
+	} else {
+		owners, err := ninjaModuleOwners(f, outputs)
+		f.Close()
+		if err != nil {
+			ctx.Verbosef("Failed to attribute critical path to modules: %s", err)
+		}
+		for _, job := range jobs {
+			for _, output := range job.Outputs {
+				if owner, ok := owners[output]; ok {
+					job.ModuleName = proto.String(owner.Name)
+					job.ModuleVariant = proto.String(owner.Variant)
+					job.ModuleType = proto.String(owner.Type)
+					break
+				}
+			}
+		}
+	}
+
+	for _, job := range info.CriticalPath {
+		if job.ModuleName != nil {
+			ctx.Verbosef("critical path module: %s (%s) %s", job.GetModuleName(), job.GetModuleType(),
+				job.GetJobDescription())
+		}
+	}
+
+	if ctx.Metrics != nil {
+		ctx.Metrics.SetCriticalPathInfo(info)
+	}
+	if ctx.Tracer != nil {
+		ctx.Tracer.ImportCriticalPath(info)
+	}
+}
+
+// ninjaModuleOwners scans a ninja file generated by Soong for the build statements that produce
+// the given outputs, and returns the modules that they belong to.
+func ninjaModuleOwners(r io.Reader, outputs map[string]bool) (map[string]ninjafile.Module, error) {
+	owners := make(map[string]ninjafile.Module)
+	_, err := ninjafile.Scan(r, func(s ninjafile.Statement) {
+		if s.Module.Name == "" {
+			return
+		}
+		for _, output := range s.Outputs {
+			if outputs[output] {
+				owners[output] = s.Module
+			}
+		}
+	})
+	return owners, err
+}
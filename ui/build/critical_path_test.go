@@ -0,0 +1,59 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"android/soong/ui/build/ninjafile"
+)
+
+func TestNinjaModuleOwners(t *testing.T) {
+	ninja := `
+# Module:  libfoo
+# Variant: android_arm64_armv8-a_shared
+# Type:    cc_library
+
+build out/soong/.intermediates/foo/libfoo/obj/foo.o: g.cc.cc foo/foo.cpp
+build out/soong/.intermediates/foo/libfoo/libfoo.so: g.cc.ld out/soong/.intermediates/foo/libfoo/obj/foo.o
+
+# Singleton: androidmk
+
+build out/soong/Android.mk: phony
+`
+
+	outputs := map[string]bool{
+		"out/soong/.intermediates/foo/libfoo/obj/foo.o": true,
+		"out/soong/Android.mk":                          true,
+	}
+
+	owners, err := ninjaModuleOwners(strings.NewReader(ninja), outputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]ninjafile.Module{
+		"out/soong/.intermediates/foo/libfoo/obj/foo.o": {
+			Name:    "libfoo",
+			Variant: "android_arm64_armv8-a_shared",
+			Type:    "cc_library",
+		},
+	}
+	if !reflect.DeepEqual(owners, want) {
+		t.Errorf("ninjaModuleOwners() = %v, want %v", owners, want)
+	}
+}
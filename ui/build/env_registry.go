@@ -0,0 +1,79 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"text/tabwriter"
+
+	"android/soong/shared"
+)
+
+// checkEnvironment warns about the SOONG_* and RBE_* environment variables that are not in the
+// registry written by soong_build, and about registered variables with invalid values. Nothing is
+// checked until soong_build has run once.
+func checkEnvironment(ctx Context, config Config) {
+	vars, err := shared.EnvRegistryFromFile(config.EnvRegistryFile())
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	} else if err != nil {
+		ctx.Verbosef("Failed to read the environment registry: %s", err)
+		return
+	}
+	for _, warning := range shared.CheckEnvironment(vars, config.Environment().AsMap()) {
+		ctx.Printf("Warning: %s", warning)
+	}
+}
+
+// ListEnv prints the environment variables that affect the build with their effective values,
+// followed by the warnings about the environment.
+func ListEnv(ctx Context, config Config, w io.Writer) {
+	vars, err := shared.EnvRegistryFromFile(config.EnvRegistryFile())
+	if err != nil {
+		ctx.Fatalf("Failed to read the environment registry: %s", err)
+	}
+	writeEnvList(w, vars, config.Environment().AsMap())
+}
+
+func writeEnvList(w io.Writer, vars []shared.EnvVar, env map[string]string) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tTYPE\tOWNER\tVALUE\tDESCRIPTION")
+	for _, v := range vars {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", v.Name, v.Type, v.Owner, effectiveEnvValue(v, env), v.Description)
+	}
+	tw.Flush()
+
+	if warnings := shared.CheckEnvironment(vars, env); len(warnings) > 0 {
+		fmt.Fprintln(w)
+		for _, warning := range warnings {
+			fmt.Fprintln(w, "Warning:", warning)
+		}
+	}
+}
+
+// effectiveEnvValue returns the value of the variable in env, or its default marked as such if it
+// isn't set.
+func effectiveEnvValue(v shared.EnvVar, env map[string]string) string {
+	if value := env[v.Name]; value != "" {
+		return fmt.Sprintf("%q", value)
+	}
+	if v.Default != "" {
+		return fmt.Sprintf("%q (default)", v.Default)
+	}
+	return "(unset)"
+}
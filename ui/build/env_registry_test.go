@@ -0,0 +1,48 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"testing"
+
+	"android/soong/shared"
+)
+
+func TestWriteEnvList(t *testing.T) {
+	vars := []shared.EnvVar{
+		{Name: "RBE_LINT_POOL", Type: shared.EnvString, Default: "java16", Owner: "java", Description: "RBE worker pool of lint."},
+		{Name: "SOONG_GEN_COMPDB", Type: shared.EnvBool, Default: "false", Owner: "cc", Description: "Write compile_commands.json."},
+		{Name: "WITH_TIDY_FLAGS", Type: shared.EnvString, Owner: "cc", Description: "Extra flags passed to clang-tidy."},
+	}
+	env := map[string]string{
+		"SOONG_GEN_COMPDB": "1",
+		"SOONG_GEN_COMPBD": "1",
+	}
+
+	want := "" +
+		"NAME              TYPE    OWNER  VALUE               DESCRIPTION\n" +
+		"RBE_LINT_POOL     string  java   \"java16\" (default)  RBE worker pool of lint.\n" +
+		"SOONG_GEN_COMPDB  bool    cc     \"1\"                 Write compile_commands.json.\n" +
+		"WITH_TIDY_FLAGS   string  cc     (unset)             Extra flags passed to clang-tidy.\n" +
+		"\n" +
+		"Warning: SOONG_GEN_COMPBD is not a known build environment variable, did you mean SOONG_GEN_COMPDB?\n"
+
+	buf := &bytes.Buffer{}
+	writeEnvList(buf, vars, env)
+	if got := buf.String(); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"android/soong/ui/build/explain"
+)
+
+// ExplainRebuilds reports why each of the actions recorded by recorder reran,
+// comparing them against the ninja state from before the build in snapshot.
+// The actions are attributed to the Soong modules that own them, printed to w
+// grouped by module, and written in full to explain.json in the logs
+// directory. It returns an error instead of failing the build, as it also runs
+// while a failed build is exiting.
+func ExplainRebuilds(ctx Context, config Config, snapshot *explain.Snapshot, recorder *explain.Recorder, w io.Writer) error {
+	after, err := explain.ReadLog(config.NinjaLogFile())
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", config.NinjaLogFile(), err)
+	}
+
+	explanations := explain.Explain(snapshot, after, recorder.Actions())
+
+	outputs := make(map[string]bool)
+	for _, e := range explanations {
+		for _, output := range e.Outputs {
+			outputs[output] = true
+		}
+	}
+	if f, err := os.Open(config.SoongNinjaFile()); err != nil {
+		ctx.Verbosef("Not attributing rebuilt actions to modules: %s", err)
+	} else {
+		owners, err := ninjaModuleOwners(f, outputs)
+		f.Close()
+		if err != nil {
+			ctx.Verbosef("Failed to attribute rebuilt actions to modules: %s", err)
+		}
+		for _, e := range explanations {
+			for _, output := range e.Outputs {
+				if owner, ok := owners[output]; ok {
+					e.Module = owner.Name
+					e.Variant = owner.Variant
+					e.ModuleType = owner.Type
+					break
+				}
+			}
+		}
+	}
+
+	explain.Print(w, explanations, 10, 5)
+
+	data, err := json.MarshalIndent(explanations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal explanations: %w", err)
+	}
+	file := filepath.Join(config.LogsDir(), "explain.json")
+	if err := ioutil.WriteFile(file, data, 0666); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	ctx.Printf("Full explanation written to %s", file)
+	return nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package explain determines why ninja reran the actions in an incremental
+// build.
+//
+// The state that ninja uses to decide whether an action is dirty, the
+// .ninja_log and .ninja_deps files in the out directory, is read before the
+// build starts. The actions that ninja runs are then collected from the ninja
+// frontend stream, and each one is compared against the state from before the
+// build to find the inputs, command lines or outputs that caused it to rerun.
+package explain
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"android/soong/ui/status"
+)
+
+// The reasons that an action reran.
+const (
+	// None of the outputs of the action had been built before.
+	ReasonNew = "new"
+	// The command line of the action changed.
+	ReasonCommand = "command"
+	// An input of the action was rebuilt by an earlier action in the same build.
+	ReasonInputRebuilt = "input_rebuilt"
+	// An input of the action was modified after its outputs were last built.
+	ReasonInputChanged = "input_changed"
+	// No input or command line changed, so an output of the action must have
+	// been missing or modified since it was last built.
+	ReasonOutput = "output"
+)
+
+// Reason is one of the causes of an action rerunning.
+type Reason struct {
+	// One of the Reason constants.
+	Kind string `json:"kind"`
+
+	// The input or output that caused the action to rerun, if any.
+	File string `json:"file,omitempty"`
+}
+
+func (r Reason) String() string {
+	switch r.Kind {
+	case ReasonNew:
+		return "not built before"
+	case ReasonCommand:
+		return "command line changed"
+	case ReasonInputRebuilt:
+		return "input rebuilt: " + r.File
+	case ReasonInputChanged:
+		return "input changed: " + r.File
+	case ReasonOutput:
+		return "output missing or modified"
+	default:
+		return r.Kind
+	}
+}
+
+// Explanation describes why a single action reran.
+type Explanation struct {
+	Description string   `json:"description"`
+	Outputs     []string `json:"outputs"`
+	Reasons     []Reason `json:"reasons"`
+
+	// The Soong module that owns the action, if any.
+	Module     string `json:"module,omitempty"`
+	Variant    string `json:"variant,omitempty"`
+	ModuleType string `json:"module_type,omitempty"`
+}
+
+// Snapshot is the state of a ninja build directory before a build.
+type Snapshot struct {
+	Log  map[string]*LogEntry
+	Deps map[string]*DepsEntry
+}
+
+// ReadSnapshot reads the ninja log and deps log of a build directory.
+func ReadSnapshot(logFile, depsFile string) (*Snapshot, error) {
+	log, err := ReadLog(logFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", logFile, err)
+	}
+	deps, err := ReadDeps(depsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", depsFile, err)
+	}
+	return &Snapshot{Log: log, Deps: deps}, nil
+}
+
+// Explain returns the reasons that each of the actions reran, given the state
+// of the build directory before the build, and the ninja log after the build.
+// Actions that are in neither log were not run by the ninja that owns the logs
+// and are skipped.
+func Explain(before *Snapshot, after map[string]*LogEntry, actions []*status.Action) []*Explanation {
+	rebuilt := make(map[string]bool)
+	for _, action := range actions {
+		for _, output := range action.Outputs {
+			rebuilt[output] = true
+		}
+	}
+
+	var explanations []*Explanation
+	for _, action := range actions {
+		var entry *LogEntry
+		inLog := false
+		for _, output := range action.Outputs {
+			if entry == nil {
+				entry = before.Log[output]
+			}
+			if after[output] != nil {
+				inLog = true
+			}
+		}
+		if entry == nil && !inLog {
+			continue
+		}
+
+		e := &Explanation{
+			Description: action.Description,
+			Outputs:     action.Outputs,
+		}
+		if e.Description == "" {
+			e.Description = action.Command
+		}
+		explanations = append(explanations, e)
+
+		if entry == nil {
+			e.Reasons = append(e.Reasons, Reason{Kind: ReasonNew})
+			continue
+		}
+
+		if !commandMatches(action.Command, entry.CommandHash) {
+			e.Reasons = append(e.Reasons, Reason{Kind: ReasonCommand})
+		}
+
+		inputs := action.Inputs
+		for _, output := range action.Outputs {
+			if deps := before.Deps[output]; deps != nil {
+				inputs = append(append([]string(nil), inputs...), deps.Inputs...)
+				break
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, input := range inputs {
+			if seen[input] {
+				continue
+			}
+			seen[input] = true
+
+			if rebuilt[input] {
+				e.Reasons = append(e.Reasons, Reason{Kind: ReasonInputRebuilt, File: input})
+			} else if entry.Mtime != 0 {
+				if info, err := os.Stat(input); err == nil && info.ModTime().After(mtimeToTime(entry.Mtime)) {
+					e.Reasons = append(e.Reasons, Reason{Kind: ReasonInputChanged, File: input})
+				}
+			}
+		}
+
+		if len(e.Reasons) == 0 {
+			e.Reasons = append(e.Reasons, Reason{Kind: ReasonOutput})
+		}
+	}
+
+	return explanations
+}
+
+// commandMatches returns true if the hash of the command, or of the command
+// with the contents of its response file, matches the hash from the ninja log.
+// The frontend stream does not include the response file, but the build runs
+// ninja with -d keeprsp so it is still on disk.
+func commandMatches(command string, hash uint64) bool {
+	if HashCommand(command) == hash {
+		return true
+	}
+	for _, arg := range strings.Fields(command) {
+		if !strings.HasPrefix(arg, "@") {
+			continue
+		}
+		rsp, err := ioutil.ReadFile(strings.Trim(arg[1:], `'"`))
+		if err != nil {
+			continue
+		}
+		if HashCommand(command+";rspfile="+string(rsp)) == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// mtimeToTime converts an mtime from a ninja log, which is in nanoseconds in
+// newer versions of ninja and in seconds in older ones.
+func mtimeToTime(mtime int64) time.Time {
+	if mtime < 1e12 {
+		return time.Unix(mtime, 0)
+	}
+	return time.Unix(0, mtime)
+}
+
+// Summary returns the number of actions that reran for each kind of reason.
+func Summary(explanations []*Explanation) map[string]int {
+	summary := make(map[string]int)
+	for _, e := range explanations {
+		kinds := make(map[string]bool)
+		for _, r := range e.Reasons {
+			kinds[r.Kind] = true
+		}
+		for kind := range kinds {
+			summary[kind]++
+		}
+	}
+	return summary
+}
+
+// Print writes the explanations to w grouped by the module that owns them,
+// with the modules that reran the most actions first. At most maxPerModule
+// actions are printed for each module, and at most maxReasons reasons for each
+// action.
+func Print(w io.Writer, explanations []*Explanation, maxPerModule, maxReasons int) {
+	summary := Summary(explanations)
+	kinds := make([]string, 0, len(summary))
+	for kind := range summary {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	fmt.Fprintf(w, "%d actions reran:\n", len(explanations))
+	for _, kind := range kinds {
+		fmt.Fprintf(w, "  %6d %s\n", summary[kind], kind)
+	}
+
+	groups := make(map[string][]*Explanation)
+	for _, e := range explanations {
+		key := "<not a Soong module>"
+		if e.Module != "" {
+			key = e.Module
+			if e.ModuleType != "" {
+				key += " (" + e.ModuleType + ")"
+			}
+			if e.Variant != "" {
+				key += " " + e.Variant
+			}
+		}
+		groups[key] = append(groups[key], e)
+	}
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(groups[keys[i]]) != len(groups[keys[j]]) {
+			return len(groups[keys[i]]) > len(groups[keys[j]])
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		group := groups[key]
+		fmt.Fprintf(w, "\n%s: %d actions\n", key, len(group))
+		for i, e := range group {
+			if i == maxPerModule {
+				fmt.Fprintf(w, "  ... and %d more\n", len(group)-i)
+				break
+			}
+			fmt.Fprintf(w, "  %s\n", e.Description)
+			for j, r := range e.Reasons {
+				if j == maxReasons {
+					fmt.Fprintf(w, "    ... and %d more\n", len(e.Reasons)-j)
+					break
+				}
+				fmt.Fprintf(w, "    %s\n", r)
+			}
+		}
+	}
+}
+
+// Recorder is a StatusOutput that records the actions run by a build.
+type Recorder struct {
+	actions []*status.Action
+}
+
+var _ status.StatusOutput = &Recorder{}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Actions returns the actions that have been started, in the order they
+// started.
+func (r *Recorder) Actions() []*status.Action {
+	return r.actions
+}
+
+func (r *Recorder) StartAction(action *status.Action, counts status.Counts) {
+	r.actions = append(r.actions, action)
+}
+
+func (r *Recorder) FinishAction(result status.ActionResult, counts status.Counts) {}
+func (r *Recorder) Message(level status.MsgLevel, message string)                 {}
+func (r *Recorder) Flush()                                                        {}
+func (r *Recorder) Write(p []byte) (int, error)                                   { return len(p), nil }
@@ -0,0 +1,156 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"android/soong/ui/status"
+)
+
+func TestExplain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "explain_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	built := time.Unix(1000, 0)
+	file := func(name string, mtime time.Time) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(name), 0666); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	oldSrc := file("old.c", built.Add(-time.Hour))
+	newSrc := file("new.c", built.Add(time.Hour))
+	newHeader := file("new.h", built.Add(time.Hour))
+	rsp := file("link.rsp", built.Add(-time.Hour))
+
+	before := &Snapshot{
+		Log: map[string]*LogEntry{
+			"out/cmd.o":    {CommandHash: HashCommand("cc -O1 old.c"), Mtime: built.UnixNano()},
+			"out/input.o":  {CommandHash: HashCommand("cc new.c"), Mtime: built.UnixNano()},
+			"out/deps.o":   {CommandHash: HashCommand("cc old.c"), Mtime: built.Unix()},
+			"out/lib.so":   {CommandHash: HashCommand("ld @" + rsp + ";rspfile=link.rsp"), Mtime: built.UnixNano()},
+			"out/output.o": {CommandHash: HashCommand("cc old.c"), Mtime: built.UnixNano()},
+		},
+		Deps: map[string]*DepsEntry{
+			"out/deps.o": {Inputs: []string{oldSrc, newHeader}},
+		},
+	}
+	after := map[string]*LogEntry{
+		"out/new.o": {},
+	}
+
+	actions := []*status.Action{
+		{Description: "new", Outputs: []string{"out/new.o"}, Command: "cc new.c"},
+		{Description: "cmd", Outputs: []string{"out/cmd.o"}, Inputs: []string{oldSrc}, Command: "cc -O2 old.c"},
+		{Description: "input", Outputs: []string{"out/input.o"}, Inputs: []string{newSrc, oldSrc}, Command: "cc new.c"},
+		{Description: "deps", Outputs: []string{"out/deps.o"}, Inputs: []string{oldSrc}, Command: "cc old.c"},
+		{Description: "link", Outputs: []string{"out/lib.so"}, Inputs: []string{"out/cmd.o", "out/new.o"},
+			Command: "ld @" + rsp},
+		{Description: "output", Outputs: []string{"out/output.o"}, Inputs: []string{oldSrc}, Command: "cc old.c"},
+		{Description: "other ninja", Outputs: []string{"out/soong/build.ninja"}, Command: "soong_build"},
+	}
+
+	got := Explain(before, after, actions)
+
+	want := []*Explanation{
+		{Description: "new", Outputs: []string{"out/new.o"}, Reasons: []Reason{{Kind: ReasonNew}}},
+		{Description: "cmd", Outputs: []string{"out/cmd.o"}, Reasons: []Reason{{Kind: ReasonCommand}}},
+		{Description: "input", Outputs: []string{"out/input.o"},
+			Reasons: []Reason{{Kind: ReasonInputChanged, File: newSrc}}},
+		{Description: "deps", Outputs: []string{"out/deps.o"},
+			Reasons: []Reason{{Kind: ReasonInputChanged, File: newHeader}}},
+		{Description: "link", Outputs: []string{"out/lib.so"}, Reasons: []Reason{
+			{Kind: ReasonInputRebuilt, File: "out/cmd.o"},
+			{Kind: ReasonInputRebuilt, File: "out/new.o"},
+		}},
+		{Description: "output", Outputs: []string{"out/output.o"}, Reasons: []Reason{{Kind: ReasonOutput}}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Explain() mismatch")
+		for i := range got {
+			t.Logf("got  %d: %+v", i, *got[i])
+		}
+		for i := range want {
+			t.Logf("want %d: %+v", i, *want[i])
+		}
+	}
+
+	wantSummary := map[string]int{
+		ReasonNew:          1,
+		ReasonCommand:      1,
+		ReasonInputChanged: 2,
+		ReasonInputRebuilt: 1,
+		ReasonOutput:       1,
+	}
+	if g := Summary(got); !reflect.DeepEqual(g, wantSummary) {
+		t.Errorf("Summary() = %v, want %v", g, wantSummary)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	explanations := []*Explanation{
+		{Description: "compile a.c", Module: "liba", ModuleType: "cc_library", Variant: "android_arm64",
+			Reasons: []Reason{{Kind: ReasonCommand}}},
+		{Description: "kati", Reasons: []Reason{{Kind: ReasonNew}}},
+		{Description: "compile b.c", Module: "liba", ModuleType: "cc_library", Variant: "android_arm64",
+			Reasons: []Reason{
+				{Kind: ReasonInputChanged, File: "b.c"},
+				{Kind: ReasonInputChanged, File: "b.h"},
+			}},
+		{Description: "compile c.c", Module: "liba", ModuleType: "cc_library", Variant: "android_arm64",
+			Reasons: []Reason{{Kind: ReasonOutput}}},
+	}
+
+	buf := &bytes.Buffer{}
+	Print(buf, explanations, 2, 1)
+
+	want := `4 actions reran:
+       1 command
+       1 input_changed
+       1 new
+       1 output
+
+liba (cc_library) android_arm64: 3 actions
+  compile a.c
+    command line changed
+  compile b.c
+    input changed: b.c
+    ... and 1 more
+  ... and 1 more
+
+<not a Soong module>: 1 actions
+  kati
+    not built before
+`
+	if g := buf.String(); g != want {
+		t.Errorf("Print() =\n%s\nwant:\n%s", g, strings.TrimSpace(want))
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LogEntry is the record of the last run of an action in ninja's .ninja_log.
+type LogEntry struct {
+	Output string
+
+	// The start and end times of the action in milliseconds, relative to the
+	// start of the build that ran it.
+	StartMillis, EndMillis int
+
+	// The modification time of the output when the action finished.
+	Mtime int64
+
+	// The hash of the command line of the action, see HashCommand.
+	CommandHash uint64
+}
+
+// ReadLog returns the entries in a .ninja_log file keyed by output. A missing
+// file is treated as an empty log.
+func ReadLog(filename string) (map[string]*LogEntry, error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return map[string]*LogEntry{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseLog(f)
+}
+
+// parseLog parses a version 5 or 6 ninja log, which is made up of a header
+// line followed by tab separated lines of:
+//
+//	<start ms> <end ms> <mtime> <output> <command hash in hex>
+//
+// Ninja appends to the log every time an action finishes, so later entries for
+// an output replace earlier ones.
+func parseLog(r io.Reader) (map[string]*LogEntry, error) {
+	entries := make(map[string]*LogEntry)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+
+	if !scanner.Scan() {
+		return entries, scanner.Err()
+	}
+	header := scanner.Text()
+	if header != "# ninja log v5" && header != "# ninja log v6" {
+		return nil, fmt.Errorf("unsupported ninja log version %q", header)
+	}
+
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 5 {
+			continue
+		}
+		start, err1 := strconv.Atoi(fields[0])
+		end, err2 := strconv.Atoi(fields[1])
+		mtime, err3 := strconv.ParseInt(fields[2], 10, 64)
+		hash, err4 := strconv.ParseUint(fields[4], 16, 64)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+		entries[fields[3]] = &LogEntry{
+			Output:      fields[3],
+			StartMillis: start,
+			EndMillis:   end,
+			Mtime:       mtime,
+			CommandHash: hash,
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+// DepsEntry is the record of the dependencies discovered from the depfile of
+// an action in ninja's .ninja_deps.
+type DepsEntry struct {
+	// The modification time of the output when the dependencies were recorded.
+	Mtime int64
+
+	Inputs []string
+}
+
+// ReadDeps returns the entries in a .ninja_deps file keyed by output. A missing
+// file is treated as an empty log.
+func ReadDeps(filename string) (map[string]*DepsEntry, error) {
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return map[string]*DepsEntry{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return parseDeps(data)
+}
+
+const depsSignature = "# ninjadeps\n"
+
+// parseDeps parses a version 3 or 4 ninja deps log. After the signature and
+// version the log is a sequence of records, each prefixed with its size. If
+// the high bit of the size is set the record is a deps record made up of the
+// id of an output, its mtime (32 bits in version 3, 64 bits in version 4) and
+// the ids of its inputs. Otherwise it is a path record made up of a path padded
+// with nulls to a multiple of 4 bytes and the ones' complement of its id, which
+// is the number of path records before it.
+func parseDeps(data []byte) (map[string]*DepsEntry, error) {
+	entries := make(map[string]*DepsEntry)
+
+	if !bytes.HasPrefix(data, []byte(depsSignature)) || len(data) < len(depsSignature)+4 {
+		return nil, fmt.Errorf("invalid ninja deps signature")
+	}
+	data = data[len(depsSignature):]
+	version := binary.LittleEndian.Uint32(data)
+	if version != 3 && version != 4 {
+		return nil, fmt.Errorf("unsupported ninja deps version %d", version)
+	}
+	data = data[4:]
+
+	var paths []string
+	for len(data) >= 4 {
+		size := binary.LittleEndian.Uint32(data)
+		isDeps := size&0x80000000 != 0
+		size &= 0x7fffffff
+		data = data[4:]
+		if uint32(len(data)) < size || size%4 != 0 {
+			// Ninja truncates incomplete records at the end of the log when it
+			// next loads it, do the same.
+			break
+		}
+		record := data[:size]
+		data = data[size:]
+
+		if isDeps {
+			mtimeSize := 8
+			if version == 3 {
+				mtimeSize = 4
+			}
+			if len(record) < 4+mtimeSize {
+				continue
+			}
+			out := int(binary.LittleEndian.Uint32(record))
+			var mtime int64
+			if version == 3 {
+				mtime = int64(binary.LittleEndian.Uint32(record[4:]))
+			} else {
+				mtime = int64(binary.LittleEndian.Uint64(record[4:]))
+			}
+			if out < 0 || out >= len(paths) {
+				continue
+			}
+			entry := &DepsEntry{Mtime: mtime}
+			for ids := record[4+mtimeSize:]; len(ids) >= 4; ids = ids[4:] {
+				if id := int(binary.LittleEndian.Uint32(ids)); id >= 0 && id < len(paths) {
+					entry.Inputs = append(entry.Inputs, paths[id])
+				}
+			}
+			entries[paths[out]] = entry
+		} else {
+			if len(record) < 4 {
+				continue
+			}
+			paths = append(paths, string(bytes.TrimRight(record[:len(record)-4], "\x00")))
+		}
+	}
+
+	return entries, nil
+}
+
+// HashCommand returns the hash that ninja records in its log for a command
+// line, MurmurHash64A with ninja's seed. For actions with a response file ninja
+// hashes the command followed by ";rspfile=" and the contents of the response
+// file.
+func HashCommand(command string) uint64 {
+	const seed = 0xDECAFBADDECAFBAD
+	const m = 0xc6a4a7935bd1e995
+	const r = 47
+
+	data := []byte(command)
+	h := uint64(seed) ^ (uint64(len(data)) * m)
+
+	for ; len(data) >= 8; data = data[8:] {
+		k := binary.LittleEndian.Uint64(data)
+		k *= m
+		k ^= k >> r
+		k *= m
+		h ^= k
+		h *= m
+	}
+
+	if len(data) > 0 {
+		for i := len(data) - 1; i >= 0; i-- {
+			h ^= uint64(data[i]) << (8 * uint(i))
+		}
+		h *= m
+	}
+
+	h ^= h >> r
+	h *= m
+	h ^= h >> r
+	return h
+}
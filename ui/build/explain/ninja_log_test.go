@@ -0,0 +1,130 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLog(t *testing.T) {
+	entries, err := parseLog(strings.NewReader(`# ninja log v5
+0	10	1000	out/a	deadbeef
+5	20	2000	out/b	1
+invalid line
+30	40	3000	out/a	cafe
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]*LogEntry{
+		"out/a": {Output: "out/a", StartMillis: 30, EndMillis: 40, Mtime: 3000, CommandHash: 0xcafe},
+		"out/b": {Output: "out/b", StartMillis: 5, EndMillis: 20, Mtime: 2000, CommandHash: 0x1},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("parseLog() = %v, want %v", entries, want)
+	}
+
+	if _, err := parseLog(strings.NewReader("# ninja log v4\n")); err == nil {
+		t.Errorf("expected error for unsupported version")
+	}
+}
+
+// depsLog builds a version 4 ninja deps log.
+type depsLog struct {
+	bytes.Buffer
+	ids map[string]uint32
+}
+
+func newDepsLog() *depsLog {
+	l := &depsLog{ids: make(map[string]uint32)}
+	l.WriteString(depsSignature)
+	binary.Write(l, binary.LittleEndian, uint32(4))
+	return l
+}
+
+func (l *depsLog) id(path string) uint32 {
+	if id, ok := l.ids[path]; ok {
+		return id
+	}
+	id := uint32(len(l.ids))
+	l.ids[path] = id
+
+	padded := []byte(path)
+	for len(padded)%4 != 0 {
+		padded = append(padded, 0)
+	}
+	binary.Write(l, binary.LittleEndian, uint32(len(padded)+4))
+	l.Write(padded)
+	binary.Write(l, binary.LittleEndian, ^id)
+	return id
+}
+
+func (l *depsLog) deps(output string, mtime int64, inputs ...string) {
+	ids := []uint32{l.id(output)}
+	for _, input := range inputs {
+		ids = append(ids, l.id(input))
+	}
+	binary.Write(l, binary.LittleEndian, uint32(4+8+4*len(inputs))|0x80000000)
+	binary.Write(l, binary.LittleEndian, ids[0])
+	binary.Write(l, binary.LittleEndian, mtime)
+	binary.Write(l, binary.LittleEndian, ids[1:])
+}
+
+func TestParseDeps(t *testing.T) {
+	l := newDepsLog()
+	l.deps("out/a.o", 100, "a.c", "a.h")
+	l.deps("out/bb.o", 200, "bb.c", "a.h")
+	l.deps("out/a.o", 300, "a.c", "common.h")
+	// A truncated record at the end of the log is ignored.
+	l.Write([]byte{8, 0, 0, 0x80, 1})
+
+	entries, err := parseDeps(l.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]*DepsEntry{
+		"out/a.o":  {Mtime: 300, Inputs: []string{"a.c", "common.h"}},
+		"out/bb.o": {Mtime: 200, Inputs: []string{"bb.c", "a.h"}},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("parseDeps() = %v, want %v", entries, want)
+	}
+
+	if _, err := parseDeps([]byte("# ninjadeps\n\x02\x00\x00\x00")); err == nil {
+		t.Errorf("expected error for unsupported version")
+	}
+}
+
+func TestHashCommand(t *testing.T) {
+	seen := make(map[uint64]string)
+	// Cover every length of trailing bytes.
+	for _, command := range []string{"", "a", "ab", "abc", "abcd", "abcde", "abcdef", "abcdefg", "abcdefgh",
+		"abcdefghi", "echo foo > out/a"} {
+		h := HashCommand(command)
+		if other, ok := seen[h]; ok {
+			t.Errorf("HashCommand(%q) == HashCommand(%q)", command, other)
+		}
+		seen[h] = command
+		if HashCommand(command) != h {
+			t.Errorf("HashCommand(%q) is not stable", command)
+		}
+	}
+}
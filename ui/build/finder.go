@@ -100,6 +100,19 @@ func NewSourceFinder(ctx Context, config Config) (f *finder.Finder) {
 	return f
 }
 
+// WatchSourceFinder keeps the database of the source finder up to date until the context is
+// cancelled, so that later builds using the same out directory start without checking every
+// directory in the source tree.
+func WatchSourceFinder(ctx Context, config Config) {
+	f := NewSourceFinder(ctx, config)
+	defer f.Shutdown()
+
+	ctx.Println("Watching the source tree for changes to", f.DbPath)
+	if err := f.Watch(ctx.Done()); err != nil {
+		ctx.Fatalf("Could not watch the source tree: %v", err)
+	}
+}
+
 // Finds the list of Bazel-related files (BUILD, WORKSPACE and Starlark) in the tree.
 func findBazelFiles(entries finder.DirEntries) (dirNames []string, fileNames []string) {
 	matches := []string{}
@@ -0,0 +1,35 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"time"
+
+	"android/soong/ui/build/history"
+)
+
+// RecordBuildHistory appends a summary of the build that started at start to
+// the build history log in the out directory. fatal should be true if the build
+// is ending with a fatal error.
+func RecordBuildHistory(ctx Context, config Config, start time.Time, counter *history.ActionCounter, fatal bool) {
+	if ctx.Metrics == nil {
+		return
+	}
+
+	record := history.NewRecord(start, ctx.Metrics.MetricsBase(), counter, fatal)
+	if err := history.Append(config.BuildHistoryFile(), record); err != nil {
+		ctx.Verbosef("Failed to record build history: %s", err)
+	}
+}
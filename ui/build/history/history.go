@@ -0,0 +1,375 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package history maintains a log of compact records of every build run in an
+// output directory, so that builds can be compared over time to find
+// regressions.
+//
+// The log is a file of newline separated JSON records, one per build, which is
+// appended to at the end of every build and trimmed to the most recent records
+// when it grows too large.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"android/soong/shared"
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
+	"android/soong/ui/status"
+)
+
+// The number of records kept when the log is trimmed. The log is trimmed once
+// it contains twice as many records.
+const MaxRecords = 1000
+
+// The names of the phases of a build, in the order that they run.
+const (
+	PhaseSetup = "setup"
+	PhaseSoong = "soong"
+	PhaseBazel = "bazel"
+	PhaseKati  = "kati"
+	PhaseNinja = "ninja"
+)
+
+var Phases = []string{PhaseSetup, PhaseSoong, PhaseBazel, PhaseKati, PhaseNinja}
+
+// Failure categories, recorded when a build does not succeed.
+const (
+	// One or more actions run by ninja failed.
+	FailureAction = "action"
+	// The build failed before any phase started, e.g. in the configuration.
+	FailureSetup = "setup"
+)
+
+// Record is a compact summary of a single build.
+type Record struct {
+	// The start time of the build, in milliseconds since the unix epoch.
+	StartTimeMillis int64 `json:"start"`
+
+	// The targets passed to the build.
+	Targets []string `json:"targets,omitempty"`
+
+	// The total duration of the build in milliseconds.
+	DurationMillis int64 `json:"duration"`
+
+	// The duration in milliseconds of each phase of the build that ran.
+	PhaseMillis map[string]int64 `json:"phases,omitempty"`
+
+	// The number of actions that ninja ran and that failed.
+	ActionsRun    int `json:"actions_run"`
+	ActionsFailed int `json:"actions_failed,omitempty"`
+
+	// The number of actions whose outputs sbox restored from its action cache
+	// instead of running them, if the cache is enabled.
+	SboxCacheHits int64 `json:"sbox_cache_hits,omitempty"`
+
+	// The duration of the critical path of the ninja build in milliseconds.
+	CriticalPathMillis int64 `json:"critical_path,omitempty"`
+
+	// Empty if the build succeeded, otherwise the category of the failure,
+	// either one of the Failure constants or the name of the phase that failed.
+	Failure string `json:"failure,omitempty"`
+}
+
+// StartTime returns the start time of the build.
+func (r *Record) StartTime() time.Time {
+	return time.Unix(0, r.StartTimeMillis*int64(time.Millisecond))
+}
+
+// Succeeded returns true if the build succeeded.
+func (r *Record) Succeeded() bool {
+	return r.Failure == ""
+}
+
+// TargetsString returns the targets of the build as a single string.
+func (r *Record) TargetsString() string {
+	if len(r.Targets) == 0 {
+		return "<default>"
+	}
+	return strings.Join(r.Targets, " ")
+}
+
+// NewRecord creates a record from the metrics collected during a build. fatal
+// should be true if the build ended with a fatal error.
+func NewRecord(start time.Time, metrics *soong_metrics_proto.MetricsBase, counter *ActionCounter, fatal bool) *Record {
+	r := &Record{
+		StartTimeMillis: start.UnixNano() / int64(time.Millisecond),
+		DurationMillis:  time.Since(start).Milliseconds(),
+		PhaseMillis:     make(map[string]int64),
+		Targets:         metrics.GetBuildConfig().GetTargets(),
+	}
+
+	phases := map[string][]*soong_metrics_proto.PerfInfo{
+		PhaseSetup: metrics.GetSetupTools(),
+		PhaseSoong: metrics.GetSoongRuns(),
+		PhaseBazel: metrics.GetBazelRuns(),
+		PhaseKati:  metrics.GetKatiRuns(),
+		PhaseNinja: metrics.GetNinjaRuns(),
+	}
+
+	// The phase that started last is the one that was running if the build
+	// failed.
+	var lastPhase string
+	var lastStart uint64
+	for phase, perfs := range phases {
+		for _, perf := range perfs {
+			r.PhaseMillis[phase] += int64(perf.GetRealTime() / uint64(time.Millisecond))
+			if perf.GetStartTime() >= lastStart {
+				lastPhase, lastStart = phase, perf.GetStartTime()
+			}
+		}
+	}
+
+	if cp := metrics.GetCriticalPathInfo(); cp != nil {
+		r.CriticalPathMillis = int64(cp.GetCriticalPathTimeMicros() / 1000)
+	}
+
+	if counter != nil {
+		r.ActionsRun = counter.finished
+		r.ActionsFailed = counter.failed
+		r.SboxCacheHits = counter.sboxCacheHits()
+	}
+
+	if r.ActionsFailed > 0 {
+		r.Failure = FailureAction
+	} else if fatal {
+		if lastPhase == "" || lastPhase == PhaseSetup {
+			r.Failure = FailureSetup
+		} else {
+			r.Failure = lastPhase
+		}
+	}
+
+	return r
+}
+
+// Append adds a record to the log in the file at filename, creating it if
+// necessary, and trims the log if it has grown too large.
+func Append(filename string, r *Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return trim(filename, MaxRecords)
+}
+
+// trim removes all but the last max records from the log once it contains more
+// than twice as many.
+func trim(filename string, max int) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 2*max {
+		return nil
+	}
+
+	tmp := filename + ".tmp"
+	if err := ioutil.WriteFile(tmp, bytes.Join(lines[len(lines)-max:], nil), 0666); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+// Read returns all the records in the log in the file at filename, oldest
+// first. Lines that cannot be parsed are skipped. A missing file is treated as
+// an empty log.
+func Read(filename string) ([]*Record, error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parse(f)
+}
+
+func parse(r io.Reader) ([]*Record, error) {
+	var records []*Record
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// Baseline returns the most recent successful build before the last record
+// that built the same targets, or nil if there is none.
+func Baseline(records []*Record) *Record {
+	if len(records) < 2 {
+		return nil
+	}
+	last := records[len(records)-1]
+	for i := len(records) - 2; i >= 0; i-- {
+		if records[i].Succeeded() && records[i].TargetsString() == last.TargetsString() {
+			return records[i]
+		}
+	}
+	return nil
+}
+
+// Regression describes a metric that got worse between two builds.
+type Regression struct {
+	// The name of the metric, e.g. "total", "critical path", or a phase name.
+	Metric string
+
+	Before, After int64
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("%s: %s -> %s (%+.0f%%)", r.Metric, formatMillis(r.Before), formatMillis(r.After),
+		float64(r.After-r.Before)*100/float64(r.Before))
+}
+
+// Regressions compares the durations of two builds and returns the metrics
+// that increased by more than threshold, a fraction of the duration in the
+// baseline build, and by more than minMillis.
+func Regressions(baseline, r *Record, threshold float64, minMillis int64) []Regression {
+	var regressions []Regression
+	check := func(metric string, before, after int64) {
+		if before <= 0 || after-before < minMillis {
+			return
+		}
+		if float64(after-before) > threshold*float64(before) {
+			regressions = append(regressions, Regression{metric, before, after})
+		}
+	}
+
+	check("total", baseline.DurationMillis, r.DurationMillis)
+	for _, phase := range Phases {
+		check(phase, baseline.PhaseMillis[phase], r.PhaseMillis[phase])
+	}
+	check("critical path", baseline.CriticalPathMillis, r.CriticalPathMillis)
+	check("actions run", int64(baseline.ActionsRun), int64(r.ActionsRun))
+
+	return regressions
+}
+
+// Print writes a table of the records to w.
+func Print(w io.Writer, records []*Record) {
+	fmt.Fprintf(w, "%-19s %9s", "start", "total")
+	for _, phase := range Phases {
+		fmt.Fprintf(w, " %9s", phase)
+	}
+	fmt.Fprintf(w, " %9s %8s %8s %-8s %s\n", "crit path", "actions", "cached", "result", "targets")
+
+	for _, r := range records {
+		fmt.Fprintf(w, "%-19s %9s", r.StartTime().Format("2006-01-02 15:04:05"), formatMillis(r.DurationMillis))
+		for _, phase := range Phases {
+			if d, ok := r.PhaseMillis[phase]; ok {
+				fmt.Fprintf(w, " %9s", formatMillis(d))
+			} else {
+				fmt.Fprintf(w, " %9s", "-")
+			}
+		}
+		result := "ok"
+		if !r.Succeeded() {
+			result = r.Failure
+		}
+		fmt.Fprintf(w, " %9s %8d %8d %-8s %s\n", formatMillis(r.CriticalPathMillis), r.ActionsRun,
+			r.SboxCacheHits, result, r.TargetsString())
+	}
+}
+
+func formatMillis(millis int64) string {
+	return (time.Duration(millis) * time.Millisecond).Round(100 * time.Millisecond).String()
+}
+
+// ActionCounter is a StatusOutput that counts the actions run by a build.
+type ActionCounter struct {
+	finished int
+	failed   int
+
+	// The file sbox records the action cache hits of this build in, or an
+	// empty string if the cache is disabled.
+	sboxCacheHitsFile string
+}
+
+var _ status.StatusOutput = &ActionCounter{}
+
+func NewActionCounter() *ActionCounter {
+	return &ActionCounter{}
+}
+
+// CountSboxCacheHits makes the counter count the actions restored from the
+// sbox action cache from now on, using the hits file in soongOutDir that
+// sbox appends to. The hits recorded by previous builds are removed.
+func (c *ActionCounter) CountSboxCacheHits(soongOutDir string) {
+	c.sboxCacheHitsFile = shared.SboxCacheHitsFile(soongOutDir)
+	os.Remove(c.sboxCacheHitsFile)
+}
+
+func (c *ActionCounter) sboxCacheHits() int64 {
+	if c.sboxCacheHitsFile == "" {
+		return 0
+	}
+	return fileSize(c.sboxCacheHitsFile)
+}
+
+// fileSize returns the size of a file, or 0 if it doesn't exist.
+func fileSize(filename string) int64 {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+func (c *ActionCounter) StartAction(action *status.Action, counts status.Counts) {}
+
+func (c *ActionCounter) FinishAction(result status.ActionResult, counts status.Counts) {
+	c.finished++
+	if result.Error != nil {
+		c.failed++
+	}
+}
+
+func (c *ActionCounter) Message(level status.MsgLevel, message string) {}
+func (c *ActionCounter) Flush()                                        {}
+func (c *ActionCounter) Write(p []byte) (int, error)                   { return len(p), nil }
@@ -0,0 +1,195 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package history
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
+	"android/soong/ui/status"
+)
+
+func perf(start, realTime time.Duration) *soong_metrics_proto.PerfInfo {
+	return &soong_metrics_proto.PerfInfo{
+		StartTime: proto.Uint64(uint64(start)),
+		RealTime:  proto.Uint64(uint64(realTime)),
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	metrics := &soong_metrics_proto.MetricsBase{
+		BuildConfig: &soong_metrics_proto.BuildConfig{
+			Targets: []string{"droid"},
+		},
+		SetupTools: []*soong_metrics_proto.PerfInfo{perf(0, time.Second)},
+		SoongRuns:  []*soong_metrics_proto.PerfInfo{perf(time.Second, 10*time.Second)},
+		NinjaRuns:  []*soong_metrics_proto.PerfInfo{perf(11*time.Second, 5*time.Second)},
+		CriticalPathInfo: &soong_metrics_proto.CriticalPathInfo{
+			CriticalPathTimeMicros: proto.Uint64(3000000),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		fatal       bool
+		failAction  bool
+		wantFailure string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:        "failed action",
+			failAction:  true,
+			wantFailure: FailureAction,
+		},
+		{
+			name:        "fatal",
+			fatal:       true,
+			wantFailure: PhaseNinja,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			soongOutDir := t.TempDir()
+			hitsFile := filepath.Join(soongOutDir, ".sbox_cache_hits")
+			// The hits of the previous build are not counted.
+			if err := ioutil.WriteFile(hitsFile, []byte(".."), 0666); err != nil {
+				t.Fatal(err)
+			}
+			counter := NewActionCounter()
+			counter.CountSboxCacheHits(soongOutDir)
+			if err := ioutil.WriteFile(hitsFile, []byte("..."), 0666); err != nil {
+				t.Fatal(err)
+			}
+			counter.FinishAction(status.ActionResult{}, status.Counts{})
+			if tt.failAction {
+				counter.FinishAction(status.ActionResult{Error: errors.New("failed")}, status.Counts{})
+			}
+
+			r := NewRecord(time.Unix(100, 0), metrics, counter, tt.fatal)
+
+			wantPhases := map[string]int64{
+				PhaseSetup: 1000,
+				PhaseSoong: 10000,
+				PhaseNinja: 5000,
+			}
+			if !reflect.DeepEqual(r.PhaseMillis, wantPhases) {
+				t.Errorf("PhaseMillis = %v, want %v", r.PhaseMillis, wantPhases)
+			}
+			if g, w := r.TargetsString(), "droid"; g != w {
+				t.Errorf("TargetsString() = %q, want %q", g, w)
+			}
+			if g, w := r.CriticalPathMillis, int64(3000); g != w {
+				t.Errorf("CriticalPathMillis = %v, want %v", g, w)
+			}
+			if g, w := r.Failure, tt.wantFailure; g != w {
+				t.Errorf("Failure = %q, want %q", g, w)
+			}
+			if g, w := r.SboxCacheHits, int64(3); g != w {
+				t.Errorf("SboxCacheHits = %v, want %v", g, w)
+			}
+			if g, w := r.StartTimeMillis, int64(100000); g != w {
+				t.Errorf("StartTimeMillis = %v, want %v", g, w)
+			}
+		})
+	}
+}
+
+func TestAppendAndRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "out", "build_history.jsonl")
+
+	if records, err := Read(filename); err != nil || len(records) != 0 {
+		t.Fatalf("Read() of missing file = %v, %v, want empty", records, err)
+	}
+
+	for i := 0; i < 2*MaxRecords+1; i++ {
+		if err := Append(filename, &Record{StartTimeMillis: int64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := Read(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != MaxRecords {
+		t.Fatalf("got %d records after trimming, want %d", len(records), MaxRecords)
+	}
+	if g, w := records[len(records)-1].StartTimeMillis, int64(2*MaxRecords); g != w {
+		t.Errorf("last record = %v, want %v", g, w)
+	}
+}
+
+func TestParseSkipsInvalidLines(t *testing.T) {
+	records, err := parse(strings.NewReader(`{"start":1,"duration":10}
+not json
+{"start":2,"duration":20,"failure":"soong"}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Record{
+		{StartTimeMillis: 1, DurationMillis: 10},
+		{StartTimeMillis: 2, DurationMillis: 20, Failure: "soong"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("parse() = %v, want %v", records, want)
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	records := []*Record{
+		{Targets: []string{"droid"}, DurationMillis: 100000,
+			PhaseMillis: map[string]int64{PhaseSoong: 60000, PhaseNinja: 40000}},
+		{Targets: []string{"nothing"}, DurationMillis: 1000},
+		{Targets: []string{"droid"}, DurationMillis: 1000, Failure: FailureAction},
+		{Targets: []string{"droid"}, DurationMillis: 150000, CriticalPathMillis: 1000,
+			PhaseMillis: map[string]int64{PhaseSoong: 61000, PhaseNinja: 89000}},
+	}
+
+	baseline := Baseline(records)
+	if baseline != records[0] {
+		t.Fatalf("Baseline() = %v, want %v", baseline, records[0])
+	}
+
+	got := Regressions(baseline, records[len(records)-1], 0.1, 1000)
+	want := []Regression{
+		{"total", 100000, 150000},
+		{PhaseNinja, 40000, 89000},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Regressions() = %v, want %v", got, want)
+	}
+
+	if g, w := got[0].String(), "total: 1m40s -> 2m30s (+50%)"; g != w {
+		t.Errorf("String() = %q, want %q", g, w)
+	}
+}
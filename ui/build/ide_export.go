@@ -0,0 +1,71 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"android/soong/shared"
+)
+
+// ideExportDirs converts the directories given on the command line, which may be absolute or
+// relative to the top of the source tree, to directories relative to the top of the source tree.
+func ideExportDirs(top string, args []string) ([]string, error) {
+	var dirs []string
+	for _, arg := range args {
+		dir := filepath.Clean(arg)
+		if filepath.IsAbs(dir) {
+			rel, err := filepath.Rel(top, dir)
+			if err != nil {
+				return nil, err
+			}
+			dir = rel
+		}
+		if dir == ".." || strings.HasPrefix(dir, "../") {
+			return nil, fmt.Errorf("%s is outside of the source tree", arg)
+		}
+		if strings.Contains(dir, ",") {
+			return nil, fmt.Errorf("%s contains a comma", arg)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// IdeExportArgs returns the arguments to pass to NewConfig to export the given directories to IDE
+// projects. Only Soong runs, followed by ninja to build the generated sources of the exported
+// modules.
+func IdeExportArgs(ctx Context, args []string) []string {
+	dirs, err := ideExportDirs(absPath(ctx, "."), args)
+	if err != nil {
+		ctx.Fatalf("Invalid directory: %s", err)
+	}
+	return []string{"--soong-only", shared.IdeExportDirsEnv + "=" + strings.Join(dirs, ","), shared.IdeExportPhony}
+}
+
+// PrintIdeExport prints the IDE project files written by the export.
+func PrintIdeExport(ctx Context, config Config, w io.Writer) {
+	fmt.Fprintln(w, "IDE project files:")
+	for _, file := range shared.IdeExportFiles {
+		path := filepath.Join(config.IdeExportDir(), file)
+		if _, err := os.Stat(path); err == nil {
+			fmt.Fprintln(w, " ", absPath(ctx, path))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdeExportDirs(t *testing.T) {
+	testCases := []struct {
+		args []string
+		want []string
+		err  string
+	}{
+		{
+			args: []string{"frameworks/base/", "/src/top/system/core", "."},
+			want: []string{"frameworks/base", "system/core", "."},
+		},
+		{
+			args: []string{"/src/other"},
+			err:  "/src/other is outside of the source tree",
+		},
+		{
+			args: []string{"a/../../b"},
+			err:  "a/../../b is outside of the source tree",
+		},
+		{
+			args: []string{"a,b"},
+			err:  "a,b contains a comma",
+		},
+	}
+
+	for _, tc := range testCases {
+		got, err := ideExportDirs("/src/top", tc.args)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("%q: want error %q, got %v", tc.args, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", tc.args, err)
+		} else if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: want %q, got %q", tc.args, tc.want, got)
+		}
+	}
+}
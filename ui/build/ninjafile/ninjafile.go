@@ -0,0 +1,164 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ninjafile is a small scanner for the ninja files generated by Soong and Kati, that finds
+// the outputs and rules of the build statements and the Soong modules that they belong to. It
+// only understands as much of the ninja syntax as is needed to attribute outputs to modules.
+package ninjafile
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Module is the Soong module that produced a build statement.
+type Module struct {
+	Name    string
+	Variant string
+	Type    string
+}
+
+// Statement is a build statement in a ninja file.
+type Statement struct {
+	// The unescaped explicit and implicit outputs of the build statement.
+	Outputs []string
+
+	// The rule of the build statement.
+	Rule string
+
+	// The Soong module that the build statement belongs to, or the zero value if the build
+	// statement doesn't belong to a module.
+	Module Module
+}
+
+// Scan calls found for every build statement in the ninja file read from r, and returns the
+// unescaped paths of the files it includes with subninja or include. The module of each build
+// statement comes from the header comment that Blueprint writes before the build statements of
+// each module, which looks like:
+//
+//	# Module:  libfoo
+//	# Variant: android_arm64_armv8-a_shared
+//	# Type:    cc_library
+func Scan(r io.Reader, found func(Statement)) (included []string, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024*1024)
+
+	var module Module
+	var statement strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if statement.Len() > 0 || strings.HasPrefix(line, "build ") {
+			// Build statements may be wrapped over multiple lines that end with a '$'.
+			statement.WriteString(strings.TrimLeft(line, " "))
+			if trailingDollars(line)%2 == 1 {
+				s := statement.String()
+				statement.Reset()
+				statement.WriteString(s[:len(s)-1])
+				continue
+			}
+
+			outputs, rule := ParseBuildStatement(statement.String())
+			found(Statement{Outputs: outputs, Rule: rule, Module: module})
+			statement.Reset()
+			continue
+		}
+
+		if strings.HasPrefix(line, "subninja ") || strings.HasPrefix(line, "include ") {
+			// The path is the rest of the line, which may contain escaped spaces.
+			path := strings.TrimSpace(line[strings.IndexByte(line, ' '):])
+			included = append(included, Unescape(path))
+			continue
+		}
+
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		comment := strings.TrimPrefix(line, "# ")
+		if value, ok := moduleHeaderField(comment, "Module:"); ok {
+			module = Module{Name: value}
+		} else if value, ok := moduleHeaderField(comment, "Variant:"); ok && module.Name != "" {
+			module.Variant = value
+		} else if value, ok := moduleHeaderField(comment, "Type:"); ok && module.Name != "" {
+			module.Type = value
+		} else if _, ok := moduleHeaderField(comment, "Singleton:"); ok {
+			module = Module{}
+		}
+	}
+
+	return included, scanner.Err()
+}
+
+func moduleHeaderField(comment, field string) (string, bool) {
+	if !strings.HasPrefix(comment, field) {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(comment, field)), true
+}
+
+func trailingDollars(line string) int {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '$'; i-- {
+		n++
+	}
+	return n
+}
+
+// ParseBuildStatement returns the unescaped explicit and implicit outputs and the rule of a ninja
+// build statement that has been joined into a single line.
+func ParseBuildStatement(statement string) (outputs []string, rule string) {
+	statement = strings.TrimPrefix(statement, "build ")
+
+	var output strings.Builder
+	flush := func() {
+		if output.Len() > 0 && output.String() != "|" {
+			outputs = append(outputs, output.String())
+		}
+		output.Reset()
+	}
+
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		switch {
+		case c == '$' && i+1 < len(statement):
+			i++
+			output.WriteByte(statement[i])
+		case c == ' ':
+			flush()
+		case c == ':':
+			flush()
+			if fields := strings.Fields(statement[i+1:]); len(fields) > 0 {
+				rule = fields[0]
+			}
+			return outputs, rule
+		default:
+			output.WriteByte(c)
+		}
+	}
+	flush()
+	return outputs, rule
+}
+
+// Unescape removes the ninja escaping from a path.
+func Unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '$' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
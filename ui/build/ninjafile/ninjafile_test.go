@@ -0,0 +1,117 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ninjafile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBuildStatement(t *testing.T) {
+	outputs, rule := ParseBuildStatement("build out/a$ b out/c | out/d$:e: g.cc.ld in | implicit")
+	if want := []string{"out/a b", "out/c", "out/d:e"}; !reflect.DeepEqual(outputs, want) {
+		t.Errorf("outputs = %q, want %q", outputs, want)
+	}
+	if rule != "g.cc.ld" {
+		t.Errorf("rule = %q, want %q", rule, "g.cc.ld")
+	}
+}
+
+func TestScan(t *testing.T) {
+	ninja := `
+builddir = out
+subninja out/soong/build$ x.ninja
+include out/rules.ninja
+
+rule g.cc.cc
+    command = clang $in -o $out
+
+# # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # #
+# Module:  libfoo
+# Variant: android_arm64_armv8-a_shared
+# Type:    cc_library
+# Factory: android/soong/cc.LibraryFactory
+# Defined: foo/Android.bp:1:1
+
+build out/soong/.intermediates/foo/libfoo/obj/foo.o: g.cc.cc foo/foo.cpp
+    cFlags = -O2
+
+build $
+        out/soong/.intermediates/foo/libfoo/libfoo.so $
+        | out/soong/.intermediates/foo/libfoo/libfoo$ with$ space.toc: $
+        g.cc.ld out/soong/.intermediates/foo/libfoo/obj/foo.o
+
+# # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # #
+# Singleton: androidmk
+# Factory:   android/soong/android.AndroidMkSingleton
+
+build out/soong/Android.mk: phony
+
+# # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # # #
+# Module:  bar
+# Variant:
+# Type:    genrule
+# Factory: android/soong/genrule.GenRuleFactory
+# Defined: bar/Android.bp:1:1
+
+build out/soong/.intermediates/bar/gen/bar.h: g.genrule.generator bar/bar.in
+`
+
+	var statements []Statement
+	included, err := Scan(strings.NewReader(ninja), func(s Statement) {
+		statements = append(statements, s)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"out/soong/build x.ninja", "out/rules.ninja"}; !reflect.DeepEqual(included, want) {
+		t.Errorf("included = %q, want %q", included, want)
+	}
+
+	libfoo := Module{
+		Name:    "libfoo",
+		Variant: "android_arm64_armv8-a_shared",
+		Type:    "cc_library",
+	}
+	want := []Statement{
+		{
+			Outputs: []string{"out/soong/.intermediates/foo/libfoo/obj/foo.o"},
+			Rule:    "g.cc.cc",
+			Module:  libfoo,
+		},
+		{
+			Outputs: []string{
+				"out/soong/.intermediates/foo/libfoo/libfoo.so",
+				"out/soong/.intermediates/foo/libfoo/libfoo with space.toc",
+			},
+			Rule:   "g.cc.ld",
+			Module: libfoo,
+		},
+		{
+			Outputs: []string{"out/soong/Android.mk"},
+			Rule:    "phony",
+		},
+		{
+			Outputs: []string{"out/soong/.intermediates/bar/gen/bar.h"},
+			Rule:    "g.genrule.generator",
+			Module:  Module{Name: "bar", Type: "genrule"},
+		},
+	}
+	if !reflect.DeepEqual(statements, want) {
+		t.Errorf("Scan() found %+v\nwant %+v", statements, want)
+	}
+}
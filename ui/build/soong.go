@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"android/soong/ui/metrics"
 	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
@@ -195,6 +196,8 @@ func primaryBuilderInvocation(
 	allArgs = append(allArgs, environmentArgs(config, name)...)
 	allArgs = append(allArgs, "Android.bp")
 
+	unbufferedOutput, _ := shared.ParseEnvBool(os.Getenv("SOONG_UNBUFFERED_OUTPUT"))
+
 	return bootstrap.PrimaryBuilderInvocation{
 		Inputs:      []string{"Android.bp"},
 		Outputs:     []string{output},
@@ -204,7 +207,7 @@ func primaryBuilderInvocation(
 		// rebuild. The bootstrap Ninja file will change, but apparently Ninja does
 		// not consider changing the pool specified in a statement a change that's
 		// worth rebuilding for.
-		Console: os.Getenv("SOONG_UNBUFFERED_OUTPUT") == "1",
+		Console: unbufferedOutput,
 		Env:     invocationEnv,
 	}
 }
@@ -266,6 +269,10 @@ func bootstrapBlueprint(ctx Context, config Config) {
 		mainSoongBuildExtraArgs,
 		fmt.Sprintf("analyzing Android.bp files and generating ninja file at %s", config.SoongNinjaFile()),
 	)
+	// The environment registry is also written by the main invocation, declare it so that it can be
+	// depended on.
+	mainSoongBuildInvocation.Outputs = append(mainSoongBuildInvocation.Outputs,
+		config.EnvRegistryFile())
 
 	if config.BazelBuildEnabled() {
 		// Mixed builds call Bazel from soong_build and they therefore need the
@@ -291,10 +298,14 @@ func bootstrapBlueprint(ctx Context, config Config) {
 		config.ModuleGraphFile(),
 		[]string{
 			"--module_graph_file", config.ModuleGraphFile(),
+			"--module_graph_proto_file", config.ModuleGraphProtoFile(),
 			"--module_actions_file", config.ModuleActionsFile(),
 		},
 		fmt.Sprintf("generating the Soong module graph at %s", config.ModuleGraphFile()),
 	)
+	// The module graph is also written as a proto, declare it so that it can be depended on.
+	jsonModuleGraphInvocation.Outputs = append(jsonModuleGraphInvocation.Outputs,
+		config.ModuleGraphProtoFile())
 
 	queryviewDir := filepath.Join(config.SoongOutDir(), "queryview")
 	queryviewInvocation := primaryBuilderInvocation(
@@ -489,10 +500,15 @@ func runSoong(ctx Context, config Config) {
 
 	ninja("bootstrap", "bootstrap.ninja", targets...)
 
+	checkEnvironment(ctx, config)
+
 	if shouldCollectBuildSoongMetrics(config) {
 		soongBuildMetrics := loadSoongBuildMetrics(ctx, config)
 		if soongBuildMetrics != nil {
 			logSoongBuildMetrics(ctx, soongBuildMetrics)
+			if ctx.Tracer != nil {
+				ctx.Tracer.ImportSoongBuildMetrics(soongBuildMetrics)
+			}
 			if ctx.Metrics != nil {
 				ctx.Metrics.SetSoongBuildMetrics(soongBuildMetrics)
 			}
@@ -509,6 +525,7 @@ func runSoong(ctx Context, config Config) {
 
 	if config.JsonModuleGraph() {
 		distGzipFile(ctx, config, config.ModuleGraphFile(), "soong")
+		distGzipFile(ctx, config, config.ModuleGraphProtoFile(), "soong")
 	}
 }
 
@@ -561,5 +578,13 @@ func logSoongBuildMetrics(ctx Context, metrics *soong_metrics_proto.SoongBuildMe
 	ctx.Verbosef(" max heap size: %v MB", metrics.GetMaxHeapSize()/1e6)
 	ctx.Verbosef(" total allocation count: %v", metrics.GetTotalAllocCount())
 	ctx.Verbosef(" total allocation size: %v MB", metrics.GetTotalAllocSize()/1e6)
-
+	for i, moduleType := range metrics.GetModuleTypes() {
+		if i == 10 {
+			break
+		}
+		ctx.Verbosef(" module type %s: %v variants, %v cumulative, slowest %s (%v)",
+			moduleType.GetModuleType(), moduleType.GetVariants(),
+			time.Duration(moduleType.GetCumulativeTime()), moduleType.GetSlowestModule(),
+			time.Duration(moduleType.GetMaxTime()))
+	}
 }
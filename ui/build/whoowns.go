@@ -0,0 +1,138 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"android/soong/android/module_graph_proto"
+)
+
+// An outputOwner is a module variant or a singleton that writes or installs a file.
+type outputOwner struct {
+	module     string
+	variant    string
+	moduleType string
+	dir        string
+	singleton  string
+	install    bool
+}
+
+func (o outputOwner) String() string {
+	verb := "built"
+	if o.install {
+		verb = "installed"
+	}
+	if o.singleton != "" {
+		return fmt.Sprintf("%s by singleton %q", verb, o.singleton)
+	}
+	s := fmt.Sprintf("%s by module %q", verb, o.module)
+	if o.variant != "" {
+		s += fmt.Sprintf(" variant %q", o.variant)
+	}
+	return s + fmt.Sprintf(" (%s in %s)", o.moduleType, o.dir)
+}
+
+// findOutputOwners returns the modules and singletons in the module graph that write or install
+// each of the given files.
+func findOutputOwners(graph *module_graph_proto.ModuleGraph, files map[string]bool) map[string][]outputOwner {
+	owners := make(map[string][]outputOwner)
+	for _, m := range graph.Modules {
+		owner := outputOwner{module: m.Name, variant: m.Variant, moduleType: m.Type, dir: m.Dir}
+		for _, output := range m.Outputs {
+			if files[output] {
+				owners[output] = append(owners[output], owner)
+			}
+		}
+		owner.install = true
+		for _, install := range m.Installs {
+			if files[install] {
+				owners[install] = append(owners[install], owner)
+			}
+		}
+	}
+	for _, s := range graph.Singletons {
+		for _, output := range s.Outputs {
+			if files[output] {
+				owners[output] = append(owners[output], outputOwner{singleton: s.Name})
+			}
+		}
+	}
+	return owners
+}
+
+// whoOwnsPath converts a path given on the command line to the form used in the module graph,
+// which is relative to the top of the source tree.
+func whoOwnsPath(ctx Context, config Config, path string) string {
+	path = filepath.Clean(path)
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(absPath(ctx, "."), path); err == nil && !strings.HasPrefix(rel, "../") {
+			return rel
+		}
+		return path
+	}
+	// Accept paths relative to the out directory too, e.g. soong/.intermediates/...
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(config.OutDir(), path)); err == nil {
+			return filepath.Join(config.OutDir(), path)
+		}
+	}
+	return path
+}
+
+// WhoOwns prints the Soong modules and singletons that write or install each of the given files,
+// using the module graph written by soong_build. The module graph must be up to date, e.g. by
+// building the json-module-graph target first.
+func WhoOwns(ctx Context, config Config, paths []string, w io.Writer) {
+	data, err := ioutil.ReadFile(config.ModuleGraphProtoFile())
+	if err != nil {
+		ctx.Fatalf("Failed to read the module graph: %s", err)
+	}
+	graph := &module_graph_proto.ModuleGraph{}
+	if err := proto.Unmarshal(data, graph); err != nil {
+		ctx.Fatalf("Failed to parse %s: %s", config.ModuleGraphProtoFile(), err)
+	}
+
+	files := make(map[string]bool)
+	var normalized []string
+	for _, path := range paths {
+		file := whoOwnsPath(ctx, config, path)
+		files[file] = true
+		normalized = append(normalized, file)
+	}
+
+	owners := findOutputOwners(graph, files)
+	var missing []string
+	for _, file := range normalized {
+		fmt.Fprintln(w, file)
+		if len(owners[file]) == 0 {
+			fmt.Fprintln(w, "  not written by Soong, it may be a source file or written by Make")
+			missing = append(missing, file)
+		}
+		for _, owner := range owners[file] {
+			fmt.Fprintln(w, " ", owner)
+		}
+	}
+	if len(missing) > 0 {
+		ctx.Fatalf("No Soong module or singleton owns %s", strings.Join(missing, ", "))
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"reflect"
+	"testing"
+
+	"android/soong/android/module_graph_proto"
+)
+
+func TestFindOutputOwners(t *testing.T) {
+	graph := &module_graph_proto.ModuleGraph{
+		Version: 1,
+		Modules: []*module_graph_proto.Module{
+			{
+				Name:    "libfoo",
+				Variant: "android_arm64_armv8-a_shared",
+				Type:    "cc_library",
+				Dir:     "foo",
+				Outputs: []string{
+					"out/soong/.intermediates/foo/libfoo/android_arm64_armv8-a_shared/libfoo.so",
+					"out/target/product/test/system/lib64/libfoo.so",
+				},
+				Installs: []string{"out/target/product/test/system/lib64/libfoo.so"},
+			},
+			{
+				Name:     "bar",
+				Type:     "prebuilt_etc",
+				Dir:      "bar",
+				Installs: []string{"out/target/product/test/system/etc/bar"},
+			},
+		},
+		Singletons: []*module_graph_proto.Singleton{
+			{Name: "androidmk", Outputs: []string{"out/soong/Android-test.mk"}},
+		},
+	}
+
+	libfoo := outputOwner{module: "libfoo", variant: "android_arm64_armv8-a_shared", moduleType: "cc_library", dir: "foo"}
+	libfooInstall := libfoo
+	libfooInstall.install = true
+
+	files := map[string]bool{
+		"out/soong/.intermediates/foo/libfoo/android_arm64_armv8-a_shared/libfoo.so": true,
+		"out/target/product/test/system/lib64/libfoo.so":                             true,
+		"out/target/product/test/system/etc/bar":                                     true,
+		"out/soong/Android-test.mk":                                                  true,
+		"out/soong/unowned":                                                          true,
+	}
+	want := map[string][]outputOwner{
+		"out/soong/.intermediates/foo/libfoo/android_arm64_armv8-a_shared/libfoo.so": {libfoo},
+		"out/target/product/test/system/lib64/libfoo.so":                             {libfoo, libfooInstall},
+		"out/target/product/test/system/etc/bar": {
+			{module: "bar", moduleType: "prebuilt_etc", dir: "bar", install: true},
+		},
+		"out/soong/Android-test.mk": {{singleton: "androidmk"}},
+	}
+
+	got := findOutputOwners(graph, files)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want owners:\n%v\ngot:\n%v", want, got)
+	}
+
+	wantStrings := map[string]string{
+		"out/soong/.intermediates/foo/libfoo/android_arm64_armv8-a_shared/libfoo.so": `built by module "libfoo" variant "android_arm64_armv8-a_shared" (cc_library in foo)`,
+		"out/target/product/test/system/etc/bar":                                     `installed by module "bar" (prebuilt_etc in bar)`,
+		"out/soong/Android-test.mk":                                                  `built by singleton "androidmk"`,
+	}
+	for file, want := range wantStrings {
+		if s := got[file][0].String(); s != want {
+			t.Errorf("%s: want %q, got %q", file, want, s)
+		}
+	}
+}
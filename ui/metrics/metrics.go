@@ -227,12 +227,22 @@ func (m *Metrics) Dump(out string) error {
 	return shared.Save(&m.metrics, out)
 }
 
+// MetricsBase returns the metrics collected so far during the build.
+func (m *Metrics) MetricsBase() *soong_metrics_proto.MetricsBase {
+	return &m.metrics
+}
+
 // SetSoongBuildMetrics sets the metrics collected from the soong_build
 // execution.
 func (m *Metrics) SetSoongBuildMetrics(metrics *soong_metrics_proto.SoongBuildMetrics) {
 	m.metrics.SoongBuildMetrics = metrics
 }
 
+// SetCriticalPathInfo sets the critical path of the ninja build.
+func (m *Metrics) SetCriticalPathInfo(info *soong_metrics_proto.CriticalPathInfo) {
+	m.metrics.CriticalPathInfo = info
+}
+
 // A CriticalUserJourneysMetrics is a struct that contains critical user journey
 // metrics. These critical user journeys are defined under cuj/cuj.go file.
 type CriticalUserJourneysMetrics struct {
@@ -17,10 +17,26 @@ package status
 import (
 	"time"
 
+	"google.golang.org/protobuf/proto"
+
 	"android/soong/ui/logger"
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
 )
 
-func NewCriticalPath(log logger.Logger) StatusOutput {
+// Actions that take longer than this are reported as long running jobs by CriticalPathInfo.
+const longRunningJobThreshold = 30 * time.Second
+
+// CriticalPath is a StatusOutput that tracks the critical path of the build, logging it to the
+// verbose log when flushed.
+type CriticalPath interface {
+	StatusOutput
+
+	// CriticalPathInfo returns the critical path and the long running actions of the actions that
+	// have finished so far.
+	CriticalPathInfo() *soong_metrics_proto.CriticalPathInfo
+}
+
+func NewCriticalPath(log logger.Logger) CriticalPath {
 	return &criticalPath{
 		log:     log,
 		running: make(map[*Action]time.Time),
@@ -35,6 +51,9 @@ type criticalPath struct {
 	nodes   map[string]*node
 	running map[*Action]time.Time
 
+	// All nodes in the order their actions finished.
+	finished []*node
+
 	start, end time.Time
 
 	clock clock
@@ -55,6 +74,10 @@ type node struct {
 	cumulativeDuration time.Duration
 	duration           time.Duration
 	input              *node
+
+	// All the nodes that produced inputs of this node.
+	inputs []*node
+	start  time.Time
 }
 
 func (cp *criticalPath) StartAction(action *Action, counts Counts) {
@@ -71,11 +94,15 @@ func (cp *criticalPath) FinishAction(result ActionResult, counts Counts) {
 
 		// Determine the input to this edge with the longest cumulative duration
 		var criticalPathInput *node
+		var inputs []*node
 		for _, input := range result.Action.Inputs {
 			if x := cp.nodes[input]; x != nil {
 				if criticalPathInput == nil || x.cumulativeDuration > criticalPathInput.cumulativeDuration {
 					criticalPathInput = x
 				}
+				if len(inputs) == 0 || inputs[len(inputs)-1] != x {
+					inputs = append(inputs, x)
+				}
 			}
 		}
 
@@ -92,11 +119,14 @@ func (cp *criticalPath) FinishAction(result ActionResult, counts Counts) {
 			cumulativeDuration: cumulativeDuration,
 			duration:           duration,
 			input:              criticalPathInput,
+			inputs:             inputs,
+			start:              start,
 		}
 
 		for _, output := range result.Action.Outputs {
 			cp.nodes[output] = node
 		}
+		cp.finished = append(cp.finished, node)
 
 		cp.end = end
 	}
@@ -152,3 +182,71 @@ func (cp *criticalPath) criticalPath() []*node {
 
 	return criticalPath
 }
+
+// slack returns how long each finished node could have been delayed without making the critical
+// path longer.
+func (cp *criticalPath) slack(criticalTime time.Duration) map[*node]time.Duration {
+	// The latest time, relative to the start of the build with perfect parallelism, at which each
+	// node could finish without delaying the end of the critical path. An action always finishes
+	// after the actions that produced its inputs, so visiting the nodes in the reverse order that
+	// they finished visits every consumer of a node before the node itself.
+	latestFinish := make(map[*node]time.Duration, len(cp.finished))
+	for i := len(cp.finished) - 1; i >= 0; i-- {
+		n := cp.finished[i]
+		finish, ok := latestFinish[n]
+		if !ok {
+			finish = criticalTime
+			latestFinish[n] = finish
+		}
+		for _, input := range n.inputs {
+			if x, ok := latestFinish[input]; !ok || finish-n.duration < x {
+				latestFinish[input] = finish - n.duration
+			}
+		}
+	}
+
+	slack := make(map[*node]time.Duration, len(latestFinish))
+	for n, finish := range latestFinish {
+		if s := finish - n.cumulativeDuration; s > 0 {
+			slack[n] = s
+		}
+	}
+	return slack
+}
+
+func (cp *criticalPath) CriticalPathInfo() *soong_metrics_proto.CriticalPathInfo {
+	info := &soong_metrics_proto.CriticalPathInfo{}
+
+	criticalPath := cp.criticalPath()
+	if len(criticalPath) == 0 {
+		return info
+	}
+
+	criticalTime := criticalPath[0].cumulativeDuration
+	info.CriticalPathTimeMicros = proto.Uint64(uint64(criticalTime.Microseconds()))
+	if !cp.start.IsZero() {
+		info.ElapsedTimeMicros = proto.Uint64(uint64(cp.end.Sub(cp.start).Microseconds()))
+	}
+
+	slack := cp.slack(criticalTime)
+	jobInfo := func(n *node) *soong_metrics_proto.JobInfo {
+		return &soong_metrics_proto.JobInfo{
+			ElapsedTimeMicros: proto.Uint64(uint64(n.duration.Microseconds())),
+			JobDescription:    proto.String(n.action.Description),
+			StartTimeMicros:   proto.Uint64(uint64(n.start.UnixNano() / int64(time.Microsecond))),
+			SlackMicros:       proto.Uint64(uint64(slack[n].Microseconds())),
+			Outputs:           n.action.Outputs,
+		}
+	}
+
+	for i := len(criticalPath) - 1; i >= 0; i-- {
+		info.CriticalPath = append(info.CriticalPath, jobInfo(criticalPath[i]))
+	}
+	for _, n := range cp.finished {
+		if n.duration > longRunningJobThreshold {
+			info.LongRunningJobs = append(info.LongRunningJobs, jobInfo(n))
+		}
+	}
+
+	return info
+}
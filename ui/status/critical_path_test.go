@@ -164,3 +164,62 @@ func TestCriticalPath(t *testing.T) {
 		})
 	}
 }
+
+func TestCriticalPathInfo(t *testing.T) {
+	cp := &testCriticalPath{
+		criticalPath: NewCriticalPath(nil).(*criticalPath),
+		actions:      make(map[int]*Action),
+	}
+
+	//  a
+	//  |\
+	//  b c
+	//  |/
+	//  d
+	cp.start(0, 0, []string{"a"}, nil)
+	cp.finish(0, time.Second)
+	cp.start(1, time.Second, []string{"b"}, []string{"a"})
+	cp.start(2, time.Second, []string{"c"}, []string{"a"})
+	cp.finish(1, 2*time.Second)
+	cp.finish(2, 41*time.Second)
+	cp.start(3, 41*time.Second, []string{"d"}, []string{"b", "c"})
+	cp.finish(3, 42*time.Second)
+
+	info := cp.CriticalPathInfo()
+
+	if g, w := info.GetCriticalPathTimeMicros(), uint64(42*time.Second/time.Microsecond); g != w {
+		t.Errorf("critical path time = %v, want %v", g, w)
+	}
+	if g, w := info.GetElapsedTimeMicros(), uint64(42*time.Second/time.Microsecond); g != w {
+		t.Errorf("elapsed time = %v, want %v", g, w)
+	}
+
+	var descs []string
+	for _, job := range info.GetCriticalPath() {
+		descs = append(descs, job.GetJobDescription())
+		if job.GetSlackMicros() != 0 {
+			t.Errorf("slack of %q = %v, want 0", job.GetJobDescription(), job.GetSlackMicros())
+		}
+	}
+	if w := []string{"a", "c", "d"}; !reflect.DeepEqual(descs, w) {
+		t.Errorf("critical path = %v, want %v", descs, w)
+	}
+
+	slack := cp.slack(42 * time.Second)
+	for _, n := range cp.finished {
+		want := time.Duration(0)
+		if n.action.Description == "b" {
+			want = 39 * time.Second
+		}
+		if slack[n] != want {
+			t.Errorf("slack of %q = %v, want %v", n.action.Description, slack[n], want)
+		}
+	}
+
+	if g := len(info.GetLongRunningJobs()); g != 1 {
+		t.Fatalf("got %d long running jobs, want 1", g)
+	}
+	if g, w := info.GetLongRunningJobs()[0].GetJobDescription(), "c"; g != w {
+		t.Errorf("long running job = %q, want %q", g, w)
+	}
+}
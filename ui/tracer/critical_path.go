@@ -0,0 +1,67 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
+)
+
+type criticalPathArg struct {
+	SlackMicros   uint64   `json:"slack_micros"`
+	Outputs       []string `json:"outputs,omitempty"`
+	ModuleName    string   `json:"module_name,omitempty"`
+	ModuleVariant string   `json:"module_variant,omitempty"`
+	ModuleType    string   `json:"module_type,omitempty"`
+}
+
+// ImportCriticalPath overlays the critical path and the long running actions on the trace, each
+// in their own thread, so that they can be viewed alongside the actions that ran in parallel.
+func (t *tracerImpl) ImportCriticalPath(info *soong_metrics_proto.CriticalPathInfo) {
+	if len(info.GetCriticalPath()) == 0 {
+		return
+	}
+
+	t.importJobs("critical path", info.GetCriticalPath())
+	if len(info.GetLongRunningJobs()) > 0 {
+		t.importJobs("long running actions", info.GetLongRunningJobs())
+	}
+}
+
+func (t *tracerImpl) importJobs(name string, jobs []*soong_metrics_proto.JobInfo) {
+	thread := t.NewThread(name)
+
+	for _, job := range jobs {
+		name := job.GetJobDescription()
+		if job.GetModuleName() != "" {
+			name = job.GetModuleName() + ": " + name
+		}
+
+		t.writeEvent(&viewerEvent{
+			Name:  name,
+			Phase: "X",
+			Time:  job.GetStartTimeMicros(),
+			Dur:   job.GetElapsedTimeMicros(),
+			Pid:   0,
+			Tid:   uint64(thread),
+			Arg: &criticalPathArg{
+				SlackMicros:   job.GetSlackMicros(),
+				Outputs:       job.GetOutputs(),
+				ModuleName:    job.GetModuleName(),
+				ModuleVariant: job.GetModuleVariant(),
+				ModuleType:    job.GetModuleType(),
+			},
+		})
+	}
+}
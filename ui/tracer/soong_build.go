@@ -0,0 +1,76 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
+)
+
+// The number of module types listed in the arguments of each analysis pass.
+const maxAnalysisPassModuleTypes = 10
+
+type analysisPassArg struct {
+	CumulativeMicros uint64              `json:"cumulative_micros"`
+	AllocCount       uint64              `json:"alloc_count"`
+	AllocSizeBytes   uint64              `json:"alloc_size_bytes"`
+	ModuleTypes      []analysisModuleArg `json:"module_types,omitempty"`
+}
+
+type analysisModuleArg struct {
+	ModuleType       string `json:"module_type"`
+	Variants         uint32 `json:"variants"`
+	CumulativeMicros uint64 `json:"cumulative_micros"`
+	SlowestModule    string `json:"slowest_module,omitempty"`
+	MaxMicros        uint64 `json:"max_micros"`
+}
+
+// ImportSoongBuildMetrics overlays the analysis passes of soong_build, which are only profiled when
+// SOONG_PROFILE_ANALYSIS is set, on the trace in their own thread.
+func (t *tracerImpl) ImportSoongBuildMetrics(metrics *soong_metrics_proto.SoongBuildMetrics) {
+	if len(metrics.GetAnalysisPasses()) == 0 {
+		return
+	}
+
+	thread := t.NewThread("soong_build analysis")
+	for _, pass := range metrics.GetAnalysisPasses() {
+		arg := &analysisPassArg{
+			CumulativeMicros: pass.GetCumulativeTime() / 1000,
+			AllocCount:       pass.GetAllocCount(),
+			AllocSizeBytes:   pass.GetAllocSize(),
+		}
+		for i, moduleType := range pass.GetModuleTypes() {
+			if i == maxAnalysisPassModuleTypes {
+				break
+			}
+			arg.ModuleTypes = append(arg.ModuleTypes, analysisModuleArg{
+				ModuleType:       moduleType.GetModuleType(),
+				Variants:         moduleType.GetVariants(),
+				CumulativeMicros: moduleType.GetCumulativeTime() / 1000,
+				SlowestModule:    moduleType.GetSlowestModule(),
+				MaxMicros:        moduleType.GetMaxTime() / 1000,
+			})
+		}
+
+		t.writeEvent(&viewerEvent{
+			Name:  pass.GetName(),
+			Phase: "X",
+			Time:  pass.GetStartTime() / 1000,
+			Dur:   pass.GetRealTime() / 1000,
+			Pid:   0,
+			Tid:   uint64(thread),
+			Arg:   arg,
+		})
+	}
+}
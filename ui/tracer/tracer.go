@@ -31,6 +31,7 @@ import (
 	"time"
 
 	"android/soong/ui/logger"
+	soong_metrics_proto "android/soong/ui/metrics/metrics_proto"
 	"android/soong/ui/status"
 )
 
@@ -50,6 +51,9 @@ type Tracer interface {
 
 	StatusTracer() status.StatusOutput
 
+	ImportCriticalPath(info *soong_metrics_proto.CriticalPathInfo)
+	ImportSoongBuildMetrics(metrics *soong_metrics_proto.SoongBuildMetrics)
+
 	NewThread(name string) Thread
 }
 
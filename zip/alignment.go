@@ -0,0 +1,95 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zip
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/blueprint/pathtools"
+
+	"android/soong/third_party/zip"
+)
+
+// An AlignmentRule aligns the data of the uncompressed files whose names match Glob to Alignment
+// bytes. An empty Glob matches every file.
+type AlignmentRule struct {
+	Glob      string
+	Alignment int
+}
+
+// AlignmentRules is a list of AlignmentRules where the last rule that matches a file wins, so that
+// general rules can be followed by more specific ones. It can be used as a flag.Value that parses
+// rules in the form [<glob>:]<alignment>, for example "-align 4 -align 'lib/**/*.so:4096'".
+type AlignmentRules []AlignmentRule
+
+func (r *AlignmentRules) String() string {
+	var rules []string
+	for _, rule := range *r {
+		if rule.Glob == "" {
+			rules = append(rules, strconv.Itoa(rule.Alignment))
+		} else {
+			rules = append(rules, rule.Glob+":"+strconv.Itoa(rule.Alignment))
+		}
+	}
+	return strings.Join(rules, " ")
+}
+
+func (r *AlignmentRules) Set(s string) error {
+	var rule AlignmentRule
+	alignment := s
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		rule.Glob, alignment = s[:i], s[i+1:]
+	}
+
+	var err error
+	rule.Alignment, err = strconv.Atoi(alignment)
+	if err != nil || rule.Alignment < 1 || rule.Alignment > zip.MaxAlignment ||
+		rule.Alignment&(rule.Alignment-1) != 0 {
+		return fmt.Errorf("invalid alignment %q in %q, must be a power of 2 between 1 and %d",
+			alignment, s, zip.MaxAlignment)
+	}
+	if rule.Glob != "" {
+		if _, err := pathtools.Match(rule.Glob, "x"); err != nil {
+			return fmt.Errorf("invalid glob in alignment %q: %w", s, err)
+		}
+	}
+
+	*r = append(*r, rule)
+	return nil
+}
+
+// Alignment returns the alignment of the data of the file with header fh, or 0 if no rule
+// matches it. It can be passed to zip.Writer.SetAlignment.
+func (r AlignmentRules) Alignment(fh *zip.FileHeader) int {
+	for i := len(r) - 1; i >= 0; i-- {
+		if r[i].Glob == "" {
+			return r[i].Alignment
+		}
+		if match, _ := pathtools.Match(r[i].Glob, fh.Name); match {
+			return r[i].Alignment
+		}
+	}
+	return 0
+}
+
+// SetAlignment makes w align the uncompressed files that it writes according to r, if r has
+// any rules.
+func (r AlignmentRules) SetAlignment(w *zip.Writer) {
+	if len(r) > 0 {
+		w.SetAlignment(r.Alignment)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zip
+
+import (
+	"bytes"
+	"testing"
+
+	"android/soong/third_party/zip"
+)
+
+func TestAlignmentRules(t *testing.T) {
+	var rules AlignmentRules
+	for _, s := range []string{"4", "lib/**/*.so:4096", "lib/x86/*.so:16384"} {
+		if err := rules.Set(s); err != nil {
+			t.Fatalf("Set(%q): %v", s, err)
+		}
+	}
+	if got, want := rules.String(), "4 lib/**/*.so:4096 lib/x86/*.so:16384"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	for name, want := range map[string]int{
+		"classes.dex":                4,
+		"lib/arm64-v8a/libfoo.so":    4096,
+		"lib/x86/libfoo.so":          16384,
+		"assets/lib/x86/libfoo.so.1": 4,
+	} {
+		if got := rules.Alignment(&zip.FileHeader{Name: name}); got != want {
+			t.Errorf("Alignment(%q) = %d, want %d", name, got, want)
+		}
+	}
+
+	if got := (AlignmentRules{}).Alignment(&zip.FileHeader{Name: "a"}); got != 0 {
+		t.Errorf("Alignment without rules = %d, want 0", got)
+	}
+
+	for _, s := range []string{"", "3", "0", "65536", "foo:", "foo:bar", "**:4"} {
+		if err := rules.Set(s); err == nil {
+			t.Errorf("Set(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestZipAlignment(t *testing.T) {
+	args := ZipArgs{
+		FileArgs:         fileArgsBuilder().File("a/a/a").File("a/a/b").File("c").FileArgs(),
+		CompressionLevel: 9,
+		NonDeflatedFiles: map[string]bool{"a/a/a": true, "c": true},
+		Filesystem:       mockFs,
+		Stderr:           &bytes.Buffer{},
+	}
+	args.Alignment.Set("4")
+	args.Alignment.Set("c:4096")
+
+	buf := &bytes.Buffer{}
+	if err := zipTo(args, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range zr.File {
+		offset, err := f.DataOffset()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := int64(args.Alignment.Alignment(&f.FileHeader))
+		if f.Method != zip.Store {
+			continue
+		}
+		if offset%want != 0 {
+			t.Errorf("%s: data offset %d is not aligned to %d", f.Name, offset, want)
+		}
+	}
+}
@@ -173,6 +173,13 @@ func main() {
 	flags.Var(&relativeRoot{}, "C", "path to use as relative root of files in following -f, -l, or -D arguments")
 	flags.Var(&junkPaths{}, "j", "junk paths, zip files without directory names")
 
+	var alignment zip.AlignmentRules
+	flags.Var(&alignment, "align", "[<glob>:]<alignment> to align the data of uncompressed files, the last matching rule wins")
+
+	var compression zip.CompressionRules
+	flags.Var(&compression, "compress", "[<glob>:]<method> to compress files with store or deflate, the last matching rule wins. "+
+		"Files passed to -s are always stored")
+
 	flags.Parse(expandedArgs[1:])
 
 	if flags.NArg() > 0 {
@@ -224,6 +231,8 @@ func main() {
 		WriteIfChanged:           *writeIfChanged,
 		StoreSymlinks:            *symlinks,
 		IgnoreMissingFiles:       *ignoreMissingFiles,
+		Alignment:                alignment,
+		Compression:              compression,
 	})
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "error:", err.Error())
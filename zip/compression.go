@@ -0,0 +1,100 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zip
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/blueprint/pathtools"
+
+	"android/soong/third_party/zip"
+)
+
+var compressionMethods = map[string]uint16{
+	"store":   zip.Store,
+	"deflate": zip.Deflate,
+}
+
+func compressionMethodName(method uint16) string {
+	for name, m := range compressionMethods {
+		if m == method {
+			return name
+		}
+	}
+	return fmt.Sprintf("method %d", method)
+}
+
+// A CompressionRule selects the compression method of the files whose names match Glob. An empty
+// Glob matches every file.
+type CompressionRule struct {
+	Glob   string
+	Method uint16
+}
+
+// CompressionRules is a list of CompressionRules where the last rule that matches a file wins,
+// like AlignmentRules. It can be used as a flag.Value that parses rules in the form
+// [<glob>:]<method>, where method is store or deflate, for example
+// "-compress store -compress '**/*.dex:deflate'".
+type CompressionRules []CompressionRule
+
+func (r *CompressionRules) String() string {
+	var rules []string
+	for _, rule := range *r {
+		if rule.Glob == "" {
+			rules = append(rules, compressionMethodName(rule.Method))
+		} else {
+			rules = append(rules, rule.Glob+":"+compressionMethodName(rule.Method))
+		}
+	}
+	return strings.Join(rules, " ")
+}
+
+func (r *CompressionRules) Set(s string) error {
+	var rule CompressionRule
+	method := s
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		rule.Glob, method = s[:i], s[i+1:]
+	}
+
+	var ok bool
+	rule.Method, ok = compressionMethods[method]
+	if !ok {
+		return fmt.Errorf("invalid compression method %q in %q, must be store or deflate",
+			method, s)
+	}
+	if rule.Glob != "" {
+		if _, err := pathtools.Match(rule.Glob, "x"); err != nil {
+			return fmt.Errorf("invalid glob in compression %q: %w", s, err)
+		}
+	}
+
+	*r = append(*r, rule)
+	return nil
+}
+
+// Method returns the compression method of the file with the given name in the zip, and false if
+// no rule matches it.
+func (r CompressionRules) Method(name string) (uint16, bool) {
+	for i := len(r) - 1; i >= 0; i-- {
+		if r[i].Glob == "" {
+			return r[i].Method, true
+		}
+		if match, _ := pathtools.Match(r[i].Glob, name); match {
+			return r[i].Method, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Google Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zip
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"android/soong/third_party/zip"
+)
+
+func TestCompressionRules(t *testing.T) {
+	var rules CompressionRules
+	for _, s := range []string{"store", "**/*.dex:deflate", "lib/x86/*.dex:store"} {
+		if err := rules.Set(s); err != nil {
+			t.Fatalf("Set(%q): %v", s, err)
+		}
+	}
+	if got, want := rules.String(), "store **/*.dex:deflate lib/x86/*.dex:store"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	for name, want := range map[string]uint16{
+		"res/raw/a.txt":       zip.Store,
+		"a/classes.dex":       zip.Deflate,
+		"lib/x86/classes.dex": zip.Store,
+	} {
+		if got, ok := rules.Method(name); !ok || got != want {
+			t.Errorf("Method(%q) = %d, %v, want %d", name, got, ok, want)
+		}
+	}
+
+	if _, ok := (CompressionRules{}).Method("a"); ok {
+		t.Errorf("Method without rules matched")
+	}
+
+	for _, s := range []string{"", "gzip", "foo:", "foo:zip", "**:deflate", "zstd"} {
+		if err := rules.Set(s); err == nil {
+			t.Errorf("Set(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestZipCompression(t *testing.T) {
+	args := ZipArgs{
+		FileArgs:         fileArgsBuilder().File("a/a/a").File("a/a/b").File("c").FileArgs(),
+		CompressionLevel: 9,
+		NonDeflatedFiles: map[string]bool{"c": true},
+		Filesystem:       mockFs,
+		Stderr:           &bytes.Buffer{},
+	}
+	args.Compression.Set("store")
+	args.Compression.Set("a/a/b:deflate")
+	args.Compression.Set("c:deflate")
+
+	buf := &bytes.Buffer{}
+	if err := zipTo(args, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]struct {
+		method   uint16
+		contents []byte
+	}{
+		"a/a/a": {zip.Store, fileA},
+		"a/a/b": {zip.Deflate, fileB},
+		// files passed to -s are stored even if a rule matches them
+		"c": {zip.Store, fileC},
+	}
+	if len(zr.File) != len(want) {
+		t.Errorf("expected %d files, got %d", len(want), len(zr.File))
+	}
+	for _, f := range zr.File {
+		w, ok := want[f.Name]
+		if !ok {
+			t.Errorf("unexpected file %q", f.Name)
+			continue
+		}
+		if f.Method != w.method {
+			t.Errorf("%s: expected method %d, got %d", f.Name, w.method, f.Method)
+		}
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("%s: %v", f.Name, err)
+		}
+		contents, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", f.Name, err)
+		}
+		if !bytes.Equal(contents, w.contents) {
+			t.Errorf("%s: expected contents %q, got %q", f.Name, w.contents, contents)
+		}
+	}
+}
@@ -228,6 +228,7 @@ type ZipWriter struct {
 
 	followSymlinks     pathtools.ShouldFollowSymlinks
 	ignoreMissingFiles bool
+	alignment          AlignmentRules
 
 	stderr io.Writer
 	fs     pathtools.FileSystem
@@ -257,6 +258,8 @@ type ZipArgs struct {
 	WriteIfChanged           bool
 	StoreSymlinks            bool
 	IgnoreMissingFiles       bool
+	Alignment                AlignmentRules
+	Compression              CompressionRules
 
 	Stderr     io.Writer
 	Filesystem pathtools.FileSystem
@@ -278,6 +281,7 @@ func zipTo(args ZipArgs, w io.Writer) error {
 		compLevel:          args.CompressionLevel,
 		followSymlinks:     followSymlinks,
 		ignoreMissingFiles: args.IgnoreMissingFiles,
+		alignment:          args.Alignment,
 		stderr:             args.Stderr,
 		fs:                 args.Filesystem,
 	}
@@ -353,7 +357,8 @@ func zipTo(args ZipArgs, w io.Writer) error {
 			srcs = append(srcs, result.Matches...)
 		}
 		for _, src := range srcs {
-			err := fillPathPairs(fa, src, &pathMappings, args.NonDeflatedFiles, noCompression)
+			err := fillPathPairs(fa, src, &pathMappings, args.NonDeflatedFiles, args.Compression,
+				noCompression)
 			if err != nil {
 				return err
 			}
@@ -406,7 +411,7 @@ func Zip(args ZipArgs) error {
 }
 
 func fillPathPairs(fa FileArg, src string, pathMappings *[]pathMapping,
-	nonDeflatedFiles map[string]bool, noCompression bool) error {
+	nonDeflatedFiles map[string]bool, compression CompressionRules, noCompression bool) error {
 
 	var dest string
 
@@ -428,7 +433,13 @@ func fillPathPairs(fa FileArg, src string, pathMappings *[]pathMapping,
 	dest = filepath.Join(fa.PathPrefixInZip, dest)
 
 	zipMethod := zip.Deflate
-	if _, found := nonDeflatedFiles[dest]; found || noCompression {
+	if noCompression {
+		zipMethod = zip.Store
+	}
+	if method, ok := compression.Method(dest); ok {
+		zipMethod = method
+	}
+	if _, found := nonDeflatedFiles[dest]; found {
 		zipMethod = zip.Store
 	}
 	*pathMappings = append(*pathMappings,
@@ -498,6 +509,7 @@ func (z *ZipWriter) write(f io.Writer, pathMappings []pathMapping, manifest stri
 	}()
 
 	zipw := zip.NewWriter(f)
+	z.alignment.SetAlignment(zipw)
 
 	var currentWriteOpChan chan *zipEntry
 	var currentWriter io.WriteCloser